@@ -20,12 +20,14 @@ Supported providers:
   - namecheap: Requires --api-key and --username, optionally --client-ip and --sandbox
   - godaddy: Requires --api-key and --api-secret
   - thelittlehost: Requires --api-key, optionally --base-url
+  - mock: Requires --state, a local JSON/YAML file used instead of a real account
 
 Examples:
   indietool config add provider cloudflare --api-token YOUR_TOKEN --email you@example.com
   indietool config add provider porkbun --api-key YOUR_KEY --api-secret YOUR_SECRET
   indietool config add provider namecheap --api-key YOUR_KEY --username YOUR_USERNAME --client-ip 203.0.113.1
-  indietool config add provider thelittlehost --api-key tlh_YOUR_API_KEY`,
+  indietool config add provider thelittlehost --api-key tlh_YOUR_API_KEY
+  indietool config add provider mock --state ./state.yaml`,
 }
 
 func init() {
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"indietool/cli/providers"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var mockStatePath string
+
+// configAddProviderMockCmd represents the config add provider mock command
+var configAddProviderMockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Add the mock provider configuration",
+	Long: `Add the mock provider to your indietool config file.
+
+The mock provider acts as both a registrar and a DNS provider without
+touching any real account. Domains and DNS records are kept in a local
+state file (JSON when the file ends in .json, YAML otherwise), so you can
+try out workflows, record demos, or seed the file by hand for tests.
+
+The state file is created on the first change if it does not exist yet.
+Use --provider mock to target it from the dns commands.`,
+	Example: `  indietool config add provider mock --state ./state.yaml
+  indietool dns set example.com www A 192.0.2.10 --provider mock`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mockStatePath == "" {
+			return fmt.Errorf("--state is required")
+		}
+
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("config not initialized")
+		}
+
+		// Store an absolute path so the state is found from any directory
+		statePath, err := filepath.Abs(expandTildePath(mockStatePath))
+		if err != nil {
+			return fmt.Errorf("failed to resolve state path: %w", err)
+		}
+
+		cfg.Providers.Mock = &providers.MockConfig{
+			StatePath: statePath,
+			Enabled:   true,
+		}
+
+		log.Info("Successfully added and enabled mock provider configuration", "state", statePath)
+
+		return nil
+	},
+}
+
+func init() {
+	configAddProviderCmd.AddCommand(configAddProviderMockCmd)
+
+	configAddProviderMockCmd.Flags().StringVar(&mockStatePath, "state", "", "Path to the JSON/YAML state file (required)")
+
+	configAddProviderMockCmd.MarkFlagRequired("state")
+}
@@ -114,6 +114,13 @@ var debugCmd = &cobra.Command{
 			} else {
 				fmt.Printf("  TheLittleHost: not configured\n")
 			}
+
+			if mock := config.Providers.Mock; mock != nil {
+				fmt.Printf("  Mock: enabled=%v, state=%s\n",
+					mock.Enabled, mock.StatePath)
+			} else {
+				fmt.Printf("  Mock: not configured\n")
+			}
 		} else {
 			fmt.Println("Provider Configurations: none (config invalid)")
 		}
@@ -18,6 +18,7 @@ var (
 	dnsDeleteForce    bool
 	dnsDeleteType     string
 	dnsDeleteID       string
+	dnsDeleteContent  string
 )
 
 var dnsDeleteCmd = &cobra.Command{
@@ -25,12 +26,18 @@ var dnsDeleteCmd = &cobra.Command{
 	Short: "Delete DNS records by name",
 	Long: `Delete DNS records from the specified domain by record name.
 If no type is specified, all records for that name will be deleted.
-Use --id to target a specific record when multiple records have the same name.
+
+When several records match, an interactive picker lists them with their index,
+content and TTL so you can choose which ones to delete. When stdin is not a
+terminal, the confirmation prompt and picker are skipped and every matching
+record is deleted, so in scripts use --match-content or --id to target
+specific records.
 
 Examples:
   indietool dns delete example.com www A
   indietool dns delete example.com api --type CNAME
   indietool dns delete example.com test --id 123abc
+  indietool dns delete example.com @ TXT --match-content "v=spf1 -all" --force
   indietool dns delete example.com @ MX --force
   indietool dns delete example.com subdomain`,
 	Args: cobra.RangeArgs(2, 3),
@@ -38,17 +45,21 @@ Examples:
 }
 
 func init() {
-	dnsDeleteCmd.Flags().StringVar(&dnsDeleteProvider, "provider", "", "DNS provider to use (cloudflare, namecheap, porkbun, godaddy, thelittlehost)")
+	dnsDeleteCmd.Flags().StringVar(&dnsDeleteProvider, "provider", "", "DNS provider to use (cloudflare, namecheap, porkbun, godaddy, thelittlehost, mock)")
 	dnsDeleteCmd.Flags().BoolVarP(&dnsDeleteForce, "force", "f", false, "Delete without confirmation")
 	dnsDeleteCmd.Flags().StringVar(&dnsDeleteType, "type", "", "Record type filter")
 	dnsDeleteCmd.Flags().StringVar(&dnsDeleteID, "id", "", "Record ID to delete (use with --wide to find IDs)")
+	dnsDeleteCmd.Flags().StringVar(&dnsDeleteContent, "match-content", "", "Only delete records whose content matches exactly")
 
 	// Add to parent dns command
 	dnsCmd.AddCommand(dnsDeleteCmd)
 }
 
 func runDNSDelete(cmd *cobra.Command, args []string) error {
-	domain := args[0]
+	domain, err := asciiDomainArg(args[0])
+	if err != nil {
+		return err
+	}
 	name := args[1]
 
 	// Determine record type (from positional arg or flag)
@@ -67,6 +78,10 @@ func runDNSDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if dnsDeleteContent != "" {
+		recordsToDelete = filterRecordsByContent(recordsToDelete, dnsDeleteContent)
+	}
+
 	// Show DNS provider
 	if resolvedProvider != "" {
 		fmt.Printf("DNS Provider: %s\n", resolvedProvider)
@@ -81,17 +96,33 @@ func runDNSDelete(cmd *cobra.Command, args []string) error {
 		if dnsDeleteID != "" {
 			filters = append(filters, fmt.Sprintf("ID '%s'", dnsDeleteID))
 		}
+		if dnsDeleteContent != "" {
+			filters = append(filters, fmt.Sprintf("content '%s'", dnsDeleteContent))
+		}
 
 		errorMsg := fmt.Sprintf("no DNS records found for '%s'", name)
 		if len(filters) > 0 {
 			errorMsg += " with " + strings.Join(filters, " and ")
 		}
-		return fmt.Errorf(errorMsg)
+		return fmt.Errorf("%s", errorMsg)
 	}
 
-	// Show confirmation unless --force
-	if !dnsDeleteForce {
-		if !confirmDeletion(recordsToDelete) {
+	// Confirm unless --force; prompts are skipped when stdin is not a terminal
+	if !dnsDeleteForce && !stdinIsTerminal() {
+		log.Infof("stdin is not a terminal; deleting %d matching DNS %s without confirmation",
+			len(recordsToDelete), plural("record", len(recordsToDelete)))
+	} else if !dnsDeleteForce {
+		if len(recordsToDelete) > 1 {
+			selected, err := pickRecords(recordsToDelete, "delete", true)
+			if err != nil {
+				return err
+			}
+			if len(selected) == 0 {
+				fmt.Println("Delete cancelled")
+				return nil
+			}
+			recordsToDelete = selected
+		} else if !confirmDeletion(recordsToDelete[0]) {
 			fmt.Println("Delete cancelled")
 			return nil
 		}
@@ -160,44 +191,23 @@ func findRecordsForDeletion(domain, name, recordType, recordID string) ([]dns.Re
 	return matches, resolvedProvider, nil
 }
 
-func confirmDeletion(records []dns.Record) bool {
-	if len(records) == 1 {
-		record := records[0]
-		fmt.Printf("Found DNS record to delete:\n")
-		fmt.Printf("  Name: %s\n", record.Name)
-		fmt.Printf("  Type: %s\n", record.Type)
-		fmt.Printf("  Content: %s\n", record.Content)
-		fmt.Printf("  TTL: %d\n", record.TTL)
-		if record.Priority != nil {
-			fmt.Printf("  Priority: %d\n", *record.Priority)
-		}
-		if record.Proxied != nil && *record.Proxied {
-			fmt.Printf("  Proxied: Yes ☁️\n")
-		}
-		if record.ID != "" {
-			fmt.Printf("  ID: %s\n", record.ID)
-		}
-		fmt.Printf("\nDelete this record? [y/N]: ")
-	} else {
-		fmt.Printf("Found %d DNS records for '%s':\n", len(records), records[0].Name)
-		for i, record := range records {
-			proxied := ""
-			if record.Proxied != nil && *record.Proxied {
-				proxied = " ☁️"
-			}
-			priority := ""
-			if record.Priority != nil {
-				priority = fmt.Sprintf(" (Priority: %d)", *record.Priority)
-			}
-			id := ""
-			if record.ID != "" {
-				id = fmt.Sprintf(" [ID: %s]", record.ID)
-			}
-			fmt.Printf("  [%d] %s %s %s%s (TTL: %d)%s%s\n",
-				i+1, record.Name, record.Type, record.Content, proxied, record.TTL, priority, id)
-		}
-		fmt.Printf("\nDelete all %d records? [y/N]: ", len(records))
+// confirmDeletion shows a single record and asks the user to confirm its deletion
+func confirmDeletion(record dns.Record) bool {
+	fmt.Printf("Found DNS record to delete:\n")
+	fmt.Printf("  Name: %s\n", record.Name)
+	fmt.Printf("  Type: %s\n", record.Type)
+	fmt.Printf("  Content: %s\n", record.Content)
+	fmt.Printf("  TTL: %d\n", record.TTL)
+	if record.Priority != nil {
+		fmt.Printf("  Priority: %d\n", *record.Priority)
+	}
+	if record.Proxied != nil && *record.Proxied {
+		fmt.Printf("  Proxied: Yes ☁️\n")
+	}
+	if record.ID != "" {
+		fmt.Printf("  ID: %s\n", record.ID)
 	}
+	fmt.Printf("\nDelete this record? [y/N]: ")
 
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
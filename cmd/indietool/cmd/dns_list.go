@@ -26,7 +26,11 @@ Examples:
   indietool dns list example.com --json`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		domain := args[0]
+		domain, err := asciiDomainArg(args[0])
+		if err != nil {
+			handleDNSError(err)
+			return
+		}
 
 		// Get DNS manager from parent command
 		dnsManager := GetDNSManager()
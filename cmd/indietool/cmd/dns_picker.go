@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"indietool/cli/dns"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// stdinIsTerminal reports whether stdin is attached to an interactive terminal.
+// Confirmation prompts and the record picker are only shown when it is.
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// filterRecordsByContent returns the records whose content matches content exactly.
+// Surrounding quotes are ignored so TXT values match regardless of how the
+// provider returns them.
+func filterRecordsByContent(records []dns.Record, content string) []dns.Record {
+	want := strings.Trim(strings.TrimSpace(content), `"`)
+
+	var matches []dns.Record
+	for _, record := range records {
+		if strings.Trim(strings.TrimSpace(record.Content), `"`) == want {
+			matches = append(matches, record)
+		}
+	}
+	return matches
+}
+
+// formatRecordLine renders a record as a single line for prompts and pickers
+func formatRecordLine(record dns.Record) string {
+	proxied := ""
+	if record.Proxied != nil && *record.Proxied {
+		proxied = " ☁️"
+	}
+	priority := ""
+	if record.Priority != nil {
+		priority = fmt.Sprintf(" (Priority: %d)", *record.Priority)
+	}
+	id := ""
+	if record.ID != "" {
+		id = fmt.Sprintf(" [ID: %s]", record.ID)
+	}
+	return fmt.Sprintf("%s %s %s%s (TTL: %d)%s%s",
+		record.Name, record.Type, record.Content, proxied, record.TTL, priority, id)
+}
+
+// pickRecords lists records with their index and lets the user select which
+// ones to act on, or just one unless multiple is set. An empty answer selects
+// nothing, which callers treat as a cancellation.
+func pickRecords(records []dns.Record, action string, multiple bool) ([]dns.Record, error) {
+	fmt.Printf("Found %d DNS records for '%s':\n", len(records), records[0].Name)
+	for i, record := range records {
+		fmt.Printf("  [%d] %s\n", i+1, formatRecordLine(record))
+	}
+	if multiple {
+		fmt.Printf("\nSelect records to %s (e.g. 1,3 or 1-2, 'all'; empty to cancel): ", action)
+	} else {
+		fmt.Printf("\nSelect the record to %s (e.g. 2; empty to cancel): ", action)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selection: %w", err)
+	}
+	return selectRecords(records, response, action, multiple)
+}
+
+// selectRecords returns the records a picker answer selects. Without
+// multiple, selecting more than one record is an error.
+func selectRecords(records []dns.Record, response, action string, multiple bool) ([]dns.Record, error) {
+	indexes, err := parseSelection(response, len(records))
+	if err != nil {
+		return nil, err
+	}
+	if !multiple && len(indexes) > 1 {
+		return nil, fmt.Errorf("select a single record to %s, got %d", action, len(indexes))
+	}
+
+	selected := make([]dns.Record, 0, len(indexes))
+	for _, i := range indexes {
+		selected = append(selected, records[i])
+	}
+	return selected, nil
+}
+
+// parseSelection parses a picker answer such as "1,3", "2-4" or "all" into
+// sorted, de-duplicated zero-based indexes in the range [0, n).
+func parseSelection(input string, n int) ([]int, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil, nil
+	}
+
+	if input == "all" || input == "*" {
+		indexes := make([]int, n)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+
+	seen := make(map[int]bool)
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		start, end := part, part
+		if before, after, found := strings.Cut(part, "-"); found {
+			start, end = before, after
+		}
+
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		to, err := strconv.Atoi(end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		if from > to {
+			from, to = to, from
+		}
+		if from < 1 || to > n {
+			return nil, fmt.Errorf("selection %q out of range (1-%d)", part, n)
+		}
+
+		for i := from; i <= to; i++ {
+			seen[i-1] = true
+		}
+	}
+
+	indexes := make([]int, 0, len(seen))
+	for i := range seen {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"indietool/cli/dns"
+	"reflect"
+	"testing"
+)
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		n       int
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty cancels", input: "  \n", n: 3, want: nil},
+		{name: "single", input: "2\n", n: 3, want: []int{1}},
+		{name: "list", input: "1,3", n: 3, want: []int{0, 2}},
+		{name: "spaces as separators", input: "3 1", n: 3, want: []int{0, 2}},
+		{name: "range", input: "2-4", n: 5, want: []int{1, 2, 3}},
+		{name: "reversed range", input: "4-2", n: 5, want: []int{1, 2, 3}},
+		{name: "duplicates and overlaps", input: "2,2,1-3,3", n: 3, want: []int{0, 1, 2}},
+		{name: "all", input: "ALL", n: 3, want: []int{0, 1, 2}},
+		{name: "star", input: "*", n: 2, want: []int{0, 1}},
+		{name: "zero", input: "0", n: 3, wantErr: true},
+		{name: "past the end", input: "4", n: 3, wantErr: true},
+		{name: "range past the end", input: "2-9", n: 3, wantErr: true},
+		{name: "negative", input: "-1", n: 3, wantErr: true},
+		{name: "not a number", input: "two", n: 3, wantErr: true},
+		{name: "open range", input: "2-", n: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSelection(tt.input, tt.n)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseSelection(%q, %d) = %v, want an error", tt.input, tt.n, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSelection(%q, %d) = %v, %v; want %v", tt.input, tt.n, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRecordsByContent(t *testing.T) {
+	records := []dns.Record{
+		{ID: "1", Name: "@", Type: "TXT", Content: `"v=spf1 -all"`},
+		{ID: "2", Name: "@", Type: "TXT", Content: "v=spf1 include:_spf.google.com ~all"},
+		{ID: "3", Name: "@", Type: "A", Content: "203.0.113.10"},
+		{ID: "4", Name: "@", Type: "A", Content: "203.0.113.100"},
+		{ID: "5", Name: "@", Type: "TXT", Content: " v=spf1 -all "},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string // IDs
+	}{
+		{name: "quoted TXT matches unquoted filter", content: "v=spf1 -all", want: []string{"1", "5"}},
+		{name: "quoted filter matches too", content: `"v=spf1 -all"`, want: []string{"1", "5"}},
+		{name: "exact, not prefix", content: "203.0.113.10", want: []string{"3"}},
+		{name: "case sensitive", content: "V=SPF1 -ALL", want: nil},
+		{name: "no match", content: "198.51.100.1", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, record := range filterRecordsByContent(records, tt.content) {
+				got = append(got, record.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterRecordsByContent(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectRecords(t *testing.T) {
+	records := []dns.Record{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	got, err := selectRecords(records, "1,3\n", "delete", true)
+	if err != nil || len(got) != 2 || got[0].ID != "1" || got[1].ID != "3" {
+		t.Errorf("multiple selection = %+v, %v; want records 1 and 3", got, err)
+	}
+	got, err = selectRecords(records, "2\n", "update", false)
+	if err != nil || len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("single selection = %+v, %v; want record 2", got, err)
+	}
+	for _, input := range []string{"1,3", "1-2", "all"} {
+		if got, err := selectRecords(records, input, "update", false); err == nil {
+			t.Errorf("selectRecords(%q) for update = %+v, want an error", input, got)
+		}
+	}
+	if got, err := selectRecords(records, "\n", "update", false); err != nil || len(got) != 0 {
+		t.Errorf("empty answer = %+v, %v; want nothing selected", got, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"indietool/cli/dns"
 	"indietool/cli/indietool"
+	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
@@ -14,6 +15,8 @@ var (
 	dnsSetProvider string
 	dnsSetTTL      int
 	dnsSetPriority int
+	dnsSetContent  string
+	dnsSetForce    bool
 )
 
 var dnsSetCmd = &cobra.Command{
@@ -22,14 +25,23 @@ var dnsSetCmd = &cobra.Command{
 	Long: `Set or update a DNS record for a domain.
 Automatically detects the DNS provider or use --provider to specify.
 
+When several existing records share the name and type, an interactive picker
+lets you choose the one to update. In scripts, use --match-content to pick
+the record to replace, or --force to let the provider choose.
+
 Examples:
   indietool dns set example.com www A 192.168.1.1
   indietool dns set example.com @ MX "10 mail.example.com"
   indietool dns set example.com --provider cloudflare www CNAME "other.example.com"
-  indietool dns set example.com _dmarc TXT "v=DMARC1; p=reject"`,
+  indietool dns set example.com _dmarc TXT "v=DMARC1; p=reject"
+  indietool dns set example.com @ A 203.0.113.20 --match-content 203.0.113.10`,
 	Args: cobra.ExactArgs(4),
 	Run: func(cmd *cobra.Command, args []string) {
-		domain := args[0]
+		domain, err := asciiDomainArg(args[0])
+		if err != nil {
+			handleDNSError(err)
+			return
+		}
 		name := args[1]
 		recordType := args[2]
 		value := args[3]
@@ -64,6 +76,20 @@ Examples:
 			record.Priority = &dnsSetPriority
 		}
 
+		// Resolve which existing record this set replaces
+		target, cancelled, err := resolveSetTarget(dnsManager, domain, record)
+		if err != nil {
+			handleDNSError(err)
+			return
+		}
+		if cancelled {
+			fmt.Println("Set cancelled")
+			return
+		}
+		if target != nil {
+			record.ID = target.ID
+		}
+
 		// Set DNS record
 		detectionResult, err := dnsManager.SetRecord(context.TODO(), domain, dnsSetProvider, record)
 		if err != nil {
@@ -92,15 +118,75 @@ Examples:
 	},
 }
 
+// resolveSetTarget finds the existing record a set would replace. It
+// returns nil when the provider should create or update the record on its
+// own, cancelled when the user cancelled the picker, and otherwise the
+// record to update by ID.
+func resolveSetTarget(manager *dns.Manager, domain string, record dns.Record) (*dns.Record, bool, error) {
+	// Without a filter and with --force there is nothing to disambiguate
+	if dnsSetContent == "" && dnsSetForce {
+		return nil, false, nil
+	}
+
+	existing, _, err := manager.ListRecords(context.TODO(), domain, dnsSetProvider)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list existing DNS records: %w", err)
+	}
+
+	name := dns.NormalizeName(record.Name, domain)
+	var matches []dns.Record
+	for _, r := range existing {
+		if dns.NormalizeName(r.Name, domain) == name && strings.EqualFold(r.Type, record.Type) {
+			matches = append(matches, r)
+		}
+	}
+
+	if dnsSetContent != "" {
+		matches = filterRecordsByContent(matches, dnsSetContent)
+		if len(matches) == 0 {
+			return nil, false, fmt.Errorf("no %s record '%s' with content '%s' found", record.Type, name, dnsSetContent)
+		}
+	}
+
+	if len(matches) <= 1 {
+		if len(matches) == 1 && matches[0].ID != "" {
+			return &matches[0], false, nil
+		}
+		return nil, false, nil
+	}
+
+	// Several matches: --force leaves the choice to the provider, as its
+	// help says
+	if dnsSetForce {
+		return nil, false, nil
+	}
+
+	if !stdinIsTerminal() {
+		return nil, false, fmt.Errorf("%d %s records named '%s' exist: use --match-content to choose one, or --force to let the provider pick",
+			len(matches), record.Type, name)
+	}
+
+	selected, err := pickRecords(matches, "update", false)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(selected) == 0 {
+		return nil, true, nil
+	}
+	return &selected[0], false, nil
+}
+
 func init() {
 	dnsCmd.AddCommand(dnsSetCmd)
 
 	// Provider flag
-	dnsSetCmd.Flags().StringVar(&dnsSetProvider, "provider", "", "DNS provider to use (cloudflare, namecheap, porkbun, godaddy, thelittlehost)")
+	dnsSetCmd.Flags().StringVar(&dnsSetProvider, "provider", "", "DNS provider to use (cloudflare, namecheap, porkbun, godaddy, thelittlehost, mock)")
 
 	// DNS record options
 	dnsSetCmd.Flags().IntVar(&dnsSetTTL, "ttl", 300, "TTL (Time To Live) in seconds")
 	dnsSetCmd.Flags().IntVar(&dnsSetPriority, "priority", 0, "Priority for MX records (required for MX)")
+	dnsSetCmd.Flags().StringVar(&dnsSetContent, "match-content", "", "Update only the existing record whose content matches exactly")
+	dnsSetCmd.Flags().BoolVarP(&dnsSetForce, "force", "f", false, "Skip the record picker and let the provider choose which record to update")
 
 	// Mark priority as required for MX records - we'll validate this in the command
 }
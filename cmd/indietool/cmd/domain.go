@@ -4,8 +4,14 @@ Copyright © 2025
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"path/filepath"
+	"time"
 
+	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 )
 
@@ -21,13 +27,22 @@ available domain names. It supports checking individual domains or exploring a b
 name across popular TLDs.
 
 Available subcommands:
-  search   Check availability of specific domain names
-  explore  Explore a domain name across multiple popular TLDs
+  search      Check availability of specific domain names
+  explore     Explore a domain name across multiple popular TLDs
+  watch       Watch domains you want and get alerted when they drop
+  register    Buy an available domain from a configured registrar
+  tlds        Browse the TLD catalog by category and restrictions
+  lookalikes  Find registered typosquats of your domains
+  sessions    Revisit saved explore sessions
+  shortlist   Star candidate domains and export the shortlist
 
 Examples:
   indietool domain search example.com
   indietool domain explore myapp
   indietool domain explore startup --tlds com,org,dev,ai
+  indietool domain watch add coolname.com
+  indietool domain register coolname.com --provider porkbun
+  indietool domain lookalikes coolname.com --monitor
 
 The domain command also shows your current configuration status including
 enabled registrars and configuration validation results.`,
@@ -74,6 +89,129 @@ enabled registrars and configuration validation results.`,
 	},
 }
 
+// asciiDomainArg converts a domain given on the command line to its A-label
+// form, warning when an internationalized name could be mistaken for another
+func asciiDomainArg(domain string) (string, error) {
+	ascii, err := dns.ToASCII(domain)
+	if err != nil {
+		return "", err
+	}
+	for _, warning := range dns.HomographWarnings(ascii) {
+		log.Warnf("%s: %s", dns.DisplayName(ascii), warning)
+	}
+	return ascii, nil
+}
+
+// Lookup cache flags, shared by the commands checking availability
+var (
+	lookupNoCache bool
+	lookupMaxAge  time.Duration
+)
+
+// addLookupCacheFlags adds --no-cache and --max-age to a command that looks
+// up domains
+func addLookupCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&lookupNoCache, "no-cache", false, "Don't use or update the lookup cache")
+	cmd.Flags().DurationVar(&lookupMaxAge, "max-age", 0, "Only use cached results checked within this long, e.g. 10m")
+}
+
+// newLookupEngine creates a lookup engine using the on-disk cache shared by
+// search, explore and watch, unless --no-cache was given. --max-age replaces
+// options.MaxAge. Call the returned function when done to save the cache.
+func newLookupEngine(options domains.LookupOptions) (*domains.LookupEngine, func()) {
+	cfg := GetConfig()
+	if lookupNoCache || cfg == nil {
+		return domains.NewLookupEngine(options), func() {}
+	}
+
+	dir := expandTildePath(cfg.GetDomainCacheDir())
+	options.BootstrapCacheDir = filepath.Join(dir, domains.BootstrapCacheDir)
+	cache, err := domains.LoadResultCache(dir)
+	if err != nil {
+		log.Warnf("Not using cached lookups: %v", err)
+		return domains.NewLookupEngine(options), func() {}
+	}
+	options.Cache = cache
+	if lookupMaxAge > 0 {
+		options.MaxAge = lookupMaxAge
+	}
+
+	return domains.NewLookupEngine(options), func() {
+		if err := cache.Save(); err != nil {
+			log.Warnf("Failed to save the lookup cache: %v", err)
+		}
+	}
+}
+
+// checkSortBy validates --sort-by, which only knows price
+func checkSortBy(sortBy string, prices bool) error {
+	switch {
+	case sortBy == "":
+		return nil
+	case sortBy != "price":
+		return fmt.Errorf("invalid --sort-by %q (use price)", sortBy)
+	case !prices:
+		return fmt.Errorf("--sort-by price needs --prices")
+	}
+	return nil
+}
+
+// lookupPrices prices the available domains in results at the configured
+// registrars, using the cached price lists unless --no-cache was given.
+// quoteNames asks for a quote on every name rather than only those no price
+// list covers.
+func lookupPrices(ctx context.Context, results []domains.DomainSearchResult, quoteNames bool) map[string]*domains.PriceQuote {
+	var available []string
+	for _, result := range results {
+		if result.Available && result.Error == "" {
+			available = append(available, result.Domain)
+		}
+	}
+	if len(available) == 0 {
+		return nil
+	}
+
+	manager, err := newDomainManager()
+	if err != nil {
+		log.Warnf("Can't look up prices: %v", err)
+		return nil
+	}
+
+	path := ""
+	table := &domains.PriceTable{Registrars: make(map[string]domains.RegistrarPrices)}
+	if cfg := GetConfig(); cfg != nil && !lookupNoCache {
+		path = expandTildePath(cfg.GetDomainPriceTablePath())
+		if table, err = domains.LoadPriceTable(path); err != nil {
+			log.Warnf("Not using cached prices: %v", err)
+			table = &domains.PriceTable{Registrars: make(map[string]domains.RegistrarPrices)}
+		}
+	}
+
+	quotes, errs := manager.PriceDomains(ctx, table, available, domains.PricingOptions{Refresh: lookupNoCache, QuoteNames: quoteNames}, time.Now())
+	for registrar, err := range errs {
+		log.Warnf("Failed to get prices from %s: %v", registrar, err)
+	}
+	mixed := 0
+	for _, quote := range quotes {
+		if quote.MixedCurrency {
+			mixed++
+		}
+	}
+	if mixed > 0 {
+		log.Warnf("Registrars priced %d domains in more than one currency; only offers in the most common currency were compared (see offers in --json)", mixed)
+	}
+	if len(quotes) == 0 && len(errs) == 0 {
+		log.Warnf("None of the configured registrars publish prices (supported: porkbun, namecheap, godaddy)")
+	}
+
+	if path != "" {
+		if err := table.Save(path); err != nil {
+			log.Warnf("Failed to save the price table: %v", err)
+		}
+	}
+	return quotes
+}
+
 func init() {
 	rootCmd.AddCommand(domainCmd)
 
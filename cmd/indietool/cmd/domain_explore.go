@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"indietool/cli/domains"
 	"indietool/cli/output"
 	"os"
+	"os/signal"
 	"strings"
 
+	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
@@ -15,6 +19,27 @@ var (
 	exploreWide      bool
 	exploreNoColor   bool
 	exploreNoHeaders bool
+
+	// Name generation
+	exploreAffixes  bool
+	explorePrefixes string
+	exploreSuffixes string
+	exploreWords    string
+	exploreCombine  bool
+	explorePlurals  bool
+	exploreHyphens  bool
+	exploreMax      int
+
+	exploreConcurrency int
+	explorePrices      bool
+	exploreQuote       bool
+	exploreSortBy      string
+
+	exploreCategories        []string
+	explorePriceBands        []string
+	exploreExcludeRestricted bool
+
+	exploreSession string
 )
 
 // exploreCmd represents the explore command
@@ -29,8 +54,50 @@ popular TLDs favored by indie hackers, startups, and developers. By default,
 it checks popular extensions like .com, .org, .dev, .io, .co, and more.
 
 You can customize the TLD list using the --tlds flag with a comma-separated list
-or reference a file containing TLDs. The command automatically extracts the base
-domain name if you provide a full domain.
+or reference a file containing TLDs, or pick TLDs by category from the TLD
+catalog (see 'indietool domain tlds'). The command automatically extracts the
+base domain name if you provide a full domain.
+
+TLD selection:
+  --category            TLD categories to explore: generic, country, sponsored,
+                        brand, tech (comma-separated); narrows --tlds if given
+  --price-band          Only TLDs in these price bands: budget, standard,
+                        premium, unknown (most TLDs have no band yet)
+  --exclude-restricted  Skip TLDs with residency or eligibility rules and
+                        closed brand TLDs
+
+Name generation builds more candidate names from the base name, each checked
+against every TLD:
+  --affixes     Add common prefixes and suffixes (get-, try-, -app, -hq, ...)
+  --prefixes    Prefixes to add, comma-separated or @filename
+  --suffixes    Suffixes to add, comma-separated or @filename
+  --words       Words to combine with the base name, comma-separated or @filename
+  --combine     Also combine the --words with each other in pairs
+  --plurals     Add plural forms
+  --hyphens     Add hyphenated forms of multi-word names
+  --max         Maximum number of domains to check (default 250)
+
+Generated names are ranked by length and TLD preference (the order of --tlds),
+best first; when there are more than --max candidates the best are checked.
+
+Pricing:
+  --prices         Show the cheapest configured registrar for each available
+                   domain with its first-year and renewal price; premium
+                   names are flagged
+  --quote-names    Also quote every name at registrars that price names
+                   individually, which finds premium names at the cost of
+                   one API call per name and registrar
+  --sort-by price  List available domains cheapest first
+
+Price lists come from registrars that publish them (Porkbun, Namecheap) and
+are cached for a day. Names whose TLD no price list covers are quoted one by
+one by registrars that price names individually (GoDaddy, and Namecheap for
+premium names). Prices in different currencies are not compared.
+
+Sessions:
+  --session     Save the results under a name and mark the names whose
+                availability changed since the session's previous run; see
+                'indietool domain sessions' and 'indietool domain shortlist'
 
 Output options:
   --tlds        Comma-separated list of TLDs or @filename for file input
@@ -44,8 +111,13 @@ Examples:
   indietool domain explore kopitiam.dev
   indietool domain explore mycompany --json
   indietool domain explore startup --tlds com,org,dev,ai
+  indietool domain explore startup --category tech --exclude-restricted
   indietool domain explore webapp --tlds @tlds.txt
-  indietool domain explore myapp --wide --no-color`,
+  indietool domain explore myapp --wide --no-color
+  indietool domain explore kopi --affixes --tlds com,io,dev
+  indietool domain explore kopi --words cat,shop,bar --hyphens --plurals
+  indietool domain explore kopi --prices --sort-by price
+  indietool domain explore kopi --affixes --session kopi`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		input := strings.TrimSpace(strings.ToLower(args[0]))
@@ -54,31 +126,64 @@ Examples:
 			os.Exit(1)
 		}
 
+		if _, err := asciiDomainArg(input); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
 		// Extract base domain name (remove TLD if present)
 		baseDomain := domains.ExtractBaseDomain(input)
 
 		// Determine which TLDs to use
-		tlds := domains.PopularTLDs
-		if customTLDs != "" {
-			var err error
-			tlds, err = domains.ParseTLDs(customTLDs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing TLDs: %v\n", err)
-				os.Exit(1)
-			}
+		tlds, err := exploreTLDs()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := checkSortBy(exploreSortBy, explorePrices); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		generator, generating, err := exploreNameGenerator()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
 		// Generate domains to check
 		domainList := make([]string, 0, len(tlds))
-		for _, tld := range tlds {
-			domainList = append(domainList, baseDomain+"."+tld)
+		if generating {
+			candidates, skipped := domains.GenerateCandidates(baseDomain, tlds, generator)
+			for _, candidate := range candidates {
+				domainList = append(domainList, candidate.Domain)
+			}
+			if skipped > 0 {
+				log.Warnf("Checking the best %d of %d candidates; raise --max to check more", len(candidates), len(candidates)+skipped)
+			}
+		} else {
+			for _, tld := range tlds {
+				domainList = append(domainList, baseDomain+"."+tld)
+			}
 		}
 
-		// Search all domains concurrently
-		results := domains.SearchDomainsConcurrent(domainList)
+		// Search all domains, stopping early on Ctrl-C with the partial results
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		engine, saveCache := newLookupEngine(domains.LookupOptions{
+			Workers:  exploreConcurrency,
+			Progress: exploreProgress(len(domainList)),
+		})
+		results := engine.Search(ctx, domainList)
+		stop()
+		saveCache()
 
 		// Organize results
 		exploreResult := domains.OrganizeExploreResults(baseDomain, results)
+		if generating {
+			exploreResult.Rank(tlds)
+		}
 
 		// Determine output format and render table
 		format := domains.GetOutputFormat(jsonOutput, exploreWide)
@@ -90,6 +195,28 @@ Examples:
 
 		// Convert results to table rows and render
 		rows := exploreResult.ConvertToTableRows()
+		var quotes map[string]*domains.PriceQuote
+		if explorePrices {
+			quotes = lookupPrices(context.Background(), results, exploreQuote)
+			tableConfig = domains.WithPriceColumns(tableConfig)
+			domains.AddPricesToRows(rows, quotes)
+			if exploreSortBy == "price" {
+				domains.SortRowsByPrice(rows)
+			}
+		}
+
+		var session *domains.ExploreSession
+		var changes map[string]domains.SessionChange
+		if exploreSession != "" {
+			session, changes, err = recordExploreSession(exploreSession, baseDomain, results, quotes)
+			if err != nil {
+				log.Warnf("Failed to save session %s: %v", exploreSession, err)
+			} else {
+				tableConfig = domains.WithChangeColumn(tableConfig)
+				domains.AddChangesToRows(rows, changes)
+			}
+		}
+
 		table := output.NewTable(tableConfig, options)
 		table.AddRows(rows)
 
@@ -97,14 +224,134 @@ Examples:
 			fmt.Fprintf(os.Stderr, "Error rendering table: %v\n", err)
 			os.Exit(1)
 		}
+		if session != nil && !jsonOutput {
+			printSessionChanges(session, changes)
+		}
 	},
 }
 
+// exploreTLDs returns the TLDs to explore: --tlds, the catalog's TLDs in
+// the --category given, or the popular ones, less restricted TLDs and those
+// outside --price-band when asked
+func exploreTLDs() ([]string, error) {
+	categories, err := domains.ParseTLDCategories(exploreCategories)
+	if err != nil {
+		return nil, err
+	}
+	bands, err := domains.ParsePriceBands(explorePriceBands)
+	if err != nil {
+		return nil, err
+	}
+	filter := domains.TLDFilter{Categories: categories, PriceBands: bands, ExcludeRestricted: exploreExcludeRestricted}
+	catalog := loadTLDCatalog()
+
+	var tlds []string
+	switch {
+	case customTLDs != "":
+		tlds, err = domains.ParseTLDs(customTLDs)
+		if err != nil {
+			return nil, fmt.Errorf("parsing TLDs: %w", err)
+		}
+		tlds = catalog.Filter(tlds, filter)
+	case len(categories) > 0:
+		tlds = catalog.Select(filter)
+	default:
+		tlds = catalog.Filter(domains.PopularTLDs, filter)
+	}
+
+	if len(tlds) == 0 {
+		return nil, fmt.Errorf("no TLDs left to explore; loosen --category, --price-band or --exclude-restricted")
+	}
+	return tlds, nil
+}
+
+// exploreProgress returns a progress callback that keeps a live count on
+// stderr, or nil when stderr isn't a terminal or the output is JSON
+func exploreProgress(total int) func(domains.LookupProgress) {
+	if jsonOutput || total < 2 || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+	available := 0
+	return func(p domains.LookupProgress) {
+		if p.Result.Available {
+			available++
+		}
+		fmt.Fprintf(os.Stderr, "\rChecked %d/%d domains, %d available", p.Done, p.Total, available)
+		if p.Done == p.Total {
+			// Clear the line before the table is printed
+			fmt.Fprint(os.Stderr, "\r\033[K")
+		}
+	}
+}
+
+// exploreNameGenerator builds the name generator options from the flags and
+// reports whether any generation mode was requested
+func exploreNameGenerator() (domains.NameGeneratorOptions, bool, error) {
+	options := domains.NameGeneratorOptions{
+		Combine:       exploreCombine,
+		Plurals:       explorePlurals,
+		Hyphens:       exploreHyphens,
+		MaxCandidates: exploreMax,
+	}
+
+	if exploreAffixes {
+		options.Prefixes = domains.DefaultPrefixes
+		options.Suffixes = domains.DefaultSuffixes
+	}
+	lists := []struct {
+		flag   string
+		value  string
+		target *[]string
+	}{
+		{"--prefixes", explorePrefixes, &options.Prefixes},
+		{"--suffixes", exploreSuffixes, &options.Suffixes},
+		{"--words", exploreWords, &options.Words},
+	}
+	for _, list := range lists {
+		if list.value == "" {
+			continue
+		}
+		words, err := domains.ParseWordList(list.value)
+		if err != nil {
+			return options, false, fmt.Errorf("invalid %s: %w", list.flag, err)
+		}
+		*list.target = words
+	}
+
+	if exploreCombine && len(options.Words) < 2 {
+		return options, false, fmt.Errorf("--combine needs at least two --words")
+	}
+
+	generating := len(options.Prefixes) > 0 || len(options.Suffixes) > 0 || len(options.Words) > 0 ||
+		exploreCombine || explorePlurals || exploreHyphens
+	return options, generating, nil
+}
+
 func init() {
 	domainCmd.AddCommand(exploreCmd)
 
+	exploreCmd.Flags().StringSliceVar(&exploreCategories, "category", nil, "Explore the catalog's TLDs in these categories (generic, country, sponsored, brand, tech)")
+	exploreCmd.Flags().StringSliceVar(&explorePriceBands, "price-band", nil, "Only explore TLDs in these price bands (budget, standard, premium, unknown)")
+	exploreCmd.Flags().BoolVar(&exploreExcludeRestricted, "exclude-restricted", false, "Skip TLDs with residency or eligibility rules and closed TLDs")
 	exploreCmd.Flags().StringVar(&customTLDs, "tlds", "", "Comma-separated list of TLDs or @filename for file input")
 
+	// Name generation flags
+	exploreCmd.Flags().BoolVar(&exploreAffixes, "affixes", false, "Add common prefixes and suffixes (get, try, use, go, my, join; app, hq, labs, hub, kit, ly)")
+	exploreCmd.Flags().StringVar(&explorePrefixes, "prefixes", "", "Prefixes to add, comma-separated or @filename")
+	exploreCmd.Flags().StringVar(&exploreSuffixes, "suffixes", "", "Suffixes to add, comma-separated or @filename")
+	exploreCmd.Flags().StringVar(&exploreWords, "words", "", "Words to combine with the base name, comma-separated or @filename")
+	exploreCmd.Flags().BoolVar(&exploreCombine, "combine", false, "Also combine the --words with each other in pairs")
+	exploreCmd.Flags().BoolVar(&explorePlurals, "plurals", false, "Add plural forms of the names")
+	exploreCmd.Flags().BoolVar(&exploreHyphens, "hyphens", false, "Add hyphenated forms of multi-word names")
+	exploreCmd.Flags().IntVar(&exploreMax, "max", domains.DefaultMaxCandidates, "Maximum number of domains to check")
+
+	exploreCmd.Flags().IntVar(&exploreConcurrency, "concurrency", domains.DefaultLookupWorkers, "Number of domains to check at once")
+	addLookupCacheFlags(exploreCmd)
+	exploreCmd.Flags().BoolVar(&explorePrices, "prices", false, "Show the cheapest registrar and price for available domains")
+	exploreCmd.Flags().BoolVar(&exploreQuote, "quote-names", false, "Quote every available name at registrars that price names individually, to find premium names")
+	exploreCmd.Flags().StringVar(&exploreSortBy, "sort-by", "", "Sort available domains by price (needs --prices)")
+	exploreCmd.Flags().StringVar(&exploreSession, "session", "", "Save the results in this named session and show what changed since its last run")
+
 	// Output format flags (consistent with domains list command)
 	exploreCmd.Flags().BoolVarP(&exploreWide, "wide", "w", false, "Show additional columns (cost, expiry, error details)")
 	exploreCmd.Flags().BoolVar(&exploreNoHeaders, "no-headers", false, "Don't show column headers")
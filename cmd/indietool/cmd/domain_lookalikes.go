@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/indietool/notify"
+	"indietool/cli/output"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lookalikesKinds   []string
+	lookalikesTLDs    []string
+	lookalikesAll     bool
+	lookalikesMonitor bool
+	lookalikesDryRun  bool
+)
+
+// lookalikeResult is one lookalike after its lookup
+type lookalikeResult struct {
+	Domain       string                `json:"domain"`
+	Kind         domains.LookalikeKind `json:"kind"`
+	Status       string                `json:"status"` // registered, available or unknown
+	CreationDate *time.Time            `json:"creation_date,omitempty"`
+	Nameservers  []string              `json:"nameservers,omitempty"`
+	Alert        string                `json:"alert,omitempty"` // sent, failed, printed or pending (dry run); --monitor only
+	AlertSinks   []string              `json:"alert_sinks,omitempty"`
+	AlertError   string                `json:"alert_error,omitempty"`
+	Error        string                `json:"error,omitempty"`
+}
+
+// lookalikesTableConfig defines the table layout for `domain lookalikes`
+var lookalikesTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Formatter: domains.DomainNameFormatter, Required: true},
+		{Name: "KIND", JSONPath: "kind", Required: true},
+		{Name: "STATUS", JSONPath: "status", Required: true},
+		{Name: "CREATED", JSONPath: "creation_date", Formatter: domains.DateFormatter, Required: true},
+		{Name: "NAMESERVERS", JSONPath: "nameservers", Formatter: tldListFormatter, Required: true},
+	},
+}
+
+// lookalikesMonitorTableConfig adds the alert state for --monitor
+var lookalikesMonitorTableConfig = output.TableConfig{
+	DefaultColumns: append(append([]output.Column{}, lookalikesTableConfig.DefaultColumns...),
+		output.Column{Name: "ALERT", JSONPath: "alert", Formatter: emptyAsDashFormatter, Required: true}),
+}
+
+var lookalikesCmd = &cobra.Command{
+	Use:   "lookalikes <domain>",
+	Short: "Find registered typosquats and lookalikes of a domain",
+	Long: `Generate domains that could be mistaken for yours and look up which of
+them are registered, with their creation date and nameservers.
+
+Kinds of lookalikes:
+  omission       a character left out (kpi.com)
+  transposition  neighbouring characters swapped (okpi.com)
+  homoglyph      characters that look alike, in ASCII (k0pi.com) or other
+                 scripts (kоpi.com with a Cyrillic о)
+  bitsquatting   one bit flipped in a character (copi.com)
+  tld-swap       the same name under another TLD (kopi.co)
+  hyphenation    a hyphen added or removed (ko-pi.com)
+
+With --monitor the registered lookalikes are remembered, and lookalikes
+seen available on an earlier run that are now registered alert through the
+notification sinks (see 'indietool domains notify'). The first run records
+the lookalikes that are already registered without alerting, as are those
+found registered after their earlier lookups failed. Run it daily from cron.
+
+Examples:
+  indietool domain lookalikes kopi.com
+  indietool domain lookalikes kopi.com --kinds homoglyph,omission --all
+  indietool domain lookalikes kopi.com --kinds tld-swap --tlds net,org,io
+  indietool domain lookalikes kopi.com --monitor`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+		if lookalikesDryRun && !lookalikesMonitor {
+			return fmt.Errorf("--dry-run only applies with --monitor")
+		}
+
+		kinds, err := domains.ParseLookalikeKinds(lookalikesKinds)
+		if err != nil {
+			return err
+		}
+		domain, err := asciiDomainArg(strings.TrimSpace(strings.ToLower(args[0])))
+		if err != nil {
+			return err
+		}
+		lookalikes, err := domains.GenerateLookalikes(domain, domains.LookalikeOptions{Kinds: kinds, TLDs: lookalikesTLDs})
+		if err != nil {
+			return err
+		}
+		if len(lookalikes) == 0 {
+			return fmt.Errorf("no lookalikes of %s to check", dns.DisplayName(domain))
+		}
+
+		names := make([]string, len(lookalikes))
+		for i, lookalike := range lookalikes {
+			names[i] = lookalike.Domain
+		}
+
+		options := domains.LookupOptions{Progress: exploreProgress(len(names))}
+		if lookalikesMonitor {
+			// New registrations need noticing on the next run
+			options.MaxAge = time.Hour
+		}
+		engine, saveCache := newLookupEngine(options)
+		searchResults := engine.Search(context.Background(), names)
+		saveCache()
+
+		results := make([]lookalikeResult, len(searchResults))
+		for i, searchResult := range searchResults {
+			res := lookalikeResult{
+				Domain:       searchResult.Domain,
+				Kind:         lookalikes[i].Kind,
+				CreationDate: searchResult.CreationDate,
+				Nameservers:  searchResult.Nameservers,
+				Error:        searchResult.Error,
+			}
+			switch domains.ClassifyWatchStatus(searchResult) {
+			case domains.WatchAvailable:
+				res.Status = "available"
+			case domains.WatchUnknown:
+				res.Status = "unknown"
+			default:
+				res.Status = "registered"
+			}
+			results[i] = res
+		}
+
+		failed := 0
+		if lookalikesMonitor {
+			failed, err = monitorLookalikes(cfg.Notifications, expandTildePath(cfg.GetDomainLookalikesPath()), domain, lookalikes, searchResults, results)
+			if err != nil {
+				return err
+			}
+		}
+
+		registered, unknown := 0, 0
+		shown := make([]lookalikeResult, 0, len(results))
+		for _, res := range results {
+			switch res.Status {
+			case "registered":
+				registered++
+			case "unknown":
+				unknown++
+			}
+			if lookalikesAll || res.Status == "registered" {
+				shown = append(shown, res)
+			}
+		}
+
+		if jsonOutput || len(shown) > 0 {
+			config := lookalikesTableConfig
+			if lookalikesMonitor {
+				config = lookalikesMonitorTableConfig
+			}
+			format := output.FormatTable
+			if jsonOutput {
+				format = output.FormatJSON
+			}
+			table := output.NewTable(config, output.TableOptions{Format: format, Writer: os.Stdout})
+			table.AddRows(shown)
+			if err := table.Render(); err != nil {
+				return err
+			}
+		}
+		if !jsonOutput {
+			if len(shown) > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("Checked %d lookalikes of %s: %d registered", len(results), dns.DisplayName(domain), registered)
+			if unknown > 0 {
+				fmt.Printf(", %d couldn't be checked", unknown)
+			}
+			fmt.Println(".")
+		}
+
+		if lookalikesDryRun && !jsonOutput {
+			fmt.Println("\nDry run: no alerts were sent and nothing was recorded.")
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d alert(s) could not be delivered and will be retried on the next run", failed)
+		}
+		return nil
+	},
+}
+
+// monitorLookalikes records the registered lookalikes of domain and alerts
+// on the ones registered since the last run, filling in the alert state of
+// results. It returns how many alerts couldn't be delivered.
+func monitorLookalikes(notifications notify.Config, path, domain string, lookalikes []domains.Lookalike, searchResults []domains.DomainSearchResult, results []lookalikeResult) (int, error) {
+	sinks, err := notify.NewSinks(notifications)
+	if err != nil {
+		return 0, fmt.Errorf("invalid notification config: %w", err)
+	}
+	monitor, err := domains.LoadLookalikeMonitor(path)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	watch := monitor.Watch(domain)
+	baseline := watch.CheckedAt == nil
+	watch.Update(lookalikes, searchResults, now)
+
+	failed := 0
+	for i := range results {
+		res := &results[i]
+		sighting, ok := watch.Registered[res.Domain]
+		if !ok || !sighting.AlertDue() {
+			continue
+		}
+
+		switch {
+		case lookalikesDryRun:
+			res.Alert = "pending"
+		case len(sinks) == 0:
+			res.Alert = "printed"
+			sighting.MarkAlerted(now)
+		default:
+			delivered, err := notify.Dispatch(context.Background(), sinks, sighting.Notification(domain))
+			res.AlertSinks = delivered
+			if err != nil {
+				res.AlertError = err.Error()
+				log.Warnf("Failed to deliver alert for %s: %v", sighting.Domain, err)
+			}
+			// Delivered to at least one sink counts; otherwise retry next run
+			if len(delivered) > 0 {
+				res.Alert = "sent"
+				sighting.MarkAlerted(now)
+			} else {
+				res.Alert = "failed"
+				failed++
+			}
+		}
+	}
+
+	if lookalikesDryRun {
+		return failed, nil
+	}
+	if err := monitor.Save(path); err != nil {
+		return failed, fmt.Errorf("failed to save lookalike monitor: %w", err)
+	}
+	if baseline && watch.CheckedAt != nil && !jsonOutput {
+		fmt.Printf("First check of %s: recorded %d registered lookalikes as the baseline; only new registrations will alert.\n",
+			dns.DisplayName(domain), len(watch.Registered))
+		if len(watch.Unknown) > 0 {
+			fmt.Printf("%d lookalikes couldn't be checked; they will only alert once seen available and then registered.\n", len(watch.Unknown))
+		}
+		fmt.Println()
+	}
+	return failed, nil
+}
+
+func init() {
+	domainCmd.AddCommand(lookalikesCmd)
+
+	lookalikesCmd.Flags().StringSliceVar(&lookalikesKinds, "kinds", nil, "Kinds of lookalikes to check (default: all)")
+	lookalikesCmd.Flags().StringSliceVar(&lookalikesTLDs, "tlds", nil, "TLDs for tld-swap lookalikes (default: popular TLDs)")
+	lookalikesCmd.Flags().BoolVarP(&lookalikesAll, "all", "a", false, "Show available and unchecked lookalikes too")
+	lookalikesCmd.Flags().BoolVar(&lookalikesMonitor, "monitor", false, "Remember registered lookalikes and alert on new ones")
+	lookalikesCmd.Flags().BoolVar(&lookalikesDryRun, "dry-run", false, "With --monitor, check without sending alerts or saving the results")
+	addLookupCacheFlags(lookalikesCmd)
+}
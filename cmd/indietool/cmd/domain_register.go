@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registerProvider    string
+	registerYears       int
+	registerContact     string
+	registerNameservers []string
+	registerYes         bool
+	registerAutoRenew   bool
+)
+
+var registerCmd = &cobra.Command{
+	Use:   "register <domain> --provider <provider>",
+	Short: "Buy an available domain from a configured registrar",
+	Long: `Register an available domain with one of your configured registrars.
+
+Before anything is bought, indietool checks the domain is still available
+and shows the price, the contact it will be registered to, the
+nameservers it will use and whether it will auto-renew. You then confirm by
+typing the domain name; use --yes to confirm up front when stdin is not a
+terminal.
+
+Contacts come from named profiles in the config file:
+
+  domains:
+    contacts:
+      default:
+        first_name: Jane
+        last_name: Doe
+        email: jane@example.com
+        phone: "+1.5555550100"
+        address1: 1 Main St
+        city: Springfield
+        state: IL
+        postal_code: "62701"
+        country: US
+
+Porkbun always uses your account's default contact and registers for one
+year. Set sandbox: true for Namecheap or environment: ote for GoDaddy to
+try registering against their test environments.
+
+Supported registrars: namecheap, porkbun, godaddy
+
+Examples:
+  indietool domain register kopi.dev --provider porkbun
+  indietool domain register kopi.com --provider namecheap --years 2 --contact work
+  indietool domain register kopi.io --provider godaddy --nameservers ns1.example.net,ns2.example.net`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+		if registerProvider == "" {
+			return fmt.Errorf("choose the registrar to buy from with --provider")
+		}
+
+		domain, err := asciiDomainArg(strings.TrimSpace(strings.ToLower(args[0])))
+		if err != nil {
+			return err
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+		registrar, ok := manager.Registrar(registerProvider)
+		if !ok {
+			return fmt.Errorf("provider %s is not configured as a registrar", registerProvider)
+		}
+		purchaser, ok := registrar.(domains.Purchaser)
+		if !ok {
+			return fmt.Errorf("%s can't register domains through indietool (supported: namecheap, porkbun, godaddy)", registerProvider)
+		}
+		info := purchaser.PurchaseInfo()
+		if registerYears < 1 || registerYears > info.MaxPeriod() {
+			return fmt.Errorf("%s registers domains for 1 to %d years, not %d", registerProvider, info.MaxPeriod(), registerYears)
+		}
+
+		request := domains.RegistrationRequest{Domain: domain, Years: registerYears, Nameservers: registerNameservers, AutoRenew: registerAutoRenew}
+		contactName := ""
+		if !info.AccountContact {
+			contactName, request.Contact, err = registrationContact(cfg.Domains.Contacts, registerContact)
+			if err != nil {
+				return err
+			}
+			if err := request.Contact.Validate(); err != nil {
+				return fmt.Errorf("contact profile %q: %w", contactName, err)
+			}
+		}
+
+		ctx := context.Background()
+
+		// Check afresh rather than trusting the lookup cache
+		result := domains.NewLookupEngine(domains.LookupOptions{}).Lookup(ctx, domain)
+		switch {
+		case result.Error != "":
+			log.Warnf("Couldn't confirm %s is available: %s", dns.DisplayName(domain), result.Error)
+		case !result.Available:
+			return fmt.Errorf("%s is already registered", dns.DisplayName(domain))
+		}
+
+		table := &domains.PriceTable{Registrars: make(map[string]domains.RegistrarPrices)}
+		quotes, errs := domains.NewManager([]domains.Registrar{registrar}).PriceDomains(ctx, table, []string{domain}, domains.PricingOptions{Refresh: true, QuoteNames: true}, time.Now())
+		for _, err := range errs {
+			log.Warnf("Failed to get the price from %s: %v", registerProvider, err)
+		}
+		if quote, ok := quotes[domain]; ok {
+			request.Price = &quote.DomainPrice
+		}
+
+		// Keep stdout for the JSON result
+		summary := io.Writer(os.Stdout)
+		if jsonOutput {
+			summary = os.Stderr
+		}
+		printRegistrationSummary(summary, request, registerProvider, info, contactName)
+
+		if !confirmRegistration(domain) {
+			return fmt.Errorf("registration of %s not confirmed; nothing was bought", dns.DisplayName(domain))
+		}
+
+		registration, err := manager.RegisterDomain(ctx, registerProvider, request)
+		if err != nil {
+			return err
+		}
+
+		if _, err := manager.SyncDomains([]string{registration.Provider}); err != nil {
+			log.Warnf("Failed to sync the domain inventory: %v", err)
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(registration, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("\nRegistered %s with %s", dns.DisplayName(registration.Domain), registration.Provider)
+		if registration.Sandbox {
+			fmt.Print(" (sandbox)")
+		}
+		fmt.Println(".")
+		if registration.OrderID != "" {
+			fmt.Printf("  Order:    %s\n", registration.OrderID)
+		}
+		if registration.Charged > 0 {
+			fmt.Printf("  Charged:  %s\n", formatAmount(registration.Charged, registration.Currency))
+		}
+		return nil
+	},
+}
+
+// registrationContact picks the contact profile named name, or the only
+// profile when no name is given and there is just one
+func registrationContact(contacts map[string]domains.Contact, name string) (string, domains.Contact, error) {
+	if len(contacts) == 0 {
+		return "", domains.Contact{}, fmt.Errorf("no contact profiles configured; add one under domains.contacts in the config file")
+	}
+	if name == "" {
+		if len(contacts) == 1 {
+			for only, contact := range contacts {
+				return only, contact, nil
+			}
+		}
+		name = "default"
+	}
+	contact, ok := contacts[name]
+	if !ok {
+		names := make([]string, 0, len(contacts))
+		for profile := range contacts {
+			names = append(names, profile)
+		}
+		sort.Strings(names)
+		return "", domains.Contact{}, fmt.Errorf("no contact profile %q (have: %s); choose one with --contact", name, strings.Join(names, ", "))
+	}
+	return name, contact, nil
+}
+
+// printRegistrationSummary shows what is about to be bought
+func printRegistrationSummary(w io.Writer, request domains.RegistrationRequest, provider string, info domains.PurchaseInfo, contactName string) {
+	where := provider
+	if info.Sandbox {
+		where += " (sandbox: nothing is charged)"
+	}
+	fmt.Fprintf(w, "\nRegister %s with %s\n\n", dns.DisplayName(request.Domain), where)
+
+	years := "1 year"
+	if request.Years > 1 {
+		years = fmt.Sprintf("%d years", request.Years)
+	}
+	if price := request.Price; price != nil {
+		fmt.Fprintf(w, "  Price:        %s for %s", formatAmount(domains.RegistrationCost(*price, request.Years), price.Currency), years)
+		if price.Renewal > 0 {
+			fmt.Fprintf(w, ", then %s/year", formatAmount(price.Renewal, price.Currency))
+		}
+		if price.Fees > 0 {
+			fmt.Fprintf(w, ", including %s/year in fees", formatAmount(price.Fees, price.Currency))
+		}
+		fmt.Fprintln(w)
+		if price.Premium {
+			fmt.Fprintln(w, "                ⚠️  premium name: priced well above the TLD's usual price")
+		}
+	} else {
+		fmt.Fprintf(w, "  Price:        unknown, check %s before confirming (%s)\n", provider, years)
+	}
+
+	if info.AccountContact {
+		fmt.Fprintf(w, "  Contact:      your %s account's default contact\n", provider)
+	} else {
+		fmt.Fprintf(w, "  Contact:      %s (profile %q)\n", request.Contact, contactName)
+	}
+
+	if len(request.Nameservers) > 0 {
+		fmt.Fprintf(w, "  Nameservers:  %s\n", strings.Join(request.Nameservers, ", "))
+	} else {
+		fmt.Fprintf(w, "  Nameservers:  %s defaults\n", provider)
+	}
+
+	switch {
+	case !info.SetsAutoRenew:
+		fmt.Fprintf(w, "  Auto-renew:   %s default\n", provider)
+	case request.AutoRenew:
+		fmt.Fprintln(w, "  Auto-renew:   on")
+	default:
+		fmt.Fprintln(w, "  Auto-renew:   off")
+	}
+	fmt.Fprintln(w)
+}
+
+// confirmRegistration asks the user to type the domain name, unless --yes
+// was given. Without a terminal only --yes confirms.
+func confirmRegistration(domain string) bool {
+	if registerYes {
+		return true
+	}
+	if !stdinIsTerminal() {
+		log.Warnf("stdin is not a terminal; pass --yes to confirm the purchase")
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "Type %s to buy it: ", dns.DisplayName(domain))
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	// Accept the A-label or the Unicode name the prompt shows
+	typed, err := dns.ToASCII(response)
+	return err == nil && typed == domain
+}
+
+// formatAmount formats a price in its currency, USD when unknown
+func formatAmount(amount float64, currency string) string {
+	if currency == "" || currency == "USD" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+func init() {
+	domainCmd.AddCommand(registerCmd)
+
+	registerCmd.Flags().StringVar(&registerProvider, "provider", "", "Registrar to buy the domain from (required)")
+	registerCmd.Flags().IntVar(&registerYears, "years", 1, "Years to register the domain for")
+	registerCmd.Flags().StringVar(&registerContact, "contact", "", "Contact profile to register the domain to (default: the only profile, or \"default\")")
+	registerCmd.Flags().StringSliceVar(&registerNameservers, "nameservers", nil, "Nameservers to use instead of the registrar's defaults")
+	registerCmd.Flags().BoolVarP(&registerYes, "yes", "y", false, "Confirm the purchase without prompting")
+	registerCmd.Flags().BoolVar(&registerAutoRenew, "auto-renew", false, "Turn on auto-renewal at purchase (GoDaddy; other registrars use their default)")
+}
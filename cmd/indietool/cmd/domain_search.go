@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"indietool/cli/domains"
 	"indietool/cli/output"
@@ -14,6 +15,9 @@ var (
 	searchWide      bool
 	searchNoColor   bool
 	searchNoHeaders bool
+	searchPrices    bool
+	searchQuote     bool
+	searchSortBy    string
 )
 
 // searchCmd represents the search command
@@ -26,12 +30,16 @@ information about domain registration status directly from registries.
 
 The command accepts multiple domain names and checks them concurrently for faster results.
 Results include availability status, registrar information, and registration details.
+Registered domains also show their nameservers, DNSSEC state, EPP status codes
+(e.g. clientHold, redemptionPeriod) and registrant country where it's public,
+and whether RDAP or WHOIS answered.
 
 Output options:
-  --wide        Show additional columns (registrar, cost, expiry, error details)
+  --wide        Show additional columns (registrar, nameservers, DNSSEC, EPP status, source)
   --json        Output results in JSON format
   --no-color    Disable colored output
   --no-headers  Don't show column headers
+  --prices      Show the cheapest configured registrar and price for available domains
 
 Examples:
   indietool domain search example.com
@@ -43,9 +51,15 @@ Examples:
 		domainList := make([]string, 0, len(args))
 		for _, domain := range args {
 			domain = strings.TrimSpace(strings.ToLower(domain))
-			if domain != "" {
-				domainList = append(domainList, domain)
+			if domain == "" {
+				continue
 			}
+			ascii, err := asciiDomainArg(domain)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			domainList = append(domainList, ascii)
 		}
 
 		if len(domainList) == 0 {
@@ -53,8 +67,15 @@ Examples:
 			os.Exit(1)
 		}
 
+		if err := checkSortBy(searchSortBy, searchPrices); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Search all domains concurrently
-		results := domains.SearchDomainsConcurrent(domainList)
+		engine, saveCache := newLookupEngine(domains.LookupOptions{})
+		results := engine.Search(context.Background(), domainList)
+		saveCache()
 
 		// Determine output format and render table
 		format := domains.GetOutputFormat(jsonOutput, searchWide)
@@ -66,6 +87,13 @@ Examples:
 
 		// Convert results to table rows and render
 		rows := domains.ConvertSearchResultsToTableRows(results)
+		if searchPrices {
+			tableConfig = domains.WithPriceColumns(tableConfig)
+			domains.AddPricesToRows(rows, lookupPrices(context.Background(), results, searchQuote))
+			if searchSortBy == "price" {
+				domains.SortRowsByPrice(rows)
+			}
+		}
 		table := output.NewTable(tableConfig, options)
 		table.AddRows(rows)
 
@@ -73,16 +101,39 @@ Examples:
 			fmt.Fprintf(os.Stderr, "Error rendering table: %v\n", err)
 			os.Exit(1)
 		}
+		if searchWide && !jsonOutput {
+			printEPPStatusLegend(results)
+		}
 	},
 }
 
+// printEPPStatusLegend explains the EPP status codes shown in the wide table
+func printEPPStatusLegend(results []domains.DomainSearchResult) {
+	legend := domains.EPPStatusLegend(results)
+	if len(legend) == 0 {
+		return
+	}
+	fmt.Println("\nEPP status codes:")
+	for _, status := range legend {
+		description := status.Description
+		if description == "" {
+			description = "Registry-specific status"
+		}
+		fmt.Printf("  %-26s %s\n", status.Code, description)
+	}
+}
+
 func init() {
 	domainCmd.AddCommand(searchCmd)
 
 	// Output format flags (consistent with domains list and explore commands)
-	searchCmd.Flags().BoolVarP(&searchWide, "wide", "w", false, "Show additional columns (registrar, cost, expiry, error details)")
+	searchCmd.Flags().BoolVarP(&searchWide, "wide", "w", false, "Show additional columns (registrar, nameservers, DNSSEC, EPP status, source)")
 	searchCmd.Flags().BoolVar(&searchNoHeaders, "no-headers", false, "Don't show column headers")
 	searchCmd.Flags().BoolVar(&searchNoColor, "no-color", true, "Disable colored output")
+	addLookupCacheFlags(searchCmd)
+	searchCmd.Flags().BoolVar(&searchPrices, "prices", false, "Show the cheapest registrar and price for available domains")
+	searchCmd.Flags().BoolVar(&searchQuote, "quote-names", false, "Quote every available name at registrars that price names individually, to find premium names")
+	searchCmd.Flags().StringVar(&searchSortBy, "sort-by", "", "Sort available domains by price (needs --prices)")
 
 	// Note: --json flag is inherited from global flags in root.go
 }
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// sessionSummary is one saved explore session in `domain sessions`
+type sessionSummary struct {
+	Name      string    `json:"name"`
+	Runs      int       `json:"runs"`
+	Base      string    `json:"base"` // Base name of the latest run
+	LastRun   time.Time `json:"last_run"`
+	Checked   int       `json:"checked"`
+	Available int       `json:"available"`
+}
+
+// sessionsTableConfig defines the table layout for `domain sessions`
+var sessionsTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "SESSION", JSONPath: "name", Required: true},
+		{Name: "RUNS", JSONPath: "runs", Required: true},
+		{Name: "BASE", JSONPath: "base", Formatter: domains.DashIfEmptyFormatter, Required: true},
+		{Name: "LAST RUN", JSONPath: "last_run", Formatter: domains.DateFormatter, Required: true},
+		{Name: "CHECKED", JSONPath: "checked", Required: true},
+		{Name: "AVAILABLE", JSONPath: "available", Required: true},
+	},
+}
+
+// sessionResultsTableConfig defines the table layout for `domain sessions show`
+var sessionResultsTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Formatter: domains.DomainNameFormatter, Required: true},
+		{Name: "STATUS", JSONPath: "status", Required: true},
+		{Name: "COST", JSONPath: "cost", Formatter: domains.CostFormatter, Required: true},
+		{Name: "RENEWAL", JSONPath: "renewal_cost", Formatter: domains.CostFormatter, Required: true},
+		{Name: "PREMIUM", JSONPath: "premium", Formatter: domains.PremiumFormatter, Required: true},
+		{Name: "CHANGE", JSONPath: "change", Formatter: domains.DashIfEmptyFormatter, Required: true},
+	},
+}
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List saved explore sessions",
+	Long: `List the explore sessions saved with 'domain explore --session <name>'.
+
+A session keeps the last 20 runs of explore made under its name, so you can
+come back to a naming search days later and see what changed.
+
+Examples:
+  indietool domain explore kopi --affixes --session kopi
+  indietool domain sessions
+  indietool domain sessions show kopi
+  indietool domain sessions remove kopi`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, _, err := loadExploreSessions()
+		if err != nil {
+			return err
+		}
+
+		list := sessions.List()
+		if len(list) == 0 && !jsonOutput {
+			fmt.Println("No explore sessions. Start one with 'indietool domain explore <name> --session <session>'.")
+			return nil
+		}
+
+		summaries := make([]sessionSummary, 0, len(list))
+		for _, session := range list {
+			summary := sessionSummary{Name: session.Name, Runs: len(session.Runs)}
+			if latest := session.Latest(); latest != nil {
+				summary.Base = latest.Base
+				summary.LastRun = latest.At
+				summary.Checked = len(latest.Results)
+				for _, result := range latest.Results {
+					if result.Status == domains.SessionAvailable {
+						summary.Available++
+					}
+				}
+			}
+			summaries = append(summaries, summary)
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(sessionsTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(summaries)
+		return table.Render()
+	},
+}
+
+var sessionsShowCmd = &cobra.Command{
+	Use:   "show <session>",
+	Short: "Show the latest results of an explore session",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, _, err := loadExploreSessions()
+		if err != nil {
+			return err
+		}
+		session, ok := sessions.Sessions[args[0]]
+		if !ok || session.Latest() == nil {
+			return fmt.Errorf("no explore session %q", args[0])
+		}
+
+		latest := session.Latest()
+		changes := domains.DiffExploreRuns(session.Previous(), latest)
+		rows := make([]map[string]interface{}, 0, len(latest.Results))
+		for _, result := range latest.Results {
+			row := map[string]interface{}{
+				"domain":       result.Domain,
+				"status":       result.Status,
+				"cost":         result.Cost,
+				"renewal_cost": result.Renewal,
+				"currency":     result.Currency,
+				"premium":      result.Premium,
+			}
+			rows = append(rows, row)
+		}
+		domains.AddChangesToRows(rows, changes)
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(sessionResultsTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(rows)
+		if err := table.Render(); err != nil {
+			return err
+		}
+		if !jsonOutput {
+			fmt.Printf("\nSession %s: %d runs, latest on %s exploring %s.\n",
+				session.Name, len(session.Runs), latest.At.Format("2006-01-02 15:04"), dns.DisplayName(latest.Base))
+		}
+		return nil
+	},
+}
+
+var sessionsRemoveCmd = &cobra.Command{
+	Use:   "remove <session...>",
+	Short: "Delete explore sessions",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, path, err := loadExploreSessions()
+		if err != nil {
+			return err
+		}
+		for _, name := range args {
+			if !sessions.Remove(name) {
+				return fmt.Errorf("no explore session %q", name)
+			}
+		}
+		if err := sessions.Save(path); err != nil {
+			return fmt.Errorf("failed to save explore sessions: %w", err)
+		}
+		fmt.Printf("Removed %d session(s).\n", len(args))
+		return nil
+	},
+}
+
+// loadExploreSessions loads the saved explore sessions and returns them
+// with their path
+func loadExploreSessions() (*domains.ExploreSessions, string, error) {
+	cfg := GetConfig()
+	if cfg == nil {
+		return nil, "", fmt.Errorf("no configuration loaded")
+	}
+	path := expandTildePath(cfg.GetDomainSessionsPath())
+	sessions, err := domains.LoadExploreSessions(path)
+	return sessions, path, err
+}
+
+// recordExploreSession adds an explore run to the named session and returns
+// the session with the names whose availability changed since its previous
+// run
+func recordExploreSession(name, base string, results []domains.DomainSearchResult, quotes map[string]*domains.PriceQuote) (*domains.ExploreSession, map[string]domains.SessionChange, error) {
+	sessions, path, err := loadExploreSessions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session := sessions.Record(name, domains.NewExploreRun(base, results, quotes, time.Now()))
+	if err := sessions.Save(path); err != nil {
+		return nil, nil, err
+	}
+	return session, domains.DiffExploreRuns(session.Previous(), session.Latest()), nil
+}
+
+// printSessionChanges lists the availability changes since the session's
+// previous run below the explore table
+func printSessionChanges(session *domains.ExploreSession, changes map[string]domains.SessionChange) {
+	previous := session.Previous()
+	switch {
+	case previous == nil:
+		fmt.Printf("\nSaved as the first run of session %q.\n", session.Name)
+		return
+	case len(changes) == 0:
+		fmt.Printf("\nNo availability changes since the previous run of session %q (%s).\n",
+			session.Name, previous.At.Format("2006-01-02 15:04"))
+		return
+	}
+
+	fmt.Printf("\n%d name(s) changed availability since the previous run of session %q (%s):\n",
+		len(changes), session.Name, previous.At.Format("2006-01-02 15:04"))
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		change := changes[name]
+		fmt.Printf("  %s: %s → %s\n", dns.DisplayName(name), change.From, change.To)
+	}
+}
+
+func init() {
+	domainCmd.AddCommand(sessionsCmd)
+	sessionsCmd.AddCommand(sessionsShowCmd)
+	sessionsCmd.AddCommand(sessionsRemoveCmd)
+}
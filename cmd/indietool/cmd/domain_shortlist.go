@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	shortlistNote   string
+	shortlistCheck  bool
+	shortlistFormat string
+	shortlistOutput string
+)
+
+// shortlistTableConfig defines the table layout for the shortlist
+var shortlistTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Formatter: domains.DomainNameFormatter, Required: true},
+		{Name: "STATUS", JSONPath: "status", Formatter: shortlistStatusFormatter, Required: true},
+		{Name: "COST", JSONPath: "cost", Formatter: domains.CostFormatter, Required: true},
+		{Name: "RENEWAL", JSONPath: "renewal_cost", Formatter: domains.CostFormatter, Required: true},
+		{Name: "CHECKED", JSONPath: "checked_at", Formatter: domains.DateFormatter, Required: true},
+		{Name: "NOTE", JSONPath: "note", Formatter: emptyAsDashFormatter, Required: true},
+	},
+	WideColumns: []output.Column{
+		{Name: "PREMIUM", JSONPath: "premium", Formatter: domains.PremiumFormatter},
+		{Name: "CURRENCY", JSONPath: "currency", Formatter: emptyAsDashFormatter},
+		{Name: "SESSION", JSONPath: "session", Formatter: emptyAsDashFormatter},
+		{Name: "ADDED", JSONPath: "added_at", Formatter: domains.DateFormatter},
+	},
+}
+
+// shortlistStatusFormatter shows domains that were never checked as unchecked
+func shortlistStatusFormatter(value interface{}) string {
+	if status, ok := value.(string); ok && status != "" {
+		return status
+	}
+	return "unchecked"
+}
+
+var shortlistCmd = &cobra.Command{
+	Use:   "shortlist",
+	Short: "Star candidate domains and share the shortlist",
+	Long: `Keep a shortlist of the candidate names you like while naming something.
+
+Availability and prices are taken from the latest explore session run that
+checked the domain (see 'domain explore --session'), or looked up again with
+'domain shortlist list --check'. Export the shortlist as Markdown or CSV to
+share it.
+
+Examples:
+  indietool domain shortlist add kopi.dev getkopi.com --note "short, .dev is cheap"
+  indietool domain shortlist list --check
+  indietool domain shortlist export --format markdown -o shortlist.md
+  indietool domain shortlist remove getkopi.com`,
+}
+
+var shortlistAddCmd = &cobra.Command{
+	Use:   "add <domain...>",
+	Short: "Add domains to the shortlist",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := make([]string, 0, len(args))
+		for _, name := range args {
+			if !strings.Contains(strings.Trim(name, "."), ".") {
+				return fmt.Errorf("%q is not a domain name; include the TLD, e.g. %s.com", name, name)
+			}
+			ascii, err := asciiDomainArg(name)
+			if err != nil {
+				return err
+			}
+			names = append(names, ascii)
+		}
+
+		shortlist, path, err := loadShortlist()
+		if err != nil {
+			return err
+		}
+		sessions, _, err := loadExploreSessions()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, name := range names {
+			entry, added := shortlist.Add(name, now)
+			if shortlistNote != "" {
+				entry.Note = shortlistNote
+			}
+			if result, session, at, ok := sessions.LatestResult(entry.Domain); ok {
+				entry.UpdateFromSession(result, session, at)
+			}
+			if added {
+				fmt.Printf("Shortlisted %s.\n", dns.DisplayName(entry.Domain))
+			} else {
+				fmt.Printf("%s is already shortlisted.\n", dns.DisplayName(entry.Domain))
+			}
+		}
+
+		if err := shortlist.Save(path); err != nil {
+			return fmt.Errorf("failed to save shortlist: %w", err)
+		}
+		return nil
+	},
+}
+
+var shortlistRemoveCmd = &cobra.Command{
+	Use:   "remove <domain...>",
+	Short: "Remove domains from the shortlist",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shortlist, path, err := loadShortlist()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range args {
+			if ascii, err := dns.ToASCII(name); err == nil {
+				name = ascii
+			}
+			if !shortlist.Remove(name) {
+				return fmt.Errorf("%s is not shortlisted", name)
+			}
+		}
+
+		if err := shortlist.Save(path); err != nil {
+			return fmt.Errorf("failed to save shortlist: %w", err)
+		}
+		fmt.Printf("Removed %s from the shortlist.\n", strings.Join(args, ", "))
+		return nil
+	},
+}
+
+var shortlistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List shortlisted domains",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shortlist, path, err := loadShortlist()
+		if err != nil {
+			return err
+		}
+
+		entries := shortlist.List()
+		if len(entries) == 0 && !jsonOutput {
+			fmt.Println("The shortlist is empty. Add a domain with 'indietool domain shortlist add <domain>'.")
+			return nil
+		}
+
+		if shortlistCheck {
+			engine, saveCache := newLookupEngine(domains.LookupOptions{})
+			results := engine.Search(context.Background(), shortlist.Names())
+			saveCache()
+
+			now := time.Now()
+			for _, result := range results {
+				shortlist.Domains[result.Domain].UpdateFromSearch(result, now)
+			}
+			if err := shortlist.Save(path); err != nil {
+				return fmt.Errorf("failed to save shortlist: %w", err)
+			}
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(shortlistTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(entries)
+		return table.Render()
+	},
+}
+
+var shortlistExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the shortlist as Markdown or CSV",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := output.OutputFormat(strings.ToLower(shortlistFormat))
+		switch format {
+		case output.FormatMarkdown, output.FormatCSV:
+		case "md":
+			format = output.FormatMarkdown
+		default:
+			return fmt.Errorf("invalid --format %q (use markdown or csv)", shortlistFormat)
+		}
+
+		shortlist, _, err := loadShortlist()
+		if err != nil {
+			return err
+		}
+
+		w := io.Writer(os.Stdout)
+		if shortlistOutput != "" {
+			file, err := os.Create(expandTildePath(shortlistOutput))
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", shortlistOutput, err)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		table := output.NewTable(shortlistTableConfig, output.TableOptions{Format: format, Writer: w})
+		table.AddRows(shortlist.List())
+		if err := table.Render(); err != nil {
+			return err
+		}
+		if shortlistOutput != "" {
+			fmt.Printf("Exported %d domains to %s.\n", len(shortlist.Domains), shortlistOutput)
+		}
+		return nil
+	},
+}
+
+// loadShortlist loads the shortlist and returns it with its path
+func loadShortlist() (*domains.Shortlist, string, error) {
+	cfg := GetConfig()
+	if cfg == nil {
+		return nil, "", fmt.Errorf("no configuration loaded")
+	}
+	path := expandTildePath(cfg.GetDomainShortlistPath())
+	shortlist, err := domains.LoadShortlist(path)
+	return shortlist, path, err
+}
+
+func init() {
+	domainCmd.AddCommand(shortlistCmd)
+	shortlistCmd.AddCommand(shortlistAddCmd)
+	shortlistCmd.AddCommand(shortlistRemoveCmd)
+	shortlistCmd.AddCommand(shortlistListCmd)
+	shortlistCmd.AddCommand(shortlistExportCmd)
+
+	shortlistAddCmd.Flags().StringVar(&shortlistNote, "note", "", "Note to keep with the domains, e.g. why you like them")
+	shortlistListCmd.Flags().BoolVar(&shortlistCheck, "check", false, "Look up the availability of every shortlisted domain again")
+	addLookupCacheFlags(shortlistListCmd)
+	shortlistExportCmd.Flags().StringVar(&shortlistFormat, "format", "markdown", "Export format: markdown or csv")
+	shortlistExportCmd.Flags().StringVarP(&shortlistOutput, "output", "o", "", "File to write instead of stdout")
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tldsCategories        []string
+	tldsPriceBands        []string
+	tldsExcludeRestricted bool
+)
+
+// tldTableConfig defines the table layout for the TLD catalog
+var tldTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "TLD", JSONPath: "tld", Required: true},
+		{Name: "CATEGORIES", JSONPath: "categories", Formatter: tldListFormatter, Required: true},
+		{Name: "RDAP", JSONPath: "rdap", Formatter: output.YesNoFormatter, Required: true},
+		{Name: "RESTRICTIONS", JSONPath: "restrictions", Formatter: tldListFormatter, Required: true},
+		{Name: "PRICE", JSONPath: "price_band", Required: true},
+	},
+}
+
+// tldListFormatter joins a list of values, such as TLD categories or
+// nameservers
+func tldListFormatter(value interface{}) string {
+	list, ok := value.([]string)
+	if !ok || len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ", ")
+}
+
+var tldsCmd = &cobra.Command{
+	Use:   "tlds",
+	Short: "Browse the TLD catalog",
+	Long: `List the TLDs indietool knows about, with their category, whether they
+have an RDAP server, registration restrictions and typical price band.
+
+Categories: generic, country, sponsored, infrastructure, brand, and tech for
+TLDs popular with developers. Restrictions:
+  hsts-preload    browsers only connect over HTTPS (.app, .dev, ...)
+  local-presence  registrants need a presence in the country or city
+  eligibility     registrants must qualify, e.g. verified banks
+  closed          not sold to the public (brand TLDs and the like)
+
+Price bands are typical yearly prices: budget under $10, standard $10-30
+and premium above. Most TLDs have no band in the catalog and show as
+unknown; filter with --price-band unknown to include them. Use
+'domain explore --prices' for actual prices.
+
+A catalog is bundled with indietool; 'indietool domain tlds update' fetches
+IANA's current TLD list and RDAP servers.
+
+Examples:
+  indietool domain tlds --category tech
+  indietool domain tlds --category country --exclude-restricted
+  indietool domain tlds --price-band budget,unknown
+  indietool domain tlds update`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		categories, err := domains.ParseTLDCategories(tldsCategories)
+		if err != nil {
+			return err
+		}
+		bands, err := domains.ParsePriceBands(tldsPriceBands)
+		if err != nil {
+			return err
+		}
+		catalog := loadTLDCatalog()
+		filter := domains.TLDFilter{Categories: categories, PriceBands: bands, ExcludeRestricted: tldsExcludeRestricted}
+
+		var rows []map[string]interface{}
+		for _, tld := range catalog.Select(filter) {
+			info, _ := catalog.Get(tld)
+			rows = append(rows, map[string]interface{}{
+				"tld":          dns.DisplayName(info.TLD),
+				"categories":   tldStrings(info.Categories),
+				"rdap":         info.RDAP,
+				"restrictions": tldStrings(info.Restrictions),
+				"price_band":   string(info.Band()),
+			})
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(tldTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(rows)
+		return table.Render()
+	},
+}
+
+var tldsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Refresh the TLD catalog from IANA",
+	Long: `Fetch IANA's list of delegated TLDs and its RDAP bootstrap, and save an
+updated catalog next to the config file. Categories, restrictions and price
+bands are kept for known TLDs; new ones are classed by name only.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		path := expandTildePath(cfg.GetDomainTLDCatalogPath())
+		base, err := domains.LoadTLDCatalog(path)
+		if err != nil {
+			log.Warnf("Updating the bundled catalog instead: %v", err)
+			base = domains.BundledTLDCatalog()
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		updated, changes, err := domains.UpdateTLDCatalog(context.Background(), client, base, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := updated.Save(path); err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(changes, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("TLD catalog updated: %d TLDs, %d added, %d removed, %d with changed RDAP support.\n",
+			len(updated.TLDs), len(changes.Added), len(changes.Removed), len(changes.RDAP))
+		if len(changes.Added) > 0 {
+			fmt.Printf("Added: %s\n", strings.Join(changes.Added, ", "))
+		}
+		if len(changes.Removed) > 0 {
+			fmt.Printf("Removed: %s\n", strings.Join(changes.Removed, ", "))
+		}
+		return nil
+	},
+}
+
+// loadTLDCatalog returns the updated TLD catalog if there is one, otherwise
+// the bundled one
+func loadTLDCatalog() *domains.TLDCatalog {
+	cfg := GetConfig()
+	if cfg == nil {
+		return domains.BundledTLDCatalog()
+	}
+	catalog, err := domains.LoadTLDCatalog(expandTildePath(cfg.GetDomainTLDCatalogPath()))
+	if err != nil {
+		log.Warnf("Using the bundled TLD catalog: %v", err)
+		return domains.BundledTLDCatalog()
+	}
+	return catalog
+}
+
+// tldStrings converts categories or restrictions for display
+func tldStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = string(value)
+	}
+	return out
+}
+
+func init() {
+	domainCmd.AddCommand(tldsCmd)
+	tldsCmd.AddCommand(tldsUpdateCmd)
+
+	tldsCmd.Flags().StringSliceVar(&tldsCategories, "category", nil, "Only list TLDs in these categories")
+	tldsCmd.Flags().StringSliceVar(&tldsPriceBands, "price-band", nil, "Only list TLDs in these price bands (budget, standard, premium, unknown)")
+	tldsCmd.Flags().BoolVar(&tldsExcludeRestricted, "exclude-restricted", false, "Leave out TLDs not everyone can register in")
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/indietool/notify"
+	"indietool/cli/output"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchDryRun bool
+	watchWide   bool
+)
+
+// watchCheckResult is one watched domain after a check
+type watchCheckResult struct {
+	Domain        string             `json:"domain"`
+	Phase         domains.WatchPhase `json:"phase"`
+	Change        string             `json:"change,omitempty"` // Phase transition seen by this check
+	PhaseSince    *time.Time         `json:"phase_since,omitempty"`
+	ExpiryDate    *time.Time         `json:"expiry_date,omitempty"`
+	PredictedDrop *time.Time         `json:"predicted_drop,omitempty"`
+	Alert         string             `json:"alert,omitempty"` // sent, failed, printed or pending (dry run)
+	AlertSinks    []string           `json:"alert_sinks,omitempty"`
+	AlertError    string             `json:"alert_error,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
+
+// watchTableConfig defines the table layout for the watchlist
+var watchTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Formatter: domains.DomainNameFormatter, Required: true},
+		{Name: "PHASE", JSONPath: "phase", Formatter: watchPhaseFormatter, Required: true},
+		{Name: "SINCE", JSONPath: "phase_since", Formatter: domains.DateFormatter, Required: true},
+		{Name: "EXPIRES", JSONPath: "expiry_date", Formatter: domains.DateFormatter, Required: true},
+		{Name: "PREDICTED DROP", JSONPath: "predicted_drop", Formatter: domains.DateFormatter, Required: true},
+		{Name: "CHECKED", JSONPath: "checked_at", Formatter: domains.DateFormatter, Required: true},
+	},
+	WideColumns: []output.Column{
+		{Name: "STATUS", JSONPath: "status", Formatter: emptyAsDashFormatter},
+		{Name: "ERROR", JSONPath: "last_error", Formatter: emptyAsDashFormatter},
+	},
+}
+
+// watchCheckTableConfig defines the table layout for `domain watch check`
+var watchCheckTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Formatter: domains.DomainNameFormatter, Required: true},
+		{Name: "PHASE", JSONPath: "phase", Formatter: watchPhaseFormatter, Required: true},
+		{Name: "CHANGE", JSONPath: "change", Formatter: emptyAsDashFormatter, Required: true},
+		{Name: "PREDICTED DROP", JSONPath: "predicted_drop", Formatter: domains.DateFormatter, Required: true},
+		{Name: "ALERT", JSONPath: "alert", Formatter: emptyAsDashFormatter, Required: true},
+		{Name: "ERROR", JSONPath: "error", Formatter: emptyAsDashFormatter, Required: true},
+	},
+}
+
+// watchPhaseFormatter shows domains that were never checked as unchecked
+func watchPhaseFormatter(value interface{}) string {
+	if phase, ok := value.(domains.WatchPhase); ok && phase != "" {
+		return string(phase)
+	}
+	return "unchecked"
+}
+
+// emptyAsDashFormatter shows "-" for empty values
+func emptyAsDashFormatter(value interface{}) string {
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	return "-"
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch domains you want but don't own",
+	Long: `Keep a watchlist of domains you'd like to register when they lapse.
+
+'domain watch check' looks up every watched domain and records how it moves
+through the registration lifecycle:
+
+  registered → autoRenewPeriod → redemptionPeriod → pendingDelete → available
+
+From the expiry date and the phase it predicts when the domain drops, and
+alerts through the notification sinks (see 'indietool domains notify') once
+a domain becomes available. Run it daily from cron.
+
+Examples:
+  indietool domain watch add coolname.com coolname.io
+  indietool domain watch check
+  indietool domain watch list
+  indietool domain watch remove coolname.io`,
+}
+
+var watchAddCmd = &cobra.Command{
+	Use:   "add <domain...>",
+	Short: "Add domains to the watchlist",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		names := make([]string, 0, len(args))
+		for _, name := range args {
+			if !strings.Contains(strings.Trim(name, "."), ".") {
+				return fmt.Errorf("%q is not a domain name; include the TLD, e.g. %s.com", name, name)
+			}
+			ascii, err := asciiDomainArg(name)
+			if err != nil {
+				return err
+			}
+			names = append(names, ascii)
+		}
+
+		path := expandTildePath(cfg.GetDomainWatchlistPath())
+		watchlist, err := domains.LoadWatchlist(path)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, name := range names {
+			entry, added := watchlist.Add(name, now)
+			if added {
+				fmt.Printf("Watching %s.\n", dns.DisplayName(entry.Domain))
+			} else {
+				fmt.Printf("%s is already watched.\n", dns.DisplayName(entry.Domain))
+			}
+		}
+
+		if err := watchlist.Save(path); err != nil {
+			return fmt.Errorf("failed to save watchlist: %w", err)
+		}
+		fmt.Println("Run 'indietool domain watch check' to look them up.")
+		return nil
+	},
+}
+
+var watchRemoveCmd = &cobra.Command{
+	Use:   "remove <domain...>",
+	Short: "Remove domains from the watchlist",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		path := expandTildePath(cfg.GetDomainWatchlistPath())
+		watchlist, err := domains.LoadWatchlist(path)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range args {
+			if ascii, err := dns.ToASCII(name); err == nil {
+				name = ascii
+			}
+			if !watchlist.Remove(name) {
+				return fmt.Errorf("%s is not watched", name)
+			}
+		}
+
+		if err := watchlist.Save(path); err != nil {
+			return fmt.Errorf("failed to save watchlist: %w", err)
+		}
+		fmt.Printf("Stopped watching %s.\n", strings.Join(args, ", "))
+		return nil
+	},
+}
+
+var watchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List watched domains as of the last check",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		watchlist, err := domains.LoadWatchlist(expandTildePath(cfg.GetDomainWatchlistPath()))
+		if err != nil {
+			return err
+		}
+
+		entries := watchlist.List()
+		if len(entries) == 0 && !jsonOutput {
+			fmt.Println("No domains watched. Add one with 'indietool domain watch add <domain>'.")
+			return nil
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		} else if watchWide {
+			format = output.FormatWide
+		}
+		table := output.NewTable(watchTableConfig, output.TableOptions{Format: format, Wide: watchWide, Writer: os.Stdout})
+		table.AddRows(entries)
+		return table.Render()
+	},
+}
+
+var watchCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Look up watched domains and alert when one becomes available",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		sinks, err := notify.NewSinks(cfg.Notifications)
+		if err != nil {
+			return fmt.Errorf("invalid notification config: %w", err)
+		}
+
+		path := expandTildePath(cfg.GetDomainWatchlistPath())
+		watchlist, err := domains.LoadWatchlist(path)
+		if err != nil {
+			return err
+		}
+
+		names := watchlist.Names()
+		if len(names) == 0 && !jsonOutput {
+			fmt.Println("No domains watched. Add one with 'indietool domain watch add <domain>'.")
+			return nil
+		}
+
+		now := time.Now()
+		// Drops need noticing within the hour, whatever the cache would allow
+		engine, saveCache := newLookupEngine(domains.LookupOptions{MaxAge: time.Hour})
+		searchResults := engine.Search(context.Background(), names)
+		if !watchDryRun {
+			saveCache()
+		}
+
+		failed := 0
+		results := make([]watchCheckResult, 0, len(searchResults))
+		for _, searchResult := range searchResults {
+			entry, ok := watchlist.Domains[searchResult.Domain]
+			if !ok {
+				log.Warnf("Lookup returned %s, which isn't on the watchlist; skipping it", searchResult.Domain)
+				continue
+			}
+			transition := entry.Update(searchResult, now)
+			res := watchCheckResult{
+				Domain:        entry.Domain,
+				Phase:         entry.Phase,
+				PhaseSince:    entry.PhaseSince,
+				ExpiryDate:    entry.ExpiryDate,
+				PredictedDrop: entry.PredictedDrop,
+				Error:         entry.LastError,
+			}
+			if transition != nil {
+				res.Change = transition.String()
+			}
+
+			if entry.AlertDue() {
+				switch {
+				case watchDryRun:
+					res.Alert = "pending"
+				case len(sinks) == 0:
+					res.Alert = "printed"
+					entry.MarkAlerted(now)
+				default:
+					delivered, err := notify.Dispatch(context.Background(), sinks, entry.Notification())
+					res.AlertSinks = delivered
+					if err != nil {
+						res.AlertError = err.Error()
+						log.Warnf("Failed to deliver alert for %s: %v", entry.Domain, err)
+					}
+					// Delivered to at least one sink counts; otherwise retry next run
+					if len(delivered) > 0 {
+						res.Alert = "sent"
+						entry.MarkAlerted(now)
+					} else {
+						res.Alert = "failed"
+						failed++
+					}
+				}
+			}
+			results = append(results, res)
+		}
+
+		if !watchDryRun {
+			if err := watchlist.Save(path); err != nil {
+				return fmt.Errorf("failed to save watchlist: %w", err)
+			}
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(watchCheckTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(results)
+		if err := table.Render(); err != nil {
+			return err
+		}
+
+		if watchDryRun && !jsonOutput {
+			fmt.Println("\nDry run: no alerts were sent and neither the watchlist nor the lookup cache was updated.")
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d alert(s) could not be delivered and will be retried on the next run", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	domainCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchAddCmd)
+	watchCmd.AddCommand(watchRemoveCmd)
+	watchCmd.AddCommand(watchListCmd)
+	watchCmd.AddCommand(watchCheckCmd)
+
+	watchListCmd.Flags().BoolVarP(&watchWide, "wide", "w", false, "Show the raw registry status and the last lookup error")
+	watchCheckCmd.Flags().BoolVar(&watchDryRun, "dry-run", false, "Look up domains without sending alerts or saving the results")
+	addLookupCacheFlags(watchCheckCmd)
+}
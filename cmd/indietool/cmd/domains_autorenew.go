@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var autorenewExpiringIn string
+
+// autorenewResultTableConfig defines the table layout for auto-renewal changes
+var autorenewResultTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Required: true},
+		{Name: "PROVIDER", JSONPath: "provider", Required: true},
+		{Name: "AUTO-RENEW", JSONPath: "auto_renewal", Formatter: output.OnOffFormatter, Required: true},
+		{Name: "RESULT", JSONPath: "status", Required: true},
+		{Name: "ERROR", JSONPath: "error", Required: true},
+	},
+}
+
+var autorenewCmd = &cobra.Command{
+	Use:   "autorenew [domain...] on|off",
+	Short: "Turn auto-renewal on or off at your registrars",
+	Long: `Change the auto-renewal setting of one or more domains through their
+registrar's API.
+
+Cloudflare, GoDaddy and the mock provider support this. Porkbun and Namecheap
+don't expose the setting in their APIs; those domains are reported as
+"unsupported" rather than failed so you know to change them in the dashboard.
+
+Examples:
+  indietool domains autorenew example.com on
+  indietool domains autorenew example.com sideproject.dev off
+  indietool domains autorenew --all-expiring-in 60d on`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var enabled bool
+		switch strings.ToLower(args[len(args)-1]) {
+		case "on", "true", "enable":
+			enabled = true
+		case "off", "false", "disable":
+			enabled = false
+		default:
+			return fmt.Errorf("last argument must be on or off, got %q", args[len(args)-1])
+		}
+		names := args[:len(args)-1]
+
+		if len(names) == 0 && autorenewExpiringIn == "" {
+			return fmt.Errorf("specify at least one domain or --all-expiring-in")
+		}
+		if len(names) > 0 && autorenewExpiringIn != "" {
+			return fmt.Errorf("cannot combine domain names with --all-expiring-in")
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		var targets []domains.ManagedDomain
+		if autorenewExpiringIn != "" {
+			result, err := manager.ListManagedDomains(domains.ListOptions{ExpiringIn: autorenewExpiringIn})
+			if err != nil {
+				return fmt.Errorf("failed to list domains: %w", err)
+			}
+			targets = result.Domains
+		} else {
+			for _, name := range names {
+				domain, err := manager.FindDomain(name)
+				if err != nil {
+					return err
+				}
+				targets = append(targets, domain)
+			}
+		}
+
+		if len(targets) == 0 {
+			fmt.Printf("No domains expiring in %s.\n", autorenewExpiringIn)
+			return nil
+		}
+
+		results, err := manager.SetAutoRenewal(context.Background(), targets, enabled)
+		if err != nil {
+			return fmt.Errorf("failed to update domain inventory: %w", err)
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(autorenewResultTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(results)
+		if err := table.Render(); err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, result := range results {
+			if result.Status == domains.AutoRenewFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to update auto-renewal for %d domain(s)", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	domainsCmd.AddCommand(autorenewCmd)
+
+	autorenewCmd.Flags().StringVar(&autorenewExpiringIn, "all-expiring-in", "", "Apply to every domain expiring within timeframe (e.g., 60d, 3mo)")
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"indietool/cli/domains"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	calendarOutput       string
+	calendarRefresh      bool
+	calendarNoCosts      bool
+	calendarAddr         string
+	calendarRefreshEvery time.Duration
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Export domain expiry dates as an iCalendar feed",
+	Long: `Generate an iCalendar (.ics) file with an all-day event on the expiry date
+of every managed domain. Each event lists the provider, auto-renew state and
+renewal cost, and carries a reminder for every expiry_warning_days threshold.
+
+Import the file into your calendar app, or run 'domains calendar serve' to
+publish it as a subscribable feed.
+
+Examples:
+  indietool domains calendar -o renewals.ics
+  indietool domains calendar --refresh > renewals.ics
+  indietool domains calendar serve --addr :8080`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		result, err := manager.ListManagedDomains(domains.ListOptions{Refresh: calendarRefresh})
+		if err != nil {
+			return fmt.Errorf("failed to list domains: %w", err)
+		}
+		for _, syncResult := range result.SyncResults {
+			if !syncResult.Success {
+				log.Warnf("Last sync of %s failed, using cached domains: %s", syncResult.Provider, syncResult.Error)
+			}
+		}
+
+		if !calendarNoCosts {
+			manager.FillRenewalCosts(context.Background(), result.Domains)
+		}
+
+		var buf bytes.Buffer
+		if err := domains.WriteCalendar(&buf, result.Domains, GetConfig().Domains.Management.ExpiryWarningDays, time.Now()); err != nil {
+			return fmt.Errorf("failed to generate calendar: %w", err)
+		}
+
+		if calendarOutput == "" || calendarOutput == "-" {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+
+		path := expandTildePath(calendarOutput)
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write calendar: %w", err)
+		}
+		fmt.Printf("Wrote %d domain(s) to %s\n", len(result.Domains), path)
+		return nil
+	},
+}
+
+var calendarServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the domain expiry calendar over HTTP",
+	Long: `Serve the iCalendar feed so calendar apps can subscribe to it. The feed is
+built from the local inventory on every request and the inventory is synced
+from your providers in the background.
+
+Subscribe to http://<addr>/renewals.ics from your calendar app.
+
+Examples:
+  indietool domains calendar serve
+  indietool domains calendar serve --addr 127.0.0.1:8080 --refresh-every 1h`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		feed := &calendarFeed{
+			manager:     manager,
+			warningDays: GetConfig().Domains.Management.ExpiryWarningDays,
+			withCosts:   !calendarNoCosts,
+			costs:       make(map[string]*domains.DomainCost),
+		}
+
+		if calendarRefreshEvery > 0 {
+			go feed.syncEvery(calendarRefreshEvery)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/renewals.ics", feed)
+		mux.Handle("/{$}", feed)
+
+		log.Infof("Serving domain calendar on http://%s/renewals.ics", displayAddr(calendarAddr))
+		server := &http.Server{Addr: calendarAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		return server.ListenAndServe()
+	},
+}
+
+// calendarFeed serves the iCalendar feed. Renewal costs are looked up once
+// per domain and kept for the lifetime of the server.
+type calendarFeed struct {
+	mu          sync.Mutex
+	manager     *domains.Manager
+	warningDays []int
+	withCosts   bool
+	costs       map[string]*domains.DomainCost
+}
+
+func (f *calendarFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result, err := f.manager.ListManagedDomains(domains.ListOptions{})
+	if err != nil {
+		log.Errorf("Failed to list domains: %v", err)
+		http.Error(w, "failed to list domains", http.StatusInternalServerError)
+		return
+	}
+
+	if f.withCosts {
+		// Unknown costs are cached too so providers without pricing are only asked once
+		var missing []domains.ManagedDomain
+		for _, domain := range result.Domains {
+			if _, ok := f.costs[domain.Name]; !ok && domain.Cost == nil {
+				missing = append(missing, domain)
+			}
+		}
+		f.manager.FillRenewalCosts(r.Context(), missing)
+		for _, domain := range missing {
+			f.costs[domain.Name] = domain.Cost
+		}
+		for i, domain := range result.Domains {
+			if domain.Cost == nil {
+				result.Domains[i].Cost = f.costs[domain.Name]
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := domains.WriteCalendar(&buf, result.Domains, f.warningDays, time.Now()); err != nil {
+		log.Errorf("Failed to generate calendar: %v", err)
+		http.Error(w, "failed to generate calendar", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="renewals.ics"`)
+	w.Write(buf.Bytes())
+}
+
+// syncEvery refreshes the inventory from all providers at the given interval
+func (f *calendarFeed) syncEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		f.mu.Lock()
+		results, err := f.manager.SyncDomains(nil)
+		f.mu.Unlock()
+
+		if err != nil {
+			log.Warnf("Failed to sync domains: %v", err)
+			continue
+		}
+		for _, result := range results {
+			if !result.Success {
+				log.Warnf("Failed to sync %s: %s", result.Provider, result.Error)
+			}
+		}
+	}
+}
+
+// displayAddr turns a listen address like ":8080" into something clickable
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+func init() {
+	domainsCmd.AddCommand(calendarCmd)
+	calendarCmd.AddCommand(calendarServeCmd)
+
+	calendarCmd.Flags().StringVarP(&calendarOutput, "output", "o", "", "Write the calendar to this file instead of stdout")
+	calendarCmd.Flags().BoolVar(&calendarRefresh, "refresh", false, "Sync domains from all providers before generating the calendar")
+	calendarCmd.PersistentFlags().BoolVar(&calendarNoCosts, "no-costs", false, "Don't look up renewal costs from providers")
+
+	calendarServeCmd.Flags().StringVar(&calendarAddr, "addr", ":8080", "Address to listen on")
+	calendarServeCmd.Flags().DurationVar(&calendarRefreshEvery, "refresh-every", 6*time.Hour, "How often to sync domains from providers (0 disables)")
+}
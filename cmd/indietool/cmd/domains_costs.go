@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	costsWithin  string
+	costsGroupBy string
+	costsJump    float64
+	costsFormat  string
+	costsTotals  bool
+	costsCached  bool
+	costsRefresh bool
+)
+
+// costsTableConfig defines the table layout for upcoming renewals
+var costsTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "MONTH", JSONPath: "month", Required: true},
+		{Name: "DOMAIN", JSONPath: "domain", Required: true},
+		{Name: "PROVIDER", JSONPath: "provider", Required: true},
+		{Name: "RENEWS", JSONPath: "renewal_date", Formatter: output.AbsoluteTimeFormatter, Required: true},
+		{Name: "PRICE", JSONPath: "price", Formatter: priceFormatter, Required: true},
+		{Name: "CURRENCY", JSONPath: "currency", Formatter: emptyAsNAFormatter, Required: true},
+		{Name: "CHANGE", JSONPath: "change", Formatter: priceChangeFormatter, Required: true},
+		{Name: "JUMP", JSONPath: "price_jump", Formatter: jumpFormatter, Required: true},
+	},
+	WideColumns: []output.Column{
+		{Name: "AUTO-RENEW", JSONPath: "auto_renewal", Formatter: output.OnOffFormatter},
+		{Name: "PREVIOUS", JSONPath: "previous_price", Formatter: priceFormatter},
+		{Name: "ERROR", JSONPath: "error", Formatter: emptyAsNAFormatter},
+	},
+}
+
+// costTotalsTableConfig defines the table layout for --totals
+var costTotalsTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "GROUP", JSONPath: "group", Required: true},
+		{Name: "CURRENCY", JSONPath: "currency", Required: true},
+		{Name: "DOMAINS", JSONPath: "domains", Required: true},
+		{Name: "AMOUNT", JSONPath: "amount", Formatter: priceFormatter, Required: true},
+	},
+}
+
+// costNestedTotalsTableConfig is costTotalsTableConfig for two groupings;
+// rows without a subgroup are the group's subtotal
+var costNestedTotalsTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "GROUP", JSONPath: "group", Required: true},
+		{Name: "SUBGROUP", JSONPath: "subgroup", Formatter: domains.DashIfEmptyFormatter, Required: true},
+		{Name: "CURRENCY", JSONPath: "currency", Required: true},
+		{Name: "DOMAINS", JSONPath: "domains", Required: true},
+		{Name: "AMOUNT", JSONPath: "amount", Formatter: priceFormatter, Required: true},
+	},
+}
+
+var costsCmd = &cobra.Command{
+	Use:   "costs",
+	Short: "Forecast renewal costs across your domains",
+	Long: `Forecast what your domains will cost to renew, grouped by month,
+provider or both (--group-by month,provider), with totals per currency.
+
+Current renewal prices are looked up from each registrar and remembered
+between runs. Domains whose price rose by more than --jump percent since the
+previously seen price are flagged. Domains whose registrar doesn't expose
+pricing are listed without a price and left out of the totals.
+
+Use --format csv or json to export the report, e.g. for a spreadsheet.
+
+Examples:
+  indietool domains costs
+  indietool domains costs --within 6mo --group-by provider
+  indietool domains costs --group-by month,provider --totals
+  indietool domains costs --totals
+  indietool domains costs --format csv > renewals.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		format, err := costsOutputFormat()
+		if err != nil {
+			return err
+		}
+
+		groupBy, err := domains.ParseCostGroupBy(costsGroupBy)
+		if err != nil {
+			return fmt.Errorf("invalid --group-by: %w", err)
+		}
+
+		var within time.Duration
+		if costsWithin != "all" {
+			if within, err = domains.ParseTimeframe(costsWithin); err != nil {
+				return err
+			}
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		result, err := manager.ListManagedDomains(domains.ListOptions{Refresh: costsRefresh})
+		if err != nil {
+			return fmt.Errorf("failed to list domains: %w", err)
+		}
+		for _, syncResult := range result.SyncResults {
+			if !syncResult.Success {
+				log.Warnf("Last sync of %s failed, using cached domains: %s", syncResult.Provider, syncResult.Error)
+			}
+		}
+
+		var lookupErrs map[string]error
+		if !costsCached {
+			if lookupErrs, err = manager.LookupRenewalCosts(context.Background(), result.Domains); err != nil {
+				log.Warnf("Failed to store renewal prices in the domain inventory: %v", err)
+			}
+			unsupported := 0
+			for name, err := range lookupErrs {
+				if errors.Is(err, domains.ErrNotSupported) {
+					unsupported++
+				} else {
+					log.Debugf("Failed to get renewal price for %s: %v", name, err)
+				}
+			}
+			if unsupported > 0 {
+				log.Infof("%d domain(s) are with registrars that don't expose renewal pricing", unsupported)
+			}
+		}
+
+		historyPath := expandTildePath(cfg.GetDomainCostHistoryPath())
+		history, err := domains.LoadCostHistory(historyPath)
+		if err != nil {
+			return err
+		}
+
+		report := domains.BuildCostReport(result.Domains, history, lookupErrs, domains.CostReportOptions{
+			Within:        within,
+			GroupBy:       groupBy,
+			JumpThreshold: costsJump,
+		})
+
+		if err := history.Save(historyPath); err != nil {
+			log.Warnf("Failed to save renewal price history: %v", err)
+		}
+
+		if format == output.FormatJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if costsTotals {
+			config := costTotalsTableConfig
+			if len(groupBy) > 1 {
+				config = costNestedTotalsTableConfig
+			}
+			table := output.NewTable(config, output.TableOptions{Format: format, Wide: format == output.FormatWide, Writer: os.Stdout})
+			table.AddRows(append(report.Totals, report.Overall...))
+			return table.Render()
+		}
+
+		if len(report.Renewals) == 0 && format != output.FormatCSV {
+			fmt.Println("No renewals due in this period.")
+			return nil
+		}
+
+		table := output.NewTable(costsTableConfig, output.TableOptions{Format: format, Wide: format == output.FormatWide, Writer: os.Stdout})
+		table.AddRows(report.Renewals)
+		if err := table.Render(); err != nil {
+			return err
+		}
+
+		if format != output.FormatCSV {
+			printCostSummary(report, groupBy)
+		}
+		return nil
+	},
+}
+
+// costsOutputFormat resolves --format, honouring the global --json flag
+func costsOutputFormat() (output.OutputFormat, error) {
+	if jsonOutput {
+		return output.FormatJSON, nil
+	}
+	switch format := output.OutputFormat(strings.ToLower(costsFormat)); format {
+	case output.FormatTable, output.FormatWide, output.FormatCSV, output.FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid --format %q (use table, wide, csv or json)", costsFormat)
+	}
+}
+
+// printCostSummary prints the per-group and overall totals below the table,
+// with subgroups indented under their group's subtotal
+func printCostSummary(report *domains.CostReport, groupBy []string) {
+	if len(report.Totals) > 0 {
+		fmt.Printf("\nTotals by %s:\n", strings.Join(groupBy, " and "))
+		for _, total := range report.Totals {
+			if total.Subgroup != "" {
+				fmt.Printf("    %-18s %10.2f %s (%d)\n", total.Subgroup, total.Amount, total.Currency, total.Domains)
+				continue
+			}
+			fmt.Printf("  %-20s %10.2f %s (%d)\n", total.Group, total.Amount, total.Currency, total.Domains)
+		}
+	}
+
+	var overall []string
+	for _, total := range report.Overall {
+		overall = append(overall, fmt.Sprintf("%.2f %s", total.Amount, total.Currency))
+	}
+	if len(overall) == 0 {
+		overall = append(overall, "no known prices")
+	}
+	fmt.Printf("\nTotal: %s across %d renewal(s)", strings.Join(overall, " + "), len(report.Renewals))
+	if report.Unknown > 0 {
+		fmt.Printf(", %d without a known price", report.Unknown)
+	}
+	fmt.Println()
+
+	jumps := 0
+	for _, renewal := range report.Renewals {
+		if renewal.PriceJump {
+			jumps++
+		}
+	}
+	if jumps > 0 {
+		fmt.Printf("⚠️  %d domain(s) renew at more than %.0f%% above their previous price\n", jumps, costsJump)
+	}
+}
+
+// priceFormatter shows a price with two decimals, or N/A when unknown
+func priceFormatter(value interface{}) string {
+	if price, ok := value.(float64); ok && price > 0 {
+		return fmt.Sprintf("%.2f", price)
+	}
+	return "N/A"
+}
+
+// priceChangeFormatter shows a percent change, or - when there was none
+func priceChangeFormatter(value interface{}) string {
+	if change, ok := value.(float64); ok && change != 0 {
+		return fmt.Sprintf("%+.1f%%", change)
+	}
+	return "-"
+}
+
+func jumpFormatter(value interface{}) string {
+	if jump, ok := value.(bool); ok && jump {
+		return "⚠️"
+	}
+	return ""
+}
+
+func emptyAsNAFormatter(value interface{}) string {
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	return "N/A"
+}
+
+func init() {
+	domainsCmd.AddCommand(costsCmd)
+
+	costsCmd.Flags().StringVar(&costsWithin, "within", "1y", "Only include renewals due within this timeframe (e.g., 90d, 6mo, 1y, or all)")
+	costsCmd.Flags().StringVar(&costsGroupBy, "group-by", "month", "Group renewals and totals by month, provider, or both (month,provider or provider,month)")
+	costsCmd.Flags().Float64Var(&costsJump, "jump", 10, "Flag renewals whose price rose by more than this percentage")
+	costsCmd.Flags().StringVar(&costsFormat, "format", "table", "Output format: table, wide, csv or json")
+	costsCmd.Flags().BoolVar(&costsTotals, "totals", false, "Only show the totals per group and currency")
+	costsCmd.Flags().BoolVar(&costsCached, "cached", false, "Use prices from the local inventory instead of asking the registrars")
+	costsCmd.Flags().BoolVar(&costsRefresh, "refresh", false, "Sync all providers before building the report")
+}
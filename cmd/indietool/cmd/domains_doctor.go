@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/indietool"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorRefresh bool
+	doctorNoProbe bool
+	doctorAll     bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [domain...]",
+	Short: "Find domains whose delegation doesn't match your DNS providers",
+	Long: `Check that every domain is delegated to a DNS provider that actually
+serves it. For each domain the registrar's nameservers are compared with
+public DNS and with the zones held by your configured DNS providers.
+
+Problems reported:
+  zone-missing           delegated to a provider that has no zone for the domain
+  unconfigured-provider  delegated to a DNS provider indietool isn't configured for
+  lame-delegation        a delegated nameserver doesn't answer for the zone
+  public-lookup          public DNS has no delegation for the domain
+  no-nameservers         the registrar reports no nameservers
+  stray-zone             a zone exists at a provider the domain isn't delegated to
+  public-mismatch        public DNS still serves other nameservers (propagation)
+
+Each problem comes with a suggested fix. The command exits with an error when
+any domain has an error-level problem, so it can run from cron.
+
+Examples:
+  indietool domains doctor
+  indietool domains doctor example.com --no-probe
+  indietool domains doctor --refresh --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry := GetProviderRegistry()
+		if registry == nil {
+			return fmt.Errorf("provider registry not initialized")
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		var domainList []domains.ManagedDomain
+		if len(args) > 0 {
+			for _, name := range args {
+				domain, err := manager.FindDomain(name)
+				if err != nil {
+					return err
+				}
+				domainList = append(domainList, domain)
+			}
+		} else {
+			result, err := manager.ListManagedDomains(domains.ListOptions{Refresh: doctorRefresh})
+			if err != nil {
+				return fmt.Errorf("failed to list domains: %w", err)
+			}
+			for _, syncResult := range result.SyncResults {
+				if !syncResult.Success {
+					log.Warnf("Last sync of %s failed, using cached domains: %s", syncResult.Provider, syncResult.Error)
+				}
+			}
+			domainList = result.Domains
+		}
+
+		reports := domains.DiagnoseDelegation(context.Background(), domainList, domains.DoctorOptions{
+			DNSProviders: indietool.GetProviders[dns.Provider](registry),
+			SkipProbe:    doctorNoProbe,
+		})
+
+		errorCount := 0
+		for _, report := range reports {
+			for _, finding := range report.Findings {
+				if finding.Severity == domains.SeverityError {
+					errorCount++
+					break
+				}
+			}
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(reports, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else {
+			printDoctorReports(reports)
+		}
+
+		if errorCount > 0 {
+			return fmt.Errorf("%d domain(s) have delegation errors", errorCount)
+		}
+		return nil
+	},
+}
+
+func printDoctorReports(reports []domains.DelegationReport) {
+	healthy := 0
+	for _, report := range reports {
+		if len(report.Findings) == 0 {
+			healthy++
+			if !doctorAll {
+				continue
+			}
+		}
+
+		delegatedTo := report.DelegatedTo
+		if delegatedTo == "" {
+			delegatedTo = "unknown DNS"
+		}
+		fmt.Printf("%s (%s → %s)\n", report.Domain, report.Registrar, delegatedTo)
+		if len(report.Findings) == 0 {
+			fmt.Printf("  ✅ delegation looks good\n\n")
+			continue
+		}
+		for _, finding := range report.Findings {
+			fmt.Printf("  %s %s\n", severityIcon(finding.Severity), finding.Message)
+			if finding.Fix != "" {
+				fmt.Printf("     fix: %s\n", finding.Fix)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(reports) == 0 {
+		fmt.Println("No domains to check.")
+		return
+	}
+
+	summary := fmt.Sprintf("%d domain(s) checked, %d without problems", len(reports), healthy)
+	if healthy > 0 && !doctorAll {
+		summary += " (use --all to list them)"
+	}
+	fmt.Println(summary)
+}
+
+func severityIcon(severity string) string {
+	switch strings.ToLower(severity) {
+	case domains.SeverityError:
+		return "❌"
+	case domains.SeverityWarning:
+		return "⚠️ "
+	default:
+		return "ℹ️ "
+	}
+}
+
+func init() {
+	domainsCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorRefresh, "refresh", false, "Sync all providers before checking")
+	doctorCmd.Flags().BoolVar(&doctorNoProbe, "no-probe", false, "Don't query each delegated nameserver directly (skips the lame delegation check)")
+	doctorCmd.Flags().BoolVar(&doctorAll, "all", false, "Also list domains without problems")
+}
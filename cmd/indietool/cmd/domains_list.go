@@ -1,14 +1,11 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"indietool/cli/domains"
 	"indietool/cli/indietool"
 	"indietool/cli/output"
 	"os"
-	"sort"
-	"sync"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
@@ -19,6 +16,9 @@ var (
 	listProviderFilter string
 	listExpiringIn     string
 	listStatus         string
+	listTag            string
+	listProject        string
+	listRefresh        bool
 	listWideOutput     bool
 	listNoHeaders      bool
 	listShowSummary    bool
@@ -31,53 +31,46 @@ var listCmd = &cobra.Command{
 	Long: `List all domains managed across your configured providers.
 Shows expiry dates, auto-renewal status, and nameserver information.
 
+Domains are served from a local inventory so listing is instant and works
+offline. Providers that have never been synced are synced automatically;
+use --refresh to pull fresh data from every provider.
+
+Tags, projects, owners and planned actions set with 'domains tag' and
+'domains note' are shown with --wide and included in --json output.
+
 Examples:
   indietool domains list
+  indietool domains list --refresh
   indietool domains list --provider cloudflare
   indietool domains list --expiring-in 30d
-  indietool domains list --status critical --json`,
+  indietool domains list --status critical --json
+  indietool domains list --tag client --project kopi --wide`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Get the global provider registry
-		registry := GetProviderRegistry()
-		if registry == nil {
-			handleError(fmt.Errorf("provider registry not initialized"))
+		manager, err := newDomainManager()
+		if err != nil {
+			handleError(err)
 			return
 		}
-
-		registrars := indietool.GetProviders[domains.Registrar](registry)
-		domainManager = domains.NewManager(registrars)
-
-		// Collect domains from all registrars
-		domainList := []domains.ManagedDomain{}
-		wg := sync.WaitGroup{}
-		domainsMux := sync.Mutex{}
-
-		for _, registrar := range registrars {
-			wg.Add(1)
-
-			go func(reg domains.Registrar) {
-				defer wg.Done()
-
-				dlist, err := reg.ListDomains(context.TODO())
-				if err != nil {
-					log.Errorf("Failed to list domains from registrar: %s", err)
-					return
-				}
-
-				domainsMux.Lock()
-				domainList = append(domainList, dlist...)
-				domainsMux.Unlock()
-			}(registrar)
-		}
-
-		wg.Wait()
-
-		sort.SliceStable(domainList, func(i, j int) bool {
-			return domainList[i].Name < domainList[j].Name
+		domainManager = manager
+
+		result, err := domainManager.ListManagedDomains(domains.ListOptions{
+			Provider:   listProviderFilter,
+			ExpiringIn: listExpiringIn,
+			Status:     listStatus,
+			Refresh:    listRefresh,
+			Tag:        listTag,
+			Project:    listProject,
 		})
+		if err != nil {
+			handleError(fmt.Errorf("failed to list domains: %w", err))
+			return
+		}
 
-		// TODO: Apply additional filters (expiring-in, status) here
-		// This would be implemented as part of the filtering logic
+		for _, syncResult := range result.SyncResults {
+			if !syncResult.Success {
+				log.Warnf("Last sync of %s failed, showing cached domains: %s", syncResult.Provider, syncResult.Error)
+			}
+		}
 
 		// Determine output format and render table
 		format := domains.GetOutputFormat(jsonOutput, listWideOutput)
@@ -89,7 +82,7 @@ Examples:
 		tableConfig := domains.GetDomainTableConfig(useColors)
 
 		table := output.NewTable(tableConfig, options)
-		table.AddRows(domainList)
+		table.AddRows(result.Domains)
 
 		if listShowSummary || (!jsonOutput && format != output.FormatJSON) {
 			if err := table.RenderWithSummary(); err != nil {
@@ -100,19 +93,42 @@ Examples:
 				handleError(fmt.Errorf("failed to render table: %w", err))
 			}
 		}
+
+		if format != output.FormatJSON && !result.LastSynced.IsZero() {
+			fmt.Printf("Last synced %s ago\n", output.RelativeTimeFormatter(result.LastSynced))
+		}
 	},
 }
 
+// newDomainManager builds a domains.Manager over the enabled registrars,
+// backed by the local domain inventory
+func newDomainManager() (*domains.Manager, error) {
+	registry := GetProviderRegistry()
+	if registry == nil {
+		return nil, fmt.Errorf("provider registry not initialized")
+	}
+
+	manager := domains.NewManager(indietool.GetProviders[domains.Registrar](registry))
+	if cfg := GetConfig(); cfg != nil {
+		manager.InventoryPath = expandTildePath(cfg.GetDomainInventoryPath())
+		manager.MetadataPath = expandTildePath(cfg.GetDomainMetadataPath())
+	}
+	return manager, nil
+}
+
 func init() {
 	domainsCmd.AddCommand(listCmd)
 
 	// Filtering flags
-	listCmd.Flags().StringVar(&listProviderFilter, "provider", "", "Filter by provider (cloudflare, namecheap, porkbun, godaddy, thelittlehost)")
+	listCmd.Flags().StringVar(&listProviderFilter, "provider", "", "Filter by provider (cloudflare, namecheap, porkbun, godaddy, thelittlehost, mock)")
 	listCmd.Flags().StringVar(&listExpiringIn, "expiring-in", "", "Show domains expiring within timeframe (e.g., 30d, 1w)")
 	listCmd.Flags().StringVar(&listStatus, "status", "", "Filter by status (healthy, warning, critical, expired)")
+	listCmd.Flags().StringVar(&listTag, "tag", "", "Filter by tag")
+	listCmd.Flags().StringVar(&listProject, "project", "", "Filter by project")
+	listCmd.Flags().BoolVar(&listRefresh, "refresh", false, "Sync domains from all providers instead of using the local inventory")
 
 	// Output format flags
-	listCmd.Flags().BoolVarP(&listWideOutput, "wide", "w", false, "Show additional columns (nameservers, cost, updated)")
+	listCmd.Flags().BoolVarP(&listWideOutput, "wide", "w", false, "Show additional columns (nameservers, cost, updated, project, tags)")
 	listCmd.Flags().BoolVar(&listNoHeaders, "no-headers", false, "Don't show column headers")
 	listCmd.Flags().BoolVar(&listShowSummary, "show-summary", true, "Show summary statistics")
 	listCmd.Flags().BoolVar(&listNoColor, "no-color", false, "Disable colored output")
@@ -121,28 +137,6 @@ func init() {
 	// --json: Output in JSON format
 }
 
-// calculateDomainSummary calculates summary statistics for a list of domains
-func calculateDomainSummary(domainList []domains.ManagedDomain) domains.DomainSummary {
-	summary := domains.DomainSummary{
-		Total: len(domainList),
-	}
-
-	for _, domain := range domainList {
-		switch domain.Status {
-		case domains.StatusHealthy:
-			summary.Healthy++
-		case domains.StatusWarning:
-			summary.Warning++
-		case domains.StatusCritical:
-			summary.Critical++
-		case domains.StatusExpired:
-			summary.Expired++
-		}
-	}
-
-	return summary
-}
-
 // handleError is a placeholder for error handling
 func handleError(err error) {
 	// TODO: Implement proper error handling
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"indietool/cli/domains"
+	"indietool/cli/indietool/notify"
+	"indietool/cli/output"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyDryRun  bool
+	notifyRefresh bool
+)
+
+// notifyResult is one expiry alert and what happened to it
+type notifyResult struct {
+	Domain     string          `json:"domain"`
+	Provider   string          `json:"provider"`
+	ExpiryDate time.Time       `json:"expiry_date"`
+	DaysLeft   int             `json:"days_left"`
+	Severity   notify.Severity `json:"severity"`
+	Status     string          `json:"status"` // sent, failed, printed or pending (dry run)
+	Sinks      []string        `json:"sinks"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// notifyResultTableConfig defines the table layout for expiry alerts
+var notifyResultTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Required: true},
+		{Name: "PROVIDER", JSONPath: "provider", Required: true},
+		{Name: "EXPIRES", JSONPath: "expiry_date", Formatter: output.AbsoluteTimeFormatter, Required: true},
+		{Name: "DAYS LEFT", JSONPath: "days_left", Required: true},
+		{Name: "SEVERITY", JSONPath: "severity", Required: true},
+		{Name: "STATUS", JSONPath: "status", Required: true},
+		{Name: "SINKS", JSONPath: "sinks", Formatter: output.StringListFormatter, Required: true},
+	},
+}
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send alerts for domains approaching expiry",
+	Long: `Check your domains against the expiry_warning_days thresholds and send
+an alert through every configured notification sink. Each threshold alerts
+once per registration period; renewing a domain resets its alerts. When a
+domain crossed several thresholds since the last run, only the most urgent
+alert is sent.
+
+This command is meant to run from cron or a systemd timer. Sinks are
+configured in the notifications section of the config file:
+
+  notifications:
+    sinks:
+      - type: webhook   # JSON payload, optional headers
+        url: https://example.com/hooks/indietool
+      - type: slack     # Slack-compatible incoming webhook
+        url: https://hooks.slack.com/services/...
+      - type: ntfy      # url defaults to https://ntfy.sh
+        topic: my-domains
+      - type: smtp
+        host: smtp.example.com
+        port: 587
+        username: alerts@example.com
+        password: secret
+        from: alerts@example.com
+        to: [me@example.com]
+      - type: command   # desktop notifications or any script
+        command: [notify-send, "{{title}}", "{{body}}"]
+
+Without sinks, alerts are printed to stdout.
+
+Examples:
+  indietool domains notify
+  indietool domains notify --dry-run
+  indietool domains notify --refresh --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		sinks, err := notify.NewSinks(cfg.Notifications)
+		if err != nil {
+			return fmt.Errorf("invalid notification config: %w", err)
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		result, err := manager.ListManagedDomains(domains.ListOptions{Refresh: notifyRefresh})
+		if err != nil {
+			return fmt.Errorf("failed to list domains: %w", err)
+		}
+		for _, syncResult := range result.SyncResults {
+			if !syncResult.Success {
+				log.Warnf("Last sync of %s failed, using cached domains: %s", syncResult.Provider, syncResult.Error)
+			}
+		}
+
+		statePath := expandTildePath(cfg.GetDomainNotifyStatePath())
+		state, err := domains.LoadExpiryNotifyState(statePath)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		alerts := state.Due(result.Domains, cfg.Domains.Management.ExpiryWarningDays, now)
+
+		sinkNames := make([]string, 0, len(sinks))
+		for _, sink := range sinks {
+			sinkNames = append(sinkNames, sink.Name())
+		}
+
+		failed := 0
+		results := make([]notifyResult, 0, len(alerts))
+		for _, alert := range alerts {
+			res := notifyResult{
+				Domain:     alert.Domain,
+				Provider:   alert.Provider,
+				ExpiryDate: alert.ExpiryDate,
+				DaysLeft:   alert.DaysLeft,
+				Severity:   alert.Severity,
+			}
+
+			switch {
+			case notifyDryRun:
+				res.Status = "pending"
+				res.Sinks = sinkNames
+			case len(sinks) == 0:
+				res.Status = "printed"
+				state.MarkNotified(alert)
+			default:
+				delivered, err := notify.Dispatch(context.Background(), sinks, alert.Notification())
+				res.Sinks = delivered
+				if err != nil {
+					res.Error = err.Error()
+					log.Warnf("Failed to deliver alert for %s: %v", alert.Domain, err)
+				}
+				// Delivered to at least one sink counts; otherwise retry next run
+				if len(delivered) > 0 {
+					res.Status = "sent"
+					state.MarkNotified(alert)
+				} else {
+					res.Status = "failed"
+					failed++
+				}
+			}
+			results = append(results, res)
+		}
+
+		if !notifyDryRun {
+			state.Prune(result.Domains, now)
+			if err := state.Save(statePath); err != nil {
+				return fmt.Errorf("failed to save notification state: %w", err)
+			}
+		}
+
+		if len(results) == 0 && !jsonOutput {
+			fmt.Println("No new expiry alerts.")
+			return nil
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(notifyResultTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(results)
+		if err := table.Render(); err != nil {
+			return err
+		}
+
+		if !jsonOutput {
+			if notifyDryRun {
+				fmt.Println("\nDry run: no alerts were sent and no state was saved.")
+			} else if len(sinks) == 0 {
+				fmt.Println("\nNo notification sinks configured; add some under 'notifications' in your config.")
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d alert(s) could not be delivered and will be retried on the next run", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	domainsCmd.AddCommand(notifyCmd)
+
+	notifyCmd.Flags().BoolVar(&notifyDryRun, "dry-run", false, "Show which alerts would be sent without sending them or saving state")
+	notifyCmd.Flags().BoolVar(&notifyRefresh, "refresh", false, "Sync all providers before checking expiry dates")
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nsResolver string
+	nsPreset   string
+	nsYes      bool
+)
+
+// nsStatus compares a domain's delegation at the registrar with public DNS
+type nsStatus struct {
+	Domain               string   `json:"domain"`
+	Registrar            string   `json:"registrar"`
+	RegistrarNameservers []string `json:"registrar_nameservers"`
+	PublicNameservers    []string `json:"public_nameservers"`
+	DNSProvider          string   `json:"dns_provider,omitempty"` // Detected from the registrar's nameservers
+	InSync               bool     `json:"in_sync"`
+	PublicError          string   `json:"public_error,omitempty"`
+}
+
+var nsCmd = &cobra.Command{
+	Use:   "ns",
+	Short: "View and change the nameservers of your domains",
+	Long: `View and change which nameservers your registrar delegates a domain to.
+
+Changing nameservers moves DNS hosting for the domain, so 'ns set' always
+previews the change against both the registrar's current delegation and what
+public DNS is serving before applying it.`,
+}
+
+var nsGetCmd = &cobra.Command{
+	Use:   "get <domain>",
+	Short: "Show a domain's nameservers at the registrar and in public DNS",
+	Long: `Show the nameservers the registrar delegates a domain to, the nameservers
+public DNS is serving, and whether the two agree. They differ for a while
+after a change until resolver caches expire.
+
+Examples:
+  indietool domains ns get example.com
+  indietool domains ns get example.com --resolver 1.1.1.1
+  indietool domains ns get example.com --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		domain, err := manager.FindDomain(args[0])
+		if err != nil {
+			return err
+		}
+
+		status := lookupNSStatus(manager, domain)
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		printNSStatus(status)
+		return nil
+	},
+}
+
+var nsSetCmd = &cobra.Command{
+	Use:   "set <domain> [nameserver...]",
+	Short: "Change the nameservers a domain is delegated to",
+	Long: `Change the nameservers a domain is delegated to at its registrar.
+
+Pass the nameservers explicitly or use --preset to fill in those of a DNS
+provider. Presets for Cloudflare and the mock provider are looked up from
+your account, so the zone must already exist there; Porkbun and Namecheap use
+their fixed nameservers.
+
+The change is previewed before it is applied. Use --yes to skip the
+confirmation, which is required when stdin is not a terminal.
+
+Examples:
+  indietool domains ns set example.com ns1.example.net ns2.example.net
+  indietool domains ns set example.com --preset cloudflare
+  indietool domains ns set example.com --preset porkbun --yes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nameservers := args[1:]
+		if nsPreset != "" && len(nameservers) > 0 {
+			return fmt.Errorf("cannot combine nameservers with --preset")
+		}
+
+		ctx := context.Background()
+		if nsPreset != "" {
+			var err error
+			if nameservers, err = presetNameservers(ctx, nsPreset, args[0]); err != nil {
+				return err
+			}
+		}
+		nameservers = dns.NormalizeNameservers(nameservers)
+		if len(nameservers) == 0 {
+			return fmt.Errorf("specify the nameservers or use --preset")
+		}
+		if len(nameservers) < 2 {
+			log.Warn("Most registries require at least two nameservers")
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		domain, err := manager.FindDomain(args[0])
+		if err != nil {
+			return err
+		}
+
+		status := lookupNSStatus(manager, domain)
+		printNSStatus(status)
+
+		if dns.SameNameservers(status.RegistrarNameservers, nameservers) {
+			fmt.Printf("\n%s is already delegated to these nameservers.\n", domain.Name)
+			return nil
+		}
+
+		fmt.Printf("\nProposed change at %s:\n", domain.Provider)
+		printNameserverDiff(status.RegistrarNameservers, nameservers)
+		if provider := dns.DetectNameserverProvider(nameservers); provider != "" {
+			fmt.Printf("\nDNS for %s will be served by %s. Make sure its records exist there first.\n", domain.Name, provider)
+		}
+
+		if !nsYes {
+			if !stdinIsTerminal() {
+				return fmt.Errorf("refusing to change nameservers without confirmation: stdin is not a terminal (use --yes)")
+			}
+			if !confirmNameserverChange(domain.Name) {
+				fmt.Println("Nameserver change cancelled")
+				return nil
+			}
+		}
+
+		if err := manager.SetNameservers(ctx, domain, nameservers); err != nil {
+			if errors.Is(err, domains.ErrNotSupported) {
+				return fmt.Errorf("%s can't change nameservers through its API: %w", domain.Provider, err)
+			}
+			return fmt.Errorf("failed to update nameservers: %w", err)
+		}
+
+		fmt.Printf("\nNameservers for %s updated. Propagation can take up to 48 hours; check with 'indietool domains ns get %s'.\n", domain.Name, domain.Name)
+		return nil
+	},
+}
+
+// lookupNSStatus gathers the registrar's and public DNS's view of domain.
+// When the registrar can't be reached the inventory's nameservers are used.
+func lookupNSStatus(manager *domains.Manager, domain domains.ManagedDomain) nsStatus {
+	ctx := context.Background()
+	status := nsStatus{Domain: domain.Name, Registrar: domain.Provider}
+
+	registrarNS, err := manager.Nameservers(ctx, domain)
+	if err != nil {
+		log.Warnf("Failed to get nameservers from %s, using the local inventory: %v", domain.Provider, err)
+		registrarNS = domain.Nameservers
+	}
+	status.RegistrarNameservers = dns.NormalizeNameservers(registrarNS)
+	status.DNSProvider = dns.DetectNameserverProvider(status.RegistrarNameservers)
+
+	publicNS, err := dns.LookupNameservers(ctx, domain.Name, nsResolver)
+	if err != nil {
+		status.PublicError = err.Error()
+		status.PublicNameservers = []string{}
+	} else {
+		status.PublicNameservers = publicNS
+		status.InSync = dns.SameNameservers(status.RegistrarNameservers, publicNS)
+	}
+
+	return status
+}
+
+func printNSStatus(status nsStatus) {
+	fmt.Printf("Domain:     %s\n", status.Domain)
+	fmt.Printf("Registrar:  %s\n", status.Registrar)
+	if status.DNSProvider != "" {
+		fmt.Printf("DNS hosted: %s\n", status.DNSProvider)
+	}
+
+	fmt.Printf("\nRegistrar delegation:\n")
+	printNameserverList(status.RegistrarNameservers)
+
+	fmt.Printf("\nPublic DNS:\n")
+	if status.PublicError != "" {
+		fmt.Printf("  (lookup failed: %s)\n", status.PublicError)
+		return
+	}
+	printNameserverList(status.PublicNameservers)
+
+	if status.InSync {
+		fmt.Printf("\n✅ Public DNS matches the registrar\n")
+	} else {
+		fmt.Printf("\n⚠️  Public DNS differs from the registrar (a recent change may still be propagating)\n")
+	}
+}
+
+func printNameserverList(nameservers []string) {
+	if len(nameservers) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	for _, ns := range nameservers {
+		fmt.Printf("  %s\n", ns)
+	}
+}
+
+// printNameserverDiff shows removed nameservers with - and added ones with +
+func printNameserverDiff(current, proposed []string) {
+	for _, ns := range current {
+		if !slices.Contains(proposed, ns) {
+			fmt.Printf("  - %s\n", ns)
+		}
+	}
+	for _, ns := range proposed {
+		if slices.Contains(current, ns) {
+			fmt.Printf("    %s\n", ns)
+		} else {
+			fmt.Printf("  + %s\n", ns)
+		}
+	}
+}
+
+// presetNameservers resolves --preset. A configured provider that assigns
+// nameservers per zone is asked first; otherwise the fixed preset is used.
+func presetNameservers(ctx context.Context, preset, domain string) ([]string, error) {
+	preset = strings.ToLower(preset)
+
+	if registry := GetProviderRegistry(); registry != nil {
+		if provider, ok := registry.Get(preset); ok {
+			if assigner, ok := provider.(dns.NameserverAssigner); ok {
+				nameservers, err := assigner.AssignedNameservers(ctx, domain)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get %s nameservers for %s: %w", preset, domain, err)
+				}
+				return nameservers, nil
+			}
+		}
+	}
+
+	if nameservers, ok := dns.PresetNameservers(preset); ok {
+		return nameservers, nil
+	}
+
+	if preset == "cloudflare" {
+		return nil, fmt.Errorf("cloudflare assigns nameservers per zone; configure the cloudflare provider or pass the nameservers explicitly")
+	}
+	return nil, fmt.Errorf("no nameserver preset for %q (available: %s, or a configured cloudflare/mock provider)", preset, strings.Join(dns.GetPresetProviders(), ", "))
+}
+
+// confirmNameserverChange asks the user to confirm a delegation change
+func confirmNameserverChange(domain string) bool {
+	fmt.Printf("\nChange nameservers for %s? [y/N]: ", domain)
+
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+func init() {
+	domainsCmd.AddCommand(nsCmd)
+	nsCmd.AddCommand(nsGetCmd)
+	nsCmd.AddCommand(nsSetCmd)
+
+	nsCmd.PersistentFlags().StringVar(&nsResolver, "resolver", "", "DNS resolver for the public lookup, e.g. 1.1.1.1 (default: system resolver)")
+	nsSetCmd.Flags().StringVar(&nsPreset, "preset", "", "Use the nameservers of a DNS provider (cloudflare, porkbun, namecheap, mock)")
+	nsSetCmd.Flags().BoolVarP(&nsYes, "yes", "y", false, "Apply the change without confirmation")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// syncResultTableConfig defines the table layout for sync results
+var syncResultTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "PROVIDER", JSONPath: "provider", Required: true},
+		{Name: "DOMAINS", JSONPath: "domains_count", Required: true},
+		{Name: "SUCCESS", JSONPath: "success", Formatter: output.YesNoFormatter, Required: true},
+		{Name: "ERROR", JSONPath: "error", Required: true},
+	},
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [provider...]",
+	Short: "Sync the local domain inventory from your providers",
+	Long: `Pull domains from your configured providers and store them in the local
+inventory used by 'domains list'. All enabled providers are synced
+concurrently unless specific providers are named.
+
+A provider that fails to sync keeps its previously cached domains.
+
+Examples:
+  indietool domains sync
+  indietool domains sync porkbun cloudflare
+  indietool domains sync --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		results, err := manager.SyncDomains(args)
+		if err != nil {
+			return fmt.Errorf("failed to sync domains: %w", err)
+		}
+
+		rows := make([]domains.SyncResult, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, result)
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i].Provider < rows[j].Provider
+		})
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+
+		table := output.NewTable(syncResultTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(rows)
+		return table.Render()
+	},
+}
+
+func init() {
+	domainsCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagRemove  bool
+	tagProject string
+	tagOwner   string
+	tagAction  string
+	noteAppend bool
+	noteClear  bool
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag <domain> [tag...]",
+	Short: "Tag a domain and set its project, owner and planned action",
+	Long: `Attach local metadata to a domain: tags, the project it belongs to, who
+owns it and what you plan to do with it at renewal (keep, sell, let-expire).
+
+Metadata is kept in a YAML file next to your config, or wherever
+domains.metadata_file points, so it can be committed to a team repo. It is
+merged into 'domains list', which can filter on it.
+
+Without tags or flags, the domain's current metadata is shown.
+
+Examples:
+  indietool domains tag kopi.dev client shop --project kopi --owner ana
+  indietool domains tag kopi.dev --action let-expire
+  indietool domains tag kopi.dev shop --remove
+  indietool domains tag kopi.dev --project ""
+  indietool domains list --tag client --project kopi`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := asciiDomainArg(args[0])
+		if err != nil {
+			return err
+		}
+		tags := args[1:]
+
+		var action domains.PlannedAction
+		if cmd.Flags().Changed("action") {
+			if action, err = domains.ParsePlannedAction(tagAction); err != nil {
+				return err
+			}
+		}
+
+		metadata, path, err := loadPortfolioMetadata()
+		if err != nil {
+			return err
+		}
+
+		changed := len(tags) > 0 || cmd.Flags().Changed("project") || cmd.Flags().Changed("owner") || cmd.Flags().Changed("action")
+		if !changed {
+			printDomainMetadata(name, metadata.Get(name))
+			return nil
+		}
+		if tagRemove && len(tags) == 0 {
+			return fmt.Errorf("--remove needs the tags to remove")
+		}
+
+		entry := metadata.Entry(name)
+		if tagRemove {
+			entry.RemoveTags(tags...)
+		} else {
+			entry.AddTags(tags...)
+		}
+		if cmd.Flags().Changed("project") {
+			entry.Project = strings.TrimSpace(tagProject)
+		}
+		if cmd.Flags().Changed("owner") {
+			entry.Owner = strings.TrimSpace(tagOwner)
+		}
+		if cmd.Flags().Changed("action") {
+			entry.Action = action
+		}
+
+		if err := metadata.Save(path); err != nil {
+			return fmt.Errorf("failed to save domain metadata: %w", err)
+		}
+		warnIfUnmanaged(name)
+		printDomainMetadata(name, metadata.Get(name))
+		return nil
+	},
+}
+
+var noteCmd = &cobra.Command{
+	Use:   "note <domain> [text...]",
+	Short: "Keep free-text notes about a domain",
+	Long: `Set the notes kept with a domain, such as why it was bought or who asked
+for it. Notes live in the same metadata file as tags and are included in
+'domains list --json'.
+
+Without text, the domain's current notes are shown.
+
+Examples:
+  indietool domains note kopi.dev "Bought for the coffee app launch, ask ana before renewing"
+  indietool domains note kopi.dev --append "Offer from a buyer: $400"
+  indietool domains note kopi.dev --clear`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := asciiDomainArg(args[0])
+		if err != nil {
+			return err
+		}
+		text := strings.TrimSpace(strings.Join(args[1:], " "))
+
+		metadata, path, err := loadPortfolioMetadata()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case noteClear && text != "":
+			return fmt.Errorf("cannot combine --clear with note text")
+		case noteClear:
+			if entry := metadata.Get(name); entry != nil {
+				entry.Notes = ""
+			}
+		case text == "":
+			if entry := metadata.Get(name); entry != nil && entry.Notes != "" {
+				fmt.Println(entry.Notes)
+			} else {
+				fmt.Printf("No notes for %s.\n", dns.DisplayName(name))
+			}
+			return nil
+		case noteAppend:
+			entry := metadata.Entry(name)
+			if entry.Notes != "" {
+				entry.Notes += "\n"
+			}
+			entry.Notes += text
+		default:
+			metadata.Entry(name).Notes = text
+		}
+
+		if err := metadata.Save(path); err != nil {
+			return fmt.Errorf("failed to save domain metadata: %w", err)
+		}
+		if noteClear {
+			fmt.Printf("Cleared the notes for %s.\n", dns.DisplayName(name))
+			return nil
+		}
+		warnIfUnmanaged(name)
+		fmt.Printf("Saved the notes for %s.\n", dns.DisplayName(name))
+		return nil
+	},
+}
+
+// loadPortfolioMetadata loads the domain metadata file and returns it with
+// its path
+func loadPortfolioMetadata() (*domains.PortfolioMetadata, string, error) {
+	cfg := GetConfig()
+	if cfg == nil {
+		return nil, "", fmt.Errorf("no configuration loaded")
+	}
+	path := expandTildePath(cfg.GetDomainMetadataPath())
+	metadata, err := domains.LoadPortfolioMetadata(path)
+	return metadata, path, err
+}
+
+// warnIfUnmanaged warns when name isn't in the local domain inventory,
+// which usually means a typo. Metadata is kept either way.
+func warnIfUnmanaged(name string) {
+	cfg := GetConfig()
+	if cfg == nil {
+		return
+	}
+	inventory, err := domains.LoadInventory(expandTildePath(cfg.GetDomainInventoryPath()))
+	if err != nil || len(inventory.Domains) == 0 {
+		return
+	}
+	for _, domain := range inventory.Domains {
+		if strings.EqualFold(domain.Name, name) {
+			return
+		}
+	}
+	log.Warnf("%s is not in your synced domains; the metadata is kept anyway", name)
+}
+
+// printDomainMetadata shows the metadata recorded for a domain
+func printDomainMetadata(name string, entry *domains.DomainMetadata) {
+	if entry == nil || entry.IsEmpty() {
+		fmt.Printf("No metadata for %s.\n", dns.DisplayName(name))
+		return
+	}
+	dash := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	fmt.Printf("%s\n", dns.DisplayName(name))
+	fmt.Printf("  Tags:    %s\n", dash(strings.Join(entry.Tags, ", ")))
+	fmt.Printf("  Project: %s\n", dash(entry.Project))
+	fmt.Printf("  Owner:   %s\n", dash(entry.Owner))
+	fmt.Printf("  Action:  %s\n", dash(string(entry.Action)))
+	if entry.Notes != "" {
+		fmt.Printf("  Notes:   %s\n", strings.ReplaceAll(entry.Notes, "\n", "\n           "))
+	}
+}
+
+func init() {
+	domainsCmd.AddCommand(tagCmd)
+	domainsCmd.AddCommand(noteCmd)
+
+	tagCmd.Flags().BoolVar(&tagRemove, "remove", false, "Remove the given tags instead of adding them")
+	tagCmd.Flags().StringVar(&tagProject, "project", "", "Project the domain belongs to (empty to clear)")
+	tagCmd.Flags().StringVar(&tagOwner, "owner", "", "Person or team responsible for the domain (empty to clear)")
+	tagCmd.Flags().StringVar(&tagAction, "action", "", "Planned action at renewal: keep, sell, let-expire or none")
+	noteCmd.Flags().BoolVar(&noteAppend, "append", false, "Add a line to the existing notes instead of replacing them")
+	noteCmd.Flags().BoolVar(&noteClear, "clear", false, "Remove the notes")
+}
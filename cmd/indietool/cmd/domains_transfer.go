@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"indietool/cli/domains"
+	"indietool/cli/output"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	transferTo       string
+	transferAuthCode string
+	transferYes      bool
+	transferDone     bool
+)
+
+// transferSteps is the order in which a transfer's steps are shown
+var transferSteps = []domains.TransferStep{
+	domains.TransferStepUnlock,
+	domains.TransferStepAuthCode,
+	domains.TransferStepInitiate,
+	domains.TransferStepAwait,
+}
+
+// transferTableConfig defines the table layout for tracked transfers
+var transferTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Required: true},
+		{Name: "FROM", JSONPath: "from", Required: true},
+		{Name: "TO", JSONPath: "to", Required: true},
+		{Name: "STEP", JSONPath: "step", Required: true},
+		{Name: "STARTED", JSONPath: "started_at", Formatter: output.AbsoluteTimeFormatter, Required: true},
+		{Name: "UPDATED", JSONPath: "updated_at", Formatter: output.AbsoluteTimeFormatter, Required: true},
+	},
+}
+
+var transferCmd = &cobra.Command{
+	Use:   "transfer <domain> --to <provider>",
+	Short: "Move a domain to another registrar, step by step",
+	Long: `Walk through moving a domain between two configured registrars:
+
+  1. unlock     remove the transfer lock at the current registrar
+  2. auth-code  get the auth (EPP) code from the current registrar
+  3. initiate   request the transfer at the new registrar
+  4. await      wait for the registry to complete it (usually 5-7 days)
+
+Steps are done through the registrars' APIs where they allow it and by hand
+otherwise; indietool tells you what to do and asks you to confirm. Progress
+is saved, so run the same command again to resume, even days later.
+
+Changes at a registrar are confirmed first; use --yes to approve them and
+--done to confirm a manual step when stdin is not a terminal.
+
+Examples:
+  indietool domains transfer example.com --to porkbun
+  indietool domains transfer example.com --auth-code 'Xy7#...'
+  indietool domains transfer list
+  indietool domains transfer cancel example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		storePath := expandTildePath(cfg.GetDomainTransfersPath())
+		store, err := domains.LoadTransfers(storePath)
+		if err != nil {
+			return err
+		}
+
+		transfer, ok := store.Get(args[0])
+		switch {
+		case ok && transferTo != "" && !strings.EqualFold(transferTo, transfer.To):
+			return fmt.Errorf("%s is already being transferred to %s; cancel it first with 'indietool domains transfer cancel %s'", transfer.Domain, transfer.To, transfer.Domain)
+		case ok && transfer.Done():
+			printTransfer(transfer)
+			return nil
+		case !ok:
+			if transferTo == "" {
+				return fmt.Errorf("no transfer of %s in progress; start one with --to <provider>", args[0])
+			}
+			domain, err := manager.FindDomain(args[0])
+			if err != nil {
+				return err
+			}
+			to := strings.ToLower(transferTo)
+			if to == domain.Provider {
+				return fmt.Errorf("%s is already registered with %s", domain.Name, to)
+			}
+			if _, ok := manager.Registrar(to); !ok {
+				return fmt.Errorf("provider %s is not configured as a registrar", to)
+			}
+			transfer = store.Start(domain.Name, domain.Provider, to, time.Now())
+		}
+
+		prompter := newTransferPrompter()
+		options := domains.TransferOptions{
+			AuthCode: transferAuthCode,
+			Approve:  prompter.approve,
+			Confirm:  prompter.confirm,
+		}
+
+		ctx := context.Background()
+		advanceErr := manager.AdvanceTransfer(ctx, transfer, options)
+
+		// Offer to paste the auth code when the registrar can't provide it
+		if advanceErr == nil && transfer.Step == domains.TransferStepAuthCode && transfer.Waiting != "" && prompter.interactive && !jsonOutput {
+			fmt.Printf("%s\n", transfer.Waiting)
+			if code := prompter.ask("Auth code (leave empty to continue later): "); code != "" {
+				options.AuthCode = code
+				advanceErr = manager.AdvanceTransfer(ctx, transfer, options)
+			}
+		}
+
+		if err := store.Save(storePath); err != nil {
+			return fmt.Errorf("failed to save transfer progress: %w", err)
+		}
+
+		if transfer.Done() {
+			if _, err := manager.SyncDomains([]string{transfer.From, transfer.To}); err != nil {
+				log.Warnf("Failed to sync the domain inventory: %v", err)
+			}
+		}
+
+		if jsonOutput {
+			redacted := *transfer
+			redacted.AuthCode = ""
+			data, err := json.MarshalIndent(redacted, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		} else {
+			printTransfer(transfer)
+		}
+
+		return advanceErr
+	},
+}
+
+var transferListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tracked transfers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		store, err := domains.LoadTransfers(expandTildePath(cfg.GetDomainTransfersPath()))
+		if err != nil {
+			return err
+		}
+
+		transfers := store.List()
+		if len(transfers) == 0 && !jsonOutput {
+			fmt.Println("No transfers in progress.")
+			return nil
+		}
+
+		rows := make([]domains.Transfer, 0, len(transfers))
+		for _, transfer := range transfers {
+			redacted := *transfer
+			redacted.AuthCode = ""
+			rows = append(rows, redacted)
+		}
+
+		format := output.FormatTable
+		if jsonOutput {
+			format = output.FormatJSON
+		}
+		table := output.NewTable(transferTableConfig, output.TableOptions{Format: format, Writer: os.Stdout})
+		table.AddRows(rows)
+		return table.Render()
+	},
+}
+
+var transferCancelCmd = &cobra.Command{
+	Use:   "cancel <domain>",
+	Short: "Stop tracking a transfer",
+	Long: `Stop tracking a transfer and forget its saved progress, including the
+auth code. Nothing is changed at either registrar: a transfer that was
+already requested must be cancelled there, and a removed transfer lock is
+not restored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("no configuration loaded")
+		}
+
+		storePath := expandTildePath(cfg.GetDomainTransfersPath())
+		store, err := domains.LoadTransfers(storePath)
+		if err != nil {
+			return err
+		}
+
+		transfer, ok := store.Get(args[0])
+		if !ok {
+			return fmt.Errorf("no transfer of %s is tracked", args[0])
+		}
+		store.Remove(transfer.Domain)
+		if err := store.Save(storePath); err != nil {
+			return fmt.Errorf("failed to save transfer progress: %w", err)
+		}
+
+		fmt.Printf("Stopped tracking the transfer of %s.\n", transfer.Domain)
+		if !transfer.InitiatedAt.IsZero() && !transfer.Done() {
+			fmt.Printf("The transfer was already requested at %s; cancel it there if needed.\n", transfer.To)
+		}
+		return nil
+	},
+}
+
+// printTransfer shows the steps of a transfer and what it is waiting for
+func printTransfer(transfer *domains.Transfer) {
+	fmt.Printf("\nTransfer of %s: %s → %s\n\n", transfer.Domain, transfer.From, transfer.To)
+
+	messages := make(map[domains.TransferStep]string)
+	for _, event := range transfer.Events {
+		messages[event.Step] = event.Message
+	}
+
+	reached := false
+	for _, step := range transferSteps {
+		switch {
+		case transfer.Done() || (!reached && step != transfer.Step):
+			fmt.Printf("  ✅ %-10s %s\n", step, messages[step])
+		case step == transfer.Step:
+			reached = true
+			fmt.Printf("  ⏳ %-10s %s\n", step, transfer.Waiting)
+		default:
+			fmt.Printf("  ·  %s\n", step)
+		}
+	}
+
+	if transfer.Done() {
+		fmt.Printf("\n%s is now registered with %s.\n", transfer.Domain, transfer.To)
+		return
+	}
+	fmt.Printf("\nRun 'indietool domains transfer %s' to resume.\n", transfer.Domain)
+}
+
+// transferPrompter asks the user to approve registrar changes and confirm
+// manual steps, honouring --yes and --done when stdin is not a terminal
+type transferPrompter struct {
+	interactive bool
+	doneUsed    bool
+	reader      *bufio.Reader
+}
+
+func newTransferPrompter() *transferPrompter {
+	return &transferPrompter{
+		interactive: stdinIsTerminal(),
+		reader:      bufio.NewReader(os.Stdin),
+	}
+}
+
+func (p *transferPrompter) approve(action string) bool {
+	if transferYes {
+		return true
+	}
+	if !p.interactive {
+		return false
+	}
+	response := p.ask(fmt.Sprintf("%s? [y/N]: ", action))
+	return strings.EqualFold(response, "y") || strings.EqualFold(response, "yes")
+}
+
+// confirm asks whether a manual step was done. --done confirms only the
+// first manual step, so one flag can't skip several.
+func (p *transferPrompter) confirm(instructions string) bool {
+	if transferDone && !p.doneUsed {
+		p.doneUsed = true
+		return true
+	}
+	if !p.interactive {
+		return false
+	}
+	fmt.Println(instructions)
+	response := p.ask("Done? [y/N]: ")
+	return strings.EqualFold(response, "y") || strings.EqualFold(response, "yes")
+}
+
+func (p *transferPrompter) ask(prompt string) string {
+	fmt.Print(prompt)
+	response, err := p.reader.ReadString('\n')
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(response)
+}
+
+func init() {
+	domainsCmd.AddCommand(transferCmd)
+	transferCmd.AddCommand(transferListCmd)
+	transferCmd.AddCommand(transferCancelCmd)
+
+	transferCmd.Flags().StringVar(&transferTo, "to", "", "Registrar to move the domain to (required to start a transfer)")
+	transferCmd.Flags().StringVar(&transferAuthCode, "auth-code", "", "Auth (EPP) code, when the current registrar can't provide it")
+	transferCmd.Flags().BoolVarP(&transferYes, "yes", "y", false, "Approve changes at the registrars without asking")
+	transferCmd.Flags().BoolVar(&transferDone, "done", false, "Confirm that the pending manual step was completed")
+}
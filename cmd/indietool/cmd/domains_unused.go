@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"indietool/cli/indietool"
+	"indietool/cli/output"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	unusedMinScore int
+	unusedAll      bool
+	unusedCached   bool
+	unusedRefresh  bool
+	unusedWide     bool
+)
+
+// unusedTableConfig defines the table layout for candidates to let expire
+var unusedTableConfig = output.TableConfig{
+	DefaultColumns: []output.Column{
+		{Name: "DOMAIN", JSONPath: "domain", Required: true},
+		{Name: "SCORE", JSONPath: "score", Required: true},
+		{Name: "RENEWS", JSONPath: "renewal_date", Formatter: output.AbsoluteTimeFormatter, Required: true},
+		{Name: "PRICE", JSONPath: "renewal_price", Formatter: priceFormatter, Required: true},
+		{Name: "SIGNALS", JSONPath: "signals", Formatter: tldListFormatter, Required: true},
+	},
+	WideColumns: []output.Column{
+		{Name: "PROVIDER", JSONPath: "provider"},
+		{Name: "AUTO-RENEW", JSONPath: "auto_renewal", Formatter: output.OnOffFormatter},
+		{Name: "CURRENCY", JSONPath: "currency", Formatter: emptyAsNAFormatter},
+		{Name: "PROJECT", JSONPath: "project", Formatter: emptyAsDashFormatter},
+		{Name: "ACTION", JSONPath: "action", Formatter: emptyAsDashFormatter},
+	},
+}
+
+var unusedCmd = &cobra.Command{
+	Use:   "unused [domain...]",
+	Short: "Find domains that serve nothing and are worth letting expire",
+	Long: `Check each managed domain for signs that it isn't used, and rank the
+candidates to let expire with the evidence behind each.
+
+Signals and how much they add to the score (0-100):
+  no-records      40  nothing in DNS beyond NS, SOA and the DNS host's defaults
+  parked          40  only parking records, or the website is a parking page
+  no-http         30  no web server answers on http or https
+  no-mx           15  no MX records, so the domain receives no mail
+  no-certificate  15  nothing on port 443 presents a certificate for it
+
+Records are read from the DNS zone when the domain is hosted at a configured
+DNS provider, otherwise from public DNS. Candidates with the same score are
+ranked by renewal price (looked up from the registrar) and then by how soon
+they renew. Domains planned to keep ('domains tag <domain> --action keep')
+are left out unless --all is given.
+
+Examples:
+  indietool domains unused
+  indietool domains unused --min-score 70 --cached
+  indietool domains unused sideproject.dev --all
+  indietool domains unused --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry := GetProviderRegistry()
+		if registry == nil {
+			return fmt.Errorf("provider registry not initialized")
+		}
+
+		manager, err := newDomainManager()
+		if err != nil {
+			return err
+		}
+
+		var domainList []domains.ManagedDomain
+		if len(args) > 0 {
+			for _, name := range args {
+				domain, err := manager.FindDomain(name)
+				if err != nil {
+					return err
+				}
+				domainList = append(domainList, domain)
+			}
+		} else {
+			result, err := manager.ListManagedDomains(domains.ListOptions{Refresh: unusedRefresh})
+			if err != nil {
+				return fmt.Errorf("failed to list domains: %w", err)
+			}
+			for _, syncResult := range result.SyncResults {
+				if !syncResult.Success {
+					log.Warnf("Last sync of %s failed, using cached domains: %s", syncResult.Provider, syncResult.Error)
+				}
+			}
+			domainList = result.Domains
+		}
+
+		if !unusedCached {
+			lookupErrs, err := manager.LookupRenewalCosts(context.Background(), domainList)
+			if err != nil {
+				log.Warnf("Failed to store renewal prices in the domain inventory: %v", err)
+			}
+			for name, err := range lookupErrs {
+				if !errors.Is(err, domains.ErrNotSupported) {
+					log.Warnf("Couldn't look up the renewal price of %s: %v", name, err)
+				}
+			}
+		}
+
+		dnsManager := dns.NewManager(indietool.GetProviders[dns.Provider](registry))
+		candidates := domains.FindUnusedDomains(context.Background(), domainList, domains.UnusedOptions{
+			ZoneRecords: func(ctx context.Context, domain string) ([]dns.Record, error) {
+				records, _, err := dnsManager.ListRecords(ctx, domain, "")
+				return records, err
+			},
+		})
+
+		shown, unchecked := []domains.UnusedCandidate{}, []domains.UnusedCandidate{}
+		candidateCount := 0
+		for _, candidate := range candidates {
+			switch {
+			case candidate.Error != "":
+				unchecked = append(unchecked, candidate)
+			case isUnusedCandidate(candidate):
+				candidateCount++
+				shown = append(shown, candidate)
+			case unusedAll:
+				shown = append(shown, candidate)
+			}
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(append(shown, unchecked...), "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(domainList) == 0 {
+			fmt.Println("No domains to check.")
+			return nil
+		}
+
+		rows := make([]map[string]interface{}, 0, len(shown))
+		for _, candidate := range shown {
+			rows = append(rows, map[string]interface{}{
+				"domain":        candidate.Domain,
+				"provider":      candidate.Provider,
+				"score":         candidate.Score,
+				"renewal_date":  candidate.RenewalDate,
+				"renewal_price": candidate.RenewalPrice,
+				"currency":      candidate.Currency,
+				"auto_renewal":  candidate.AutoRenewal,
+				"project":       candidate.Project,
+				"action":        string(candidate.Action),
+				"signals":       candidate.Signals(),
+			})
+		}
+
+		if len(rows) > 0 {
+			format := output.FormatTable
+			if unusedWide {
+				format = output.FormatWide
+			}
+			table := output.NewTable(unusedTableConfig, output.TableOptions{Format: format, Wide: unusedWide, Writer: os.Stdout})
+			table.AddRows(rows)
+			if err := table.Render(); err != nil {
+				return err
+			}
+			printUnusedEvidence(shown)
+		}
+
+		checked := len(candidates) - len(unchecked)
+		summary := fmt.Sprintf("%d domain(s) checked, %d candidate(s) to let expire", checked, candidateCount)
+		if len(unchecked) > 0 {
+			summary += fmt.Sprintf(", %d couldn't be checked", len(unchecked))
+		}
+		fmt.Println(summary)
+		for _, candidate := range unchecked {
+			fmt.Printf("  %s: %s\n", candidate.Domain, candidate.Error)
+		}
+		return nil
+	},
+}
+
+// isUnusedCandidate reports whether a checked domain scores at least
+// --min-score and isn't planned to keep
+func isUnusedCandidate(candidate domains.UnusedCandidate) bool {
+	return candidate.Score >= unusedMinScore && candidate.Action != domains.ActionKeep
+}
+
+// printUnusedEvidence lists what was found for each domain below the table
+func printUnusedEvidence(candidates []domains.UnusedCandidate) {
+	fmt.Println("\nEvidence:")
+	for _, candidate := range candidates {
+		if len(candidate.Evidence) == 0 {
+			fmt.Printf("  %s: looks in use\n", candidate.Domain)
+			continue
+		}
+		fmt.Printf("  %s (%d days to renewal)\n", candidate.Domain, candidate.DaysToRenew)
+		for _, evidence := range candidate.Evidence {
+			fmt.Printf("    %-15s %s\n", evidence.Signal, strings.TrimSpace(evidence.Detail))
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	domainsCmd.AddCommand(unusedCmd)
+
+	unusedCmd.Flags().IntVar(&unusedMinScore, "min-score", domains.DefaultUnusedMinScore, "Lowest score (0-100) listed as a candidate to let expire")
+	unusedCmd.Flags().BoolVar(&unusedAll, "all", false, "List every domain with its score, including ones planned to keep")
+	unusedCmd.Flags().BoolVar(&unusedCached, "cached", false, "Use the last known renewal prices instead of asking the registrars")
+	unusedCmd.Flags().BoolVarP(&unusedWide, "wide", "w", false, "Show additional columns (provider, auto-renew, currency, project, action)")
+	unusedCmd.Flags().BoolVar(&unusedRefresh, "refresh", false, "Sync all providers before checking")
+}
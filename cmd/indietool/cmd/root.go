@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"indietool/cli/indietool"
 	"indietool/cli/indietool/metrics"
 	"os"
@@ -70,6 +71,10 @@ func Execute() {
 	// Wait for all pending tracking items to complete
 	pendingItemsWG.Wait()
 
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.code)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
@@ -208,6 +213,12 @@ func initProviderRegistry(cfg *indietool.Config) {
 			enabledCount++
 		}
 	}
+	if cfg.Providers.Mock != nil {
+		configuredCount++
+		if cfg.Providers.Mock.Enabled {
+			enabledCount++
+		}
+	}
 
 	if configuredCount > 0 {
 		log.Debugf("Configured %d provider(s)", configuredCount)
@@ -49,4 +49,6 @@ func init() {
 	secretsCmd.AddCommand(secretsImportCmd)
 	secretsCmd.AddCommand(secretsShardCmd)
 	secretsCmd.AddCommand(secretsJoinCmd)
+	secretsCmd.AddCommand(secretsRunCmd)
+	secretsCmd.AddCommand(secretsRenderCmd)
 }
@@ -17,12 +17,16 @@ import (
 
 var secretsExportCmd = &cobra.Command{
 	Use:   "export <@db | secret[@db]> [<@db | secret[@db]> ...]",
-	Short: "Export secrets to a portable JSON file",
-	Long: `Export individual secrets or entire databases to a JSON file.
+	Short: "Export secrets to a portable JSON or .env file",
+	Long: `Export individual secrets or entire databases to a JSON file, or to a .env
+file with --format dotenv.
 
 By default the export is passphrase-encrypted for safe transport.
 Use -P / --no-passphrase for plaintext output (e.g. when piping to another tool).
 
+In dotenv format each secret becomes a KEY=value line named after the secret
+(openai-key becomes OPENAI_KEY), with its note as comment lines above it.
+
 Specify what to export using one or more arguments:
   @<db>           export all secrets from a database
   <name>[@<db>]   export a single secret (omit @db to target the default database)
@@ -38,14 +42,18 @@ Examples:
   indietool secret export @production mysecret@staging --out backup.json
 
   # Plaintext output (pipe-friendly)
-  indietool secret export @default --no-passphrase | jq .`,
+  indietool secret export @default --no-passphrase | jq .
+
+  # Write a .env file for a project
+  indietool secret export @proj --format dotenv -P --out .env`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: exportSecrets,
 }
 
 func init() {
 	secretsExportCmd.Flags().StringP("out", "o", "", "Output file (default: stdout)")
-	secretsExportCmd.Flags().BoolP("no-passphrase", "P", false, "Write plaintext JSON instead of passphrase-encrypting the output")
+	secretsExportCmd.Flags().BoolP("no-passphrase", "P", false, "Write plaintext instead of passphrase-encrypting the output")
+	secretsExportCmd.Flags().String("format", secrets.FormatJSON, "Output format: json or dotenv")
 }
 
 func exportSecrets(cmd *cobra.Command, args []string) error {
@@ -56,6 +64,10 @@ func exportSecrets(cmd *cobra.Command, args []string) error {
 
 	noPassphrase, _ := cmd.Flags().GetBool("no-passphrase")
 	outFile, _ := cmd.Flags().GetString("out")
+	format, _ := cmd.Flags().GetString("format")
+	if format != secrets.FormatJSON && format != secrets.FormatDotenv {
+		return fmt.Errorf("unsupported format %q (use json or dotenv)", format)
+	}
 
 	secretsConfig := cfg.GetSecretsConfig()
 	defaultDB := secretsConfig.GetDefaultDatabase()
@@ -107,14 +119,19 @@ func exportSecrets(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to export secrets: %w", err)
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	var plaintext []byte
+	if format == secrets.FormatDotenv {
+		plaintext, err = marshalDotenvExport(data)
+	} else {
+		plaintext, err = json.MarshalIndent(data, "", "  ")
+	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal export data: %w", err)
 	}
 
 	var payload []byte
 	if noPassphrase {
-		payload = jsonData
+		payload = plaintext
 	} else {
 		passphrase, err := promptPassphrase("Enter export passphrase: ", true)
 		if err != nil {
@@ -129,7 +146,7 @@ func exportSecrets(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to initialize encryption: %w", err)
 		}
-		if _, err := w.Write(jsonData); err != nil {
+		if _, err := w.Write(plaintext); err != nil {
 			return fmt.Errorf("failed to encrypt data: %w", err)
 		}
 		if err := w.Close(); err != nil {
@@ -157,6 +174,23 @@ func exportSecrets(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// marshalDotenvExport renders the exported databases as one .env file, in
+// database order
+func marshalDotenvExport(data *secrets.ExportData) ([]byte, error) {
+	var buf bytes.Buffer
+	dbs := sortedKeys(data.Databases)
+	fmt.Fprintf(&buf, "# Exported by indietool from @%s on %s\n\n", strings.Join(dbs, ", @"), data.ExportedAt.Format("2006-01-02"))
+
+	var list []*secrets.Secret
+	for _, db := range dbs {
+		list = append(list, data.Databases[db]...)
+	}
+	if err := secrets.WriteDotenv(&buf, list); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func printExportSummary(databases map[string][]*secrets.Secret) {
 	fmt.Fprintln(os.Stderr, "Exported")
 	for _, db := range sortedKeys(databases) {
@@ -4,25 +4,32 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"indietool/cli/indietool/secrets"
 	"os"
 	"strings"
 
 	"filippo.io/age"
 	"github.com/spf13/cobra"
-	"indietool/cli/indietool/secrets"
 )
 
 // ageMagic is the header written by filippo.io/age for binary-format encrypted files.
 const ageMagic = "age-encryption.org/v1"
 
 var secretsImportCmd = &cobra.Command{
-	Use:   "import <input-file>",
-	Short: "Import secrets from an exported JSON file",
+	Use:   "import <input-file | file.env[@db]>",
+	Short: "Import secrets from an exported JSON file or a .env file",
 	Long: `Import secrets from a previously exported JSON file into the local instance.
 
 Encrypted exports are detected automatically and will prompt for a passphrase.
 Use --force to overwrite secrets that already exist locally.
 
+With --format dotenv, each KEY=value line of a .env file is stored as a secret
+named KEY in the database given after @ or with --db (the default database
+without either). Quoted and multiline values are supported, and comment lines
+directly above a key become the secret's note:
+  indietool secret import --format dotenv .env@proj
+  indietool secret import --format dotenv ~/work@home/.env --db proj
+
 Use --remap to redirect a source database to a different name on import:
   --remap old:new          rename one database
   --remap a:x --remap b:x  merge two databases into one`,
@@ -33,6 +40,8 @@ Use --remap to redirect a source database to a different name on import:
 func init() {
 	secretsImportCmd.Flags().BoolP("force", "f", false, "Overwrite existing secrets")
 	secretsImportCmd.Flags().StringArrayP("remap", "r", nil, "Rename a database on import: old:new (repeatable)")
+	secretsImportCmd.Flags().String("format", secrets.FormatJSON, "Input format: json or dotenv")
+	secretsImportCmd.Flags().String("db", "", "Database to import a .env file into (dotenv format only)")
 }
 
 func importSecrets(cmd *cobra.Command, args []string) error {
@@ -43,6 +52,14 @@ func importSecrets(cmd *cobra.Command, args []string) error {
 
 	force, _ := cmd.Flags().GetBool("force")
 	remapArgs, _ := cmd.Flags().GetStringArray("remap")
+	format, _ := cmd.Flags().GetString("format")
+	database, _ := cmd.Flags().GetString("db")
+	if format != secrets.FormatJSON && format != secrets.FormatDotenv {
+		return fmt.Errorf("unsupported format %q (use json or dotenv)", format)
+	}
+	if database != "" && format != secrets.FormatDotenv {
+		return fmt.Errorf("--db only applies to --format dotenv; use --remap for JSON exports")
+	}
 
 	// Parse --remap old:new pairs
 	remap, err := parseRemap(remapArgs)
@@ -50,12 +67,26 @@ func importSecrets(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fileData, err := os.ReadFile(args[0])
+	inputFile := args[0]
+	if format == secrets.FormatDotenv {
+		path, target := secrets.ParseDotenvTarget(inputFile)
+		if target != "" {
+			if database != "" && database != target {
+				return fmt.Errorf("database given both as @%s and --db %s", target, database)
+			}
+			inputFile, database = path, target
+		}
+		if database == "" {
+			database = cfg.GetSecretsConfig().GetDefaultDatabase()
+		}
+	}
+
+	fileData, err := os.ReadFile(inputFile)
 	if err != nil {
 		return fmt.Errorf("failed to read input file: %w", err)
 	}
 
-	jsonData := fileData
+	plaintext := fileData
 	if bytes.HasPrefix(fileData, []byte(ageMagic)) {
 		passphrase, err := promptPassphrase("Enter import passphrase: ", false)
 		if err != nil {
@@ -73,11 +104,18 @@ func importSecrets(cmd *cobra.Command, args []string) error {
 		if _, err := buf.ReadFrom(r); err != nil {
 			return fmt.Errorf("failed to read decrypted data: %w", err)
 		}
-		jsonData = buf.Bytes()
+		plaintext = buf.Bytes()
 	}
 
 	var data secrets.ExportData
-	if err := json.Unmarshal(jsonData, &data); err != nil {
+	if format == secrets.FormatDotenv {
+		parsed, err := secrets.ParseDotenv(bytes.NewReader(plaintext))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", inputFile, err)
+		}
+		data = *secrets.NewExportData()
+		data.Databases[database] = parsed
+	} else if err := json.Unmarshal(plaintext, &data); err != nil {
 		return fmt.Errorf("failed to parse import file: %w", err)
 	}
 
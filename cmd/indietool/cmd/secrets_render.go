@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"indietool/cli/indietool/secrets"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var secretsRenderCmd = &cobra.Command{
+	Use:   "render <template>",
+	Short: "Fill secrets into a template, such as a .env file",
+	Long: `Render a template with secrets filled in and write it to stdout or --out.
+
+Placeholders use Go template syntax:
+  {{ secret "name[@db]" }}            the secret's value
+  {{ secret "name[@db]" | dotenv }}   the value quoted for a .env file
+
+Names without @db use --db, or the default database. Rendering fails, and
+nothing is written, if any secret is missing, or expired without
+--allow-expired. Use - to read the template from stdin.
+
+Example template.env.tmpl:
+  OPENAI_KEY={{ secret "openai-key@proj" }}
+  TLS_KEY={{ secret "tls-key@proj" | dotenv }}
+
+Examples:
+  indietool secrets render template.env.tmpl > .env
+  indietool secrets render config.yaml.tmpl --db proj --out config.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: renderSecrets,
+}
+
+func init() {
+	secretsRenderCmd.Flags().StringP("out", "o", "", "Output file (default: stdout)")
+	secretsRenderCmd.Flags().String("db", "", "Database for placeholders without @db (defaults to the default database)")
+	secretsRenderCmd.Flags().Bool("allow-expired", false, "Render secrets even if they have expired")
+}
+
+func renderSecrets(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("no configuration available")
+	}
+
+	outFile, _ := cmd.Flags().GetString("out")
+	database, _ := cmd.Flags().GetString("db")
+	allowExpired, _ := cmd.Flags().GetBool("allow-expired")
+
+	var text []byte
+	var err error
+	if args[0] == "-" {
+		text, err = io.ReadAll(os.Stdin)
+	} else {
+		text, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	secretsConfig := cfg.GetSecretsConfig()
+	if database == "" {
+		database = secretsConfig.GetDefaultDatabase()
+	}
+
+	manager, err := secrets.NewManager(secretsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create secrets manager: %w", err)
+	}
+
+	// Render fully before creating --out so a failure leaves no partial file
+	var rendered bytes.Buffer
+	err = manager.RenderTemplate(&rendered, filepath.Base(args[0]), string(text), database, allowExpired)
+	if errors.Is(err, secrets.ErrSecretExpired) {
+		return fmt.Errorf("%w (use --allow-expired to render it anyway)", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if outFile == "" {
+		_, err = os.Stdout.Write(rendered.Bytes())
+		return err
+	}
+	if err := os.WriteFile(outFile, rendered.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
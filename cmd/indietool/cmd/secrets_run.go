@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"indietool/cli/indietool/secrets"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var secretsRunCmd = &cobra.Command{
+	Use:   "run [--env VAR=secret[@db]]... -- <command> [args...]",
+	Short: "Run a command with secrets in its environment",
+	Long: `Decrypt secrets and run a command with them set as environment variables,
+so values never pass through your shell history, the process list or an
+exported variable in your shell.
+
+Secrets are mapped to variables with --env VAR=secret[@db], or listed one per
+line in a mapping file. The file defaults to ` + secrets.DefaultEnvFile + ` in the current
+directory when it exists:
+
+  # VAR=secret[@database]
+  OPENAI_KEY=openai-key@proj
+  STRIPE_KEY=stripe-key
+
+--env entries override file entries for the same variable. Mappings without
+@db use --db, or the default database.
+
+Signals are forwarded to the command and indietool exits with its exit code.
+Expired secrets are refused unless --allow-expired is given.
+
+Examples:
+  indietool secrets run --env OPENAI_KEY=openai-key@proj -- npm start
+  indietool secrets run --db proj -- ./deploy.sh
+  indietool secrets run --env-file .env.secrets -- make release`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWithSecrets,
+}
+
+func init() {
+	secretsRunCmd.Flags().SetInterspersed(false)
+	secretsRunCmd.Flags().StringArrayP("env", "e", nil, "Map a secret to a variable as VAR=secret[@db] (repeatable)")
+	secretsRunCmd.Flags().String("db", "", "Database for mappings without @db (defaults to the default database)")
+	secretsRunCmd.Flags().String("env-file", "", "Mapping file to read (default "+secrets.DefaultEnvFile+" if present)")
+	secretsRunCmd.Flags().Bool("allow-expired", false, "Inject secrets even if they have expired")
+}
+
+// exitCodeError carries a child process's exit code back to Execute
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func runWithSecrets(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("no configuration available")
+	}
+
+	envFlags, _ := cmd.Flags().GetStringArray("env")
+	database, _ := cmd.Flags().GetString("db")
+	envFile, _ := cmd.Flags().GetString("env-file")
+	allowExpired, _ := cmd.Flags().GetBool("allow-expired")
+
+	mappings, err := loadRunMappings(envFile, cmd.Flags().Changed("env-file"))
+	if err != nil {
+		return err
+	}
+	for _, flag := range envFlags {
+		mapping, err := secrets.ParseEnvMapping(flag)
+		if err != nil {
+			return err
+		}
+		mappings = secrets.MergeEnvMappings(mappings, mapping)
+	}
+	if len(mappings) == 0 {
+		return fmt.Errorf("no secrets to inject: use --env VAR=secret[@db] or create %s", secrets.DefaultEnvFile)
+	}
+
+	secretsConfig := cfg.GetSecretsConfig()
+	if database == "" {
+		database = secretsConfig.GetDefaultDatabase()
+	}
+
+	manager, err := secrets.NewManager(secretsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create secrets manager: %w", err)
+	}
+
+	env, err := manager.ResolveEnv(mappings, database, allowExpired)
+	if errors.Is(err, secrets.ErrSecretExpired) {
+		return fmt.Errorf("%w (use --allow-expired to inject them anyway)", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = secrets.MergeEnv(os.Environ(), env)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	// From here on the child reports its own errors
+	cmd.SilenceUsage = true
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", args[0], err)
+	}
+
+	go func() {
+		for sig := range signals {
+			_ = child.Process.Signal(sig)
+		}
+	}()
+
+	err = child.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		cmd.SilenceErrors = true
+		code := exitErr.ExitCode()
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			code = 128 + int(status.Signal())
+		}
+		return &exitCodeError{code: code}
+	}
+	return err
+}
+
+// loadRunMappings reads the mapping file. Without --env-file, a missing
+// default file is not an error.
+func loadRunMappings(path string, explicit bool) ([]secrets.EnvMapping, error) {
+	if path == "" {
+		path = secrets.DefaultEnvFile
+	}
+	mappings, err := secrets.LoadEnvMappings(expandTildePath(path))
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	return mappings, nil
+}
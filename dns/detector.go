@@ -32,6 +32,9 @@ var nameserverPatterns = map[string][]string{
 		".thelittlehost.net",
 		".thelittlehost.com",
 	},
+	"mock": {
+		".mock.test",
+	},
 }
 
 // DetectorResult contains the result of DNS provider detection
@@ -44,6 +47,12 @@ type DetectorResult struct {
 
 // DetectProvider attempts to detect the DNS hosting provider for a domain
 func DetectProvider(domain string) (*DetectorResult, error) {
+	ascii, err := ToASCII(domain)
+	if err != nil {
+		return &DetectorResult{Error: err.Error()}, err
+	}
+	domain = ascii
+
 	// Query nameservers for the domain
 	nameservers, err := net.LookupNS(domain)
 	if err != nil {
@@ -0,0 +1,265 @@
+// Package dnstest provides a conformance suite that any dns.Provider
+// implementation can be run against.
+//
+// Providers are exercised through the dns.Provider interface only, with
+// record names already normalised the way dns.Manager passes them ("@" for
+// the apex, the bare label for subdomains). Real services should be put
+// behind an httptest fake so the suite stays hermetic.
+package dnstest
+
+import (
+	"context"
+	"indietool/cli/dns"
+	"strings"
+	"testing"
+)
+
+// Factory returns a fresh provider serving an empty zone for the domain
+// passed to Run. It is called once per subtest so cases don't share state.
+type Factory func(t *testing.T) dns.Provider
+
+// Options tweaks the suite for provider quirks
+type Options struct {
+	// TTL used for created records. Defaults to 300, which every supported
+	// provider accepts.
+	TTL int
+
+	// MissingRecordID is an ID that is well-formed for the provider but does
+	// not exist in the zone. Defaults to "999999".
+	MissingRecordID string
+}
+
+// Run executes the conformance suite against providers built by newProvider.
+// domain must be the zone the factory's providers serve.
+func Run(t *testing.T, domain string, newProvider Factory, opts Options) {
+	t.Helper()
+
+	if opts.TTL == 0 {
+		opts.TTL = 300
+	}
+	if opts.MissingRecordID == "" {
+		opts.MissingRecordID = "999999"
+	}
+
+	s := &suite{domain: domain, opts: opts}
+	cases := []struct {
+		name string
+		fn   func(t *testing.T, p dns.Provider)
+	}{
+		{"CreateAndGet", s.testCreateAndGet},
+		{"UpdateByNameAndType", s.testUpdateByNameAndType},
+		{"UpdateByID", s.testUpdateByID},
+		{"UpdateMissingID", s.testUpdateMissingID},
+		{"TypesAreIndependent", s.testTypesAreIndependent},
+		{"RootRecord", s.testRootRecord},
+		{"MXPriority", s.testMXPriority},
+		{"Delete", s.testDelete},
+		{"DeleteMissing", s.testDeleteMissing},
+		{"GetMissing", s.testGetMissing},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newProvider(t))
+		})
+	}
+}
+
+type suite struct {
+	domain string
+	opts   Options
+}
+
+func (s *suite) record(name, recordType, content string) dns.Record {
+	return dns.Record{Name: name, Type: recordType, Content: content, TTL: s.opts.TTL}
+}
+
+func (s *suite) testCreateAndGet(t *testing.T, p dns.Provider) {
+	ctx := context.Background()
+
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.1"))
+
+	got := mustGet(t, p, s.domain, "www", "A")
+	if got.Content != "192.0.2.1" {
+		t.Errorf("GetRecord content = %q, want %q", got.Content, "192.0.2.1")
+	}
+	if got.Name != "www" {
+		t.Errorf("GetRecord name = %q, want %q", got.Name, "www")
+	}
+	if got.ID == "" {
+		t.Error("GetRecord returned a record without an ID")
+	}
+
+	records, err := p.ListRecords(ctx, s.domain)
+	if err != nil {
+		t.Fatalf("ListRecords: %v", err)
+	}
+	if n := len(find(records, "www", "A")); n != 1 {
+		t.Errorf("ListRecords returned %d www A records, want 1", n)
+	}
+}
+
+func (s *suite) testUpdateByNameAndType(t *testing.T, p dns.Provider) {
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.1"))
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.2"))
+
+	matches := find(mustList(t, p, s.domain), "www", "A")
+	if len(matches) != 1 {
+		t.Fatalf("got %d www A records after update, want 1 (update must not duplicate)", len(matches))
+	}
+	if matches[0].Content != "192.0.2.2" {
+		t.Errorf("updated content = %q, want %q", matches[0].Content, "192.0.2.2")
+	}
+}
+
+func (s *suite) testUpdateByID(t *testing.T, p dns.Provider) {
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.1"))
+	mustSet(t, p, s.domain, s.record("api", "A", "192.0.2.5"))
+
+	target := mustGet(t, p, s.domain, "api", "A")
+
+	update := s.record("api", "A", "192.0.2.6")
+	update.ID = target.ID
+	mustSet(t, p, s.domain, update)
+
+	records := mustList(t, p, s.domain)
+	api := find(records, "api", "A")
+	if len(api) != 1 || api[0].Content != "192.0.2.6" {
+		t.Errorf("api A records after update by ID = %+v, want one with content 192.0.2.6", api)
+	}
+	www := find(records, "www", "A")
+	if len(www) != 1 || www[0].Content != "192.0.2.1" {
+		t.Errorf("www A records after update by ID = %+v, want it untouched", www)
+	}
+}
+
+func (s *suite) testUpdateMissingID(t *testing.T, p dns.Provider) {
+	update := s.record("www", "A", "192.0.2.1")
+	update.ID = s.opts.MissingRecordID
+
+	if err := p.SetRecord(context.Background(), s.domain, update); err == nil {
+		t.Error("SetRecord with an unknown ID succeeded, want an error")
+	}
+}
+
+func (s *suite) testTypesAreIndependent(t *testing.T, p dns.Provider) {
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.1"))
+	mustSet(t, p, s.domain, s.record("www", "TXT", "hello"))
+
+	records := mustList(t, p, s.domain)
+	if n := len(find(records, "www", "A")); n != 1 {
+		t.Errorf("got %d www A records, want 1", n)
+	}
+	txt := find(records, "www", "TXT")
+	if len(txt) != 1 || strings.Trim(txt[0].Content, `"`) != "hello" {
+		t.Errorf("www TXT records = %+v, want one with content hello", txt)
+	}
+}
+
+func (s *suite) testRootRecord(t *testing.T, p dns.Provider) {
+	mustSet(t, p, s.domain, s.record("@", "A", "192.0.2.9"))
+
+	got := mustGet(t, p, s.domain, "@", "A")
+	if got.Name != "@" {
+		t.Errorf("root record name = %q, want %q", got.Name, "@")
+	}
+	if got.Content != "192.0.2.9" {
+		t.Errorf("root record content = %q, want %q", got.Content, "192.0.2.9")
+	}
+}
+
+func (s *suite) testMXPriority(t *testing.T, p dns.Provider) {
+	priority := 20
+	mx := s.record("@", "MX", "mail."+s.domain)
+	mx.Priority = &priority
+	mustSet(t, p, s.domain, mx)
+
+	got := mustGet(t, p, s.domain, "@", "MX")
+	if got.Priority == nil {
+		t.Errorf("MX priority missing, want %d", priority)
+	} else if *got.Priority != priority {
+		t.Errorf("MX priority = %d, want %d", *got.Priority, priority)
+	}
+	if strings.TrimSuffix(got.Content, ".") != "mail."+s.domain {
+		t.Errorf("MX content = %q, want %q", got.Content, "mail."+s.domain)
+	}
+}
+
+func (s *suite) testDelete(t *testing.T, p dns.Provider) {
+	ctx := context.Background()
+
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.1"))
+	mustSet(t, p, s.domain, s.record("api", "A", "192.0.2.5"))
+	target := mustGet(t, p, s.domain, "www", "A")
+
+	if err := p.DeleteRecord(ctx, s.domain, target.ID); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+
+	records := mustList(t, p, s.domain)
+	if n := len(find(records, "www", "A")); n != 0 {
+		t.Errorf("got %d www A records after delete, want 0", n)
+	}
+	if n := len(find(records, "api", "A")); n != 1 {
+		t.Errorf("got %d api A records after deleting www, want 1", n)
+	}
+	if _, err := p.GetRecord(ctx, s.domain, "www", "A"); err == nil {
+		t.Error("GetRecord found a deleted record")
+	}
+}
+
+func (s *suite) testDeleteMissing(t *testing.T, p dns.Provider) {
+	mustSet(t, p, s.domain, s.record("www", "A", "192.0.2.1"))
+
+	if err := p.DeleteRecord(context.Background(), s.domain, s.opts.MissingRecordID); err == nil {
+		t.Error("DeleteRecord with an unknown ID succeeded, want an error")
+	}
+	if n := len(find(mustList(t, p, s.domain), "www", "A")); n != 1 {
+		t.Errorf("got %d www A records after failed delete, want 1", n)
+	}
+}
+
+func (s *suite) testGetMissing(t *testing.T, p dns.Provider) {
+	if _, err := p.GetRecord(context.Background(), s.domain, "missing", "A"); err == nil {
+		t.Error("GetRecord for a missing record succeeded, want an error")
+	}
+}
+
+func mustSet(t *testing.T, p dns.Provider, domain string, record dns.Record) {
+	t.Helper()
+	if err := p.SetRecord(context.Background(), domain, record); err != nil {
+		t.Fatalf("SetRecord(%s %s %s): %v", record.Name, record.Type, record.Content, err)
+	}
+}
+
+func mustGet(t *testing.T, p dns.Provider, domain, name, recordType string) *dns.Record {
+	t.Helper()
+	record, err := p.GetRecord(context.Background(), domain, name, recordType)
+	if err != nil {
+		t.Fatalf("GetRecord(%s %s): %v", name, recordType, err)
+	}
+	if record == nil {
+		t.Fatalf("GetRecord(%s %s) returned nil without an error", name, recordType)
+	}
+	return record
+}
+
+func mustList(t *testing.T, p dns.Provider, domain string) []dns.Record {
+	t.Helper()
+	records, err := p.ListRecords(context.Background(), domain)
+	if err != nil {
+		t.Fatalf("ListRecords: %v", err)
+	}
+	return records
+}
+
+// find returns the records matching name and type
+func find(records []dns.Record, name, recordType string) []dns.Record {
+	var matches []dns.Record
+	for _, record := range records {
+		if strings.EqualFold(record.Name, name) && strings.EqualFold(record.Type, recordType) {
+			matches = append(matches, record)
+		}
+	}
+	return matches
+}
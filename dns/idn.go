@@ -0,0 +1,252 @@
+package dns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// ToASCII converts a domain name to its A-label (punycode) form following
+// UTS #46, e.g. "Bücher.de" to "xn--bcher-kva.de". ASCII names are only
+// lowercased and stripped of a trailing dot, so names that aren't valid
+// hostnames (like _dmarc records) pass through unchanged.
+func ToASCII(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if isASCII(domain) {
+		return strings.ToLower(domain), nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized domain name %q: %w", domain, err)
+	}
+	return ascii, nil
+}
+
+// ToUnicode converts a domain name to its U-label form for display. Names
+// that can't be converted are returned as given.
+func ToUnicode(domain string) string {
+	if !IsIDN(domain) {
+		return domain
+	}
+	unicodeName, err := idna.Display.ToUnicode(domain)
+	if err != nil {
+		return domain
+	}
+	return unicodeName
+}
+
+// IsIDN reports whether domain is an internationalized name, in either
+// U-label or A-label form
+func IsIDN(domain string) bool {
+	if !isASCII(domain) {
+		return true
+	}
+	for _, label := range strings.Split(strings.ToLower(domain), ".") {
+		if strings.HasPrefix(label, "xn--") {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayName shows an internationalized domain as both labels, e.g.
+// "bücher.de (xn--bcher-kva.de)". Other names are returned unchanged.
+func DisplayName(domain string) string {
+	if !IsIDN(domain) {
+		return domain
+	}
+	ascii, err := ToASCII(domain)
+	if err != nil {
+		return domain
+	}
+	unicodeName := ToUnicode(ascii)
+	if unicodeName == ascii {
+		return ascii
+	}
+	return fmt.Sprintf("%s (%s)", unicodeName, ascii)
+}
+
+// HomographWarnings describes why an internationalized domain may be mistaken
+// for another: labels mixing scripts in ways UTS #39 doesn't allow, and
+// characters that look like Latin letters in labels that mix them with Latin
+// or read entirely as ASCII. It returns nothing for ASCII names.
+func HomographWarnings(domain string) []string {
+	unicodeName := ToUnicode(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if isASCII(unicodeName) {
+		return nil
+	}
+
+	var warnings []string
+	for _, label := range strings.Split(unicodeName, ".") {
+		if isASCII(label) {
+			continue
+		}
+
+		scripts := labelScripts(label)
+		if !allowedScriptMix(scripts) {
+			warnings = append(warnings, fmt.Sprintf("%q mixes %s scripts", label, strings.Join(scripts, " and ")))
+		}
+
+		// Look-alike letters matter next to Latin ones, or when the whole
+		// label reads as ASCII; native Cyrillic or Greek names are fine
+		skeleton := Skeleton(label)
+		if !isASCII(skeleton) && !(len(scripts) > 1 && slices.Contains(scripts, "Latin")) {
+			continue
+		}
+		var confusable []string
+		for _, r := range label {
+			if latin, ok := confusables[r]; ok {
+				confusable = append(confusable, fmt.Sprintf("%c (U+%04X) looks like %c", r, r, latin))
+			}
+		}
+		if len(confusable) > 0 {
+			message := fmt.Sprintf("%q contains confusable characters: %s", label, strings.Join(confusable, ", "))
+			if isASCII(skeleton) {
+				message += fmt.Sprintf("; it may be read as %q", skeleton)
+			}
+			warnings = append(warnings, message)
+		}
+	}
+	return warnings
+}
+
+// Skeleton replaces the characters that look like Latin letters with those
+// letters, so "аpple" (Cyrillic а) becomes "apple"
+func Skeleton(label string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if latin, ok := confusables[r]; ok {
+			b.WriteRune(latin)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Homoglyphs returns the characters from other scripts that are mistaken
+// for the ASCII character r, e.g. Cyrillic о and Greek ο for "o"
+func Homoglyphs(r rune) []rune {
+	homoglyphsOnce.Do(func() {
+		homoglyphs = make(map[rune][]rune)
+		for lookalike, latin := range confusables {
+			homoglyphs[latin] = append(homoglyphs[latin], lookalike)
+		}
+		for _, lookalikes := range homoglyphs {
+			slices.Sort(lookalikes)
+		}
+	})
+	return homoglyphs[unicode.ToLower(r)]
+}
+
+var (
+	homoglyphs     map[rune][]rune
+	homoglyphsOnce sync.Once
+)
+
+// scriptTables are the scripts told apart when checking for mixed labels
+var scriptTables = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+	{"Armenian", unicode.Armenian},
+	{"Georgian", unicode.Georgian},
+	{"Hebrew", unicode.Hebrew},
+	{"Arabic", unicode.Arabic},
+	{"Devanagari", unicode.Devanagari},
+	{"Thai", unicode.Thai},
+	{"Han", unicode.Han},
+	{"Hiragana", unicode.Hiragana},
+	{"Katakana", unicode.Katakana},
+	{"Hangul", unicode.Hangul},
+	{"Bopomofo", unicode.Bopomofo},
+}
+
+// labelScripts returns the scripts used in label, ignoring characters common
+// to all scripts such as digits and hyphens
+func labelScripts(label string) []string {
+	var scripts []string
+	seen := make(map[string]bool)
+	for _, r := range label {
+		if unicode.Is(unicode.Common, r) || unicode.Is(unicode.Inherited, r) {
+			continue
+		}
+		name := "Other"
+		for _, script := range scriptTables {
+			if unicode.Is(script.table, r) {
+				name = script.name
+				break
+			}
+		}
+		if !seen[name] {
+			seen[name] = true
+			scripts = append(scripts, name)
+		}
+	}
+	return scripts
+}
+
+// allowedScriptMix implements the UTS #39 "highly restrictive" level: one
+// script, or Latin combined with the scripts of Japanese, Chinese or Korean
+// writing
+func allowedScriptMix(scripts []string) bool {
+	if len(scripts) <= 1 {
+		return true
+	}
+	for _, allowed := range [][]string{
+		{"Latin", "Han", "Hiragana", "Katakana"},
+		{"Latin", "Han", "Bopomofo"},
+		{"Latin", "Han", "Hangul"},
+	} {
+		if subsetOf(scripts, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func subsetOf(items, set []string) bool {
+	for _, item := range items {
+		if !slices.Contains(set, item) {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// confusables maps characters from other scripts (and Latin look-alikes) to
+// the ASCII letter they are most often mistaken for. It is a practical subset
+// of the Unicode confusables data covering the letters used in domain
+// spoofing.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'ғ': 'f', 'һ': 'h',
+	'і': 'i', 'ј': 'j', 'к': 'k', 'ӏ': 'l', 'м': 'm', 'п': 'n', 'о': 'o',
+	'р': 'p', 'ԛ': 'q', 'г': 'r', 'ѕ': 's', 'т': 't', 'ц': 'u', 'ѵ': 'v',
+	'ԝ': 'w', 'х': 'x', 'у': 'y', 'з': '3', 'ь': 'b', 'ё': 'e', 'ї': 'i',
+	// Greek
+	'α': 'a', 'β': 'b', 'ϲ': 'c', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k',
+	'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'γ': 'y',
+	'ω': 'w',
+	// Armenian
+	'ա': 'w', 'ց': 'g', 'հ': 'h', 'ո': 'n', 'ս': 'u', 'օ': 'o', 'զ': 'q',
+	// Latin look-alikes
+	'ı': 'i', 'ɡ': 'g', 'ɑ': 'a', 'ǀ': 'l', 'ʟ': 'l', 'ɩ': 'i',
+}
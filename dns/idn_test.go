@@ -0,0 +1,106 @@
+package dns
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Example.COM.", "example.com"},
+		{"bücher.de", "xn--bcher-kva.de"},
+		{"BÜCHER.de", "xn--bcher-kva.de"},
+		{"пример.рф", "xn--e1afmkfd.xn--p1ai"},
+		{"xn--bcher-kva.de", "xn--bcher-kva.de"},
+		{"_dmarc", "_dmarc"},
+	}
+
+	for _, tt := range tests {
+		got, err := ToASCII(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ToASCII(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	if _, err := ToASCII("bü_cher.de"); err == nil {
+		t.Error("expected an error for an underscore in an internationalized label")
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	if got := DisplayName("xn--bcher-kva.de"); got != "bücher.de (xn--bcher-kva.de)" {
+		t.Errorf("DisplayName(A-label) = %q", got)
+	}
+	if got := DisplayName("bücher.de"); got != "bücher.de (xn--bcher-kva.de)" {
+		t.Errorf("DisplayName(U-label) = %q", got)
+	}
+	if got := DisplayName("example.com"); got != "example.com" {
+		t.Errorf("DisplayName(ASCII) = %q", got)
+	}
+}
+
+func TestHomographWarnings(t *testing.T) {
+	if warnings := HomographWarnings("bücher.de"); len(warnings) != 0 {
+		t.Errorf("bücher.de warnings = %v, want none", warnings)
+	}
+	if warnings := HomographWarnings("пример.рф"); len(warnings) != 0 {
+		t.Errorf("пример.рф warnings = %v, want none", warnings)
+	}
+
+	// All Cyrillic, but reads as "apple"
+	if warnings := HomographWarnings("аррӏе.com"); len(warnings) != 1 || !strings.Contains(warnings[0], `read as "apple"`) {
+		t.Errorf("аррӏе.com warnings = %v", warnings)
+	}
+	if warnings := HomographWarnings("日本語テスト.jp"); len(warnings) != 0 {
+		t.Errorf("Japanese warnings = %v, want none", warnings)
+	}
+
+	// Latin "p", "l", "e" with a Cyrillic "а"
+	warnings := HomographWarnings("аpple.com")
+	if len(warnings) != 2 {
+		t.Fatalf("аpple.com warnings = %v, want mixed scripts and confusables", warnings)
+	}
+	if !strings.Contains(warnings[0], "Latin and Cyrillic") && !strings.Contains(warnings[0], "Cyrillic and Latin") {
+		t.Errorf("mixed script warning = %q", warnings[0])
+	}
+	if !strings.Contains(warnings[1], `read as "apple"`) {
+		t.Errorf("confusable warning = %q", warnings[1])
+	}
+
+	// A-label input is checked in its Unicode form
+	ascii, _ := ToASCII("аpple.com")
+	if len(HomographWarnings(ascii)) != 2 {
+		t.Errorf("A-label %s was not checked", ascii)
+	}
+}
+
+func TestNormalizeNameIDN(t *testing.T) {
+	if got := NormalizeName("www.bücher.de", "xn--bcher-kva.de"); got != "www" {
+		t.Errorf("NormalizeName = %q, want www", got)
+	}
+	if got := NormalizeName("straße", "example.com"); got != "xn--strae-oqa" {
+		t.Errorf("NormalizeName = %q, want xn--strae-oqa", got)
+	}
+	if got := NormalizeName("_DMARC", "example.com"); got != "_DMARC" {
+		t.Errorf("NormalizeName changed an ASCII name to %q", got)
+	}
+}
+
+func TestHomoglyphs(t *testing.T) {
+	lookalikes := Homoglyphs('O')
+	if !slices.Contains(lookalikes, 'о') || !slices.Contains(lookalikes, 'ο') {
+		t.Errorf("Homoglyphs('O') = %q, want Cyrillic and Greek o", lookalikes)
+	}
+	for _, r := range lookalikes {
+		if Skeleton(string(r)) != "o" {
+			t.Errorf("%q does not read as o", r)
+		}
+	}
+	if len(Homoglyphs('-')) != 0 {
+		t.Error("hyphen has no homoglyphs")
+	}
+}
@@ -19,6 +19,11 @@ func NewManager(providers []Provider) *Manager {
 
 // ListRecords lists DNS records for a domain, auto-detecting or using specified provider
 func (m *Manager) ListRecords(ctx context.Context, domain, providerName string) ([]Record, *DetectorResult, error) {
+	domain, err := ToASCII(domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var provider Provider
 	var detectionResult *DetectorResult
 
@@ -53,6 +58,11 @@ func (m *Manager) ListRecords(ctx context.Context, domain, providerName string)
 
 // SetRecord sets a DNS record, auto-detecting or using specified provider
 func (m *Manager) SetRecord(ctx context.Context, domain, providerName string, record Record) (*DetectorResult, error) {
+	domain, err := ToASCII(domain)
+	if err != nil {
+		return nil, err
+	}
+
 	var provider Provider
 	var detectionResult *DetectorResult
 
@@ -94,6 +104,11 @@ func (m *Manager) SetRecord(ctx context.Context, domain, providerName string, re
 
 // DeleteRecord deletes a DNS record by ID
 func (m *Manager) DeleteRecord(ctx context.Context, domain, providerName, recordID string) error {
+	domain, err := ToASCII(domain)
+	if err != nil {
+		return err
+	}
+
 	// If no provider specified, attempt auto-detection
 	if providerName == "" {
 		result, err := DetectProvider(domain)
@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// nameserverPresets lists the fixed nameservers of providers that use the
+// same set for every domain. Providers that assign nameservers per zone
+// (Cloudflare, GoDaddy) implement NameserverAssigner instead.
+var nameserverPresets = map[string][]string{
+	"porkbun": {
+		"curitiba.ns.porkbun.com",
+		"fortaleza.ns.porkbun.com",
+		"maceio.ns.porkbun.com",
+		"salvador.ns.porkbun.com",
+	},
+	"namecheap": {
+		"dns1.registrar-servers.com",
+		"dns2.registrar-servers.com",
+	},
+}
+
+// NameserverAssigner is implemented by DNS providers that can tell which
+// nameservers a domain must delegate to in order to be served by them
+type NameserverAssigner interface {
+	AssignedNameservers(ctx context.Context, domain string) ([]string, error)
+}
+
+// PresetNameservers returns the fixed nameservers of provider, if it has any
+func PresetNameservers(provider string) ([]string, bool) {
+	nameservers, ok := nameserverPresets[strings.ToLower(provider)]
+	return slices.Clone(nameservers), ok
+}
+
+// GetPresetProviders returns the providers with fixed nameserver presets
+func GetPresetProviders() []string {
+	providers := make([]string, 0, len(nameserverPresets))
+	for provider := range nameserverPresets {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// NormalizeNameservers lowercases, strips trailing dots, removes duplicates
+// and sorts nameservers so sets can be compared
+func NormalizeNameservers(nameservers []string) []string {
+	normalized := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		ns = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(ns), "."))
+		if ns != "" && !slices.Contains(normalized, ns) {
+			normalized = append(normalized, ns)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// SameNameservers reports whether a and b contain the same nameservers,
+// ignoring order, case and trailing dots
+func SameNameservers(a, b []string) bool {
+	return slices.Equal(NormalizeNameservers(a), NormalizeNameservers(b))
+}
+
+// DetectNameserverProvider returns the provider the nameservers belong to, or
+// an empty string when they are not recognized
+func DetectNameserverProvider(nameservers []string) string {
+	for _, ns := range NormalizeNameservers(nameservers) {
+		if provider := matchNameserverPattern(ns); provider != "" {
+			return provider
+		}
+	}
+	return ""
+}
+
+// LookupNameservers asks public DNS which nameservers serve domain. An empty
+// resolver uses the system resolver; otherwise it is a host[:port] address
+// such as 1.1.1.1.
+func LookupNameservers(ctx context.Context, domain, resolver string) ([]string, error) {
+	r := net.DefaultResolver
+	if resolver != "" {
+		r = resolverAt(resolver)
+	}
+
+	records, err := r.LookupNS(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup nameservers for %s: %w", domain, err)
+	}
+
+	nameservers := make([]string, len(records))
+	for i, record := range records {
+		nameservers[i] = record.Host
+	}
+	return NormalizeNameservers(nameservers), nil
+}
+
+// QueryNameserver asks nameserver itself for the NS records of domain. A
+// nameserver that is delegated to but doesn't answer for the zone is lame.
+func QueryNameserver(ctx context.Context, nameserver, domain string) ([]string, error) {
+	return LookupNameservers(ctx, domain, strings.TrimSuffix(nameserver, "."))
+}
+
+// resolverAt returns a resolver that sends every query to address, a
+// host[:port] defaulting to port 53
+func resolverAt(address string) *net.Resolver {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 5 * time.Second}
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package dns
+
+import "testing"
+
+func TestSameNameservers(t *testing.T) {
+	a := []string{"Fred.NS.Cloudflare.com.", "pam.ns.cloudflare.com"}
+	b := []string{"pam.ns.cloudflare.com", "fred.ns.cloudflare.com", "fred.ns.cloudflare.com"}
+	if !SameNameservers(a, b) {
+		t.Errorf("SameNameservers(%v, %v) = false, want true", a, b)
+	}
+	if SameNameservers(a, b[:1]) {
+		t.Error("SameNameservers with a missing nameserver = true, want false")
+	}
+}
+
+func TestDetectNameserverProvider(t *testing.T) {
+	cases := map[string][]string{
+		"cloudflare": {"fred.ns.cloudflare.com."},
+		"porkbun":    {"maceio.ns.porkbun.com"},
+		"namecheap":  {"dns1.registrar-servers.com"},
+		"":           {"ns1.example.net"},
+	}
+	for want, nameservers := range cases {
+		if got := DetectNameserverProvider(nameservers); got != want {
+			t.Errorf("DetectNameserverProvider(%v) = %q, want %q", nameservers, got, want)
+		}
+	}
+}
+
+func TestPresetNameservers(t *testing.T) {
+	nameservers, ok := PresetNameservers("Porkbun")
+	if !ok || len(nameservers) != 4 {
+		t.Fatalf("PresetNameservers(Porkbun) = %v, %v", nameservers, ok)
+	}
+	if DetectNameserverProvider(nameservers) != "porkbun" {
+		t.Error("porkbun preset not detected as porkbun")
+	}
+
+	// Callers must not be able to modify the preset table
+	nameservers[0] = "changed.example"
+	if again, _ := PresetNameservers("porkbun"); again[0] == "changed.example" {
+		t.Error("PresetNameservers returned the shared slice")
+	}
+
+	if _, ok := PresetNameservers("cloudflare"); ok {
+		t.Error("cloudflare assigns nameservers per zone and should have no fixed preset")
+	}
+}
@@ -12,7 +12,8 @@ type Provider interface {
 	// ListRecords retrieves all DNS records for a domain
 	ListRecords(ctx context.Context, domain string) ([]Record, error)
 
-	// SetRecord creates or updates a DNS record
+	// SetRecord creates or updates a DNS record. When record.ID is set, the
+	// record with that ID is updated instead of the first name/type match.
 	SetRecord(ctx context.Context, domain string, record Record) error
 
 	// DeleteRecord removes a DNS record by ID
@@ -37,3 +38,10 @@ type CapableProvider interface {
 	Provider
 	Capabilities() ProviderCapabilities
 }
+
+// ZoneLister is implemented by providers where zones are created explicitly,
+// as opposed to every registered domain having one
+type ZoneLister interface {
+	// ListZones returns the names of all zones in the account
+	ListZones(ctx context.Context) ([]string, error)
+}
@@ -39,6 +39,18 @@ func NormalizeName(name, domain string) string {
 		return "@"
 	}
 
+	// Providers store internationalized names as A-labels
+	if !isASCII(name) {
+		if ascii, err := ToASCII(name); err == nil {
+			name = ascii
+		}
+	}
+	if !isASCII(domain) {
+		if ascii, err := ToASCII(domain); err == nil {
+			domain = ascii
+		}
+	}
+
 	// Remove trailing domain if present (e.g., "www.example.com" -> "www")
 	if strings.HasSuffix(name, "."+domain) {
 		name = strings.TrimSuffix(name, "."+domain)
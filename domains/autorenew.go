@@ -0,0 +1,95 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AutoRenewStatus is the outcome of changing a domain's auto-renewal setting
+type AutoRenewStatus string
+
+const (
+	AutoRenewUpdated     AutoRenewStatus = "updated"     // Registrar accepted the change
+	AutoRenewUnsupported AutoRenewStatus = "unsupported" // Registrar API cannot change it
+	AutoRenewFailed      AutoRenewStatus = "failed"      // Registrar returned an error
+)
+
+// AutoRenewResult reports what happened to one domain
+type AutoRenewResult struct {
+	Domain      string          `json:"domain"`
+	Provider    string          `json:"provider"`
+	AutoRenewal bool            `json:"auto_renewal"` // Requested setting
+	Status      AutoRenewStatus `json:"status"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// FindDomain returns the managed domain with the given name from the local
+// inventory
+func (d *Manager) FindDomain(name string) (ManagedDomain, error) {
+	result, err := d.ListManagedDomains(ListOptions{})
+	if err != nil {
+		return ManagedDomain{}, err
+	}
+
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	for _, domain := range result.Domains {
+		if strings.EqualFold(domain.Name, name) {
+			return domain, nil
+		}
+	}
+	return ManagedDomain{}, fmt.Errorf("domain %s is not managed by any configured provider", name)
+}
+
+// SetAutoRenewal turns auto-renewal on or off for every domain in
+// domainList through its registrar. The registrar is asked even when the
+// inventory already shows the requested state, since the setting may have
+// been changed in the registrar's dashboard since the last sync. Successful
+// changes are written back to the inventory.
+func (d *Manager) SetAutoRenewal(ctx context.Context, domainList []ManagedDomain, enabled bool) ([]AutoRenewResult, error) {
+	results := make([]AutoRenewResult, 0, len(domainList))
+	updated := make(map[string]bool)
+
+	for _, domain := range domainList {
+		result := AutoRenewResult{Domain: domain.Name, Provider: domain.Provider, AutoRenewal: enabled}
+
+		registrar, ok := d.Registrar(domain.Provider)
+		switch {
+		case !ok:
+			result.Status = AutoRenewFailed
+			result.Error = fmt.Sprintf("provider %s is not configured", domain.Provider)
+		default:
+			err := registrar.UpdateAutoRenewal(ctx, domain.Name, enabled)
+			switch {
+			case errors.Is(err, ErrNotSupported):
+				result.Status = AutoRenewUnsupported
+				result.Error = err.Error()
+			case err != nil:
+				result.Status = AutoRenewFailed
+				result.Error = err.Error()
+			default:
+				result.Status = AutoRenewUpdated
+				updated[domain.Name] = true
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if len(updated) == 0 {
+		return results, nil
+	}
+
+	inventory, err := d.loadInventory()
+	if err != nil {
+		return results, err
+	}
+	for i := range inventory.Domains {
+		if updated[inventory.Domains[i].Name] {
+			inventory.Domains[i].AutoRenewal = enabled
+			inventory.Domains[i].SetStatus()
+		}
+	}
+	return results, d.saveInventory(inventory)
+}
@@ -0,0 +1,158 @@
+package domains
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openrdap/rdap/bootstrap/cache"
+)
+
+// Cache lifetimes. Available names are rechecked sooner because they can be
+// registered at any moment; taken ones rarely change within a day.
+const (
+	DefaultAvailableTTL = time.Hour
+	DefaultTakenTTL     = 24 * time.Hour
+	DefaultBootstrapTTL = 24 * time.Hour
+
+	// ResultCacheFile and BootstrapCacheDir live in the cache directory
+	ResultCacheFile   = "lookups.json"
+	BootstrapCacheDir = "rdap-bootstrap"
+)
+
+// CachedResult is a lookup result and when it was checked
+type CachedResult struct {
+	Result    DomainSearchResult `json:"result"`
+	CheckedAt time.Time          `json:"checked_at"`
+}
+
+// ResultCache keeps domain lookup results on disk, keyed by A-label domain,
+// so search, explore and watch share them. It is safe for concurrent use.
+type ResultCache struct {
+	AvailableTTL time.Duration
+	TakenTTL     time.Duration
+
+	path    string
+	mu      sync.Mutex
+	entries map[string]CachedResult
+	dirty   bool
+}
+
+// LoadResultCache reads the result cache in dir. A missing cache is empty.
+func LoadResultCache(dir string) (*ResultCache, error) {
+	c := &ResultCache{
+		AvailableTTL: DefaultAvailableTTL,
+		TakenTTL:     DefaultTakenTTL,
+		path:         filepath.Join(dir, ResultCacheFile),
+		entries:      make(map[string]CachedResult),
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("failed to read lookup cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse lookup cache %s: %w", c.path, err)
+	}
+	return c, nil
+}
+
+// Get returns the cached result for domain if it is within its TTL and, when
+// maxAge is positive, no older than maxAge
+func (c *ResultCache) Get(domain string, maxAge time.Duration, now time.Time) (DomainSearchResult, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[cacheKey(domain)]
+	c.mu.Unlock()
+	if !ok {
+		return DomainSearchResult{}, false
+	}
+
+	ttl := c.ttl(entry.Result)
+	if maxAge > 0 && maxAge < ttl {
+		ttl = maxAge
+	}
+	if now.Sub(entry.CheckedAt) > ttl {
+		return DomainSearchResult{}, false
+	}
+	result := entry.Result
+	result.Cached = true
+	return result, true
+}
+
+// Put records a lookup result. Failed lookups aren't cached.
+func (c *ResultCache) Put(result DomainSearchResult, now time.Time) {
+	if result.Error != "" {
+		return
+	}
+	result.Cached = false
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[cacheKey(result.Domain)] = CachedResult{Result: result, CheckedAt: now}
+	c.dirty = true
+}
+
+// Save writes the cache if it changed, dropping expired results
+func (c *ResultCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	now := time.Now()
+	for domain, entry := range c.entries {
+		if now.Sub(entry.CheckedAt) > c.ttl(entry.Result) {
+			delete(c.entries, domain)
+		}
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lookup cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Several commands can share the cache, so replace it in one step
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write lookup cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write lookup cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+func (c *ResultCache) ttl(result DomainSearchResult) time.Duration {
+	if result.Available {
+		return c.AvailableTTL
+	}
+	return c.TakenTTL
+}
+
+func cacheKey(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
+
+// newBootstrapCache keeps the IANA RDAP bootstrap files in dir
+func newBootstrapCache(dir string) (cache.RegistryCache, error) {
+	// DiskCache only creates the last directory itself
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	diskCache := cache.NewDiskCache()
+	diskCache.Dir = dir
+	diskCache.SetTimeout(DefaultBootstrapTTL)
+	return diskCache, nil
+}
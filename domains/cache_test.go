@@ -0,0 +1,84 @@
+package domains
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResultCacheTTLs(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := LoadResultCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	cache.Put(DomainSearchResult{Domain: "free.com", Available: true, Status: "available"}, now.Add(-2*time.Hour))
+	cache.Put(DomainSearchResult{Domain: "Taken.com", Status: "registered"}, now.Add(-2*time.Hour))
+	cache.Put(DomainSearchResult{Domain: "broken.com", Error: "timeout"}, now)
+
+	if _, ok := cache.Get("free.com", 0, now); ok {
+		t.Error("available result older than its TTL was used")
+	}
+	result, ok := cache.Get("taken.com", 0, now)
+	if !ok || !result.Cached || result.Status != "registered" {
+		t.Errorf("taken result = %+v, %v", result, ok)
+	}
+	if _, ok := cache.Get("taken.com", time.Hour, now); ok {
+		t.Error("result older than --max-age was used")
+	}
+	if _, ok := cache.Get("broken.com", 0, now); ok {
+		t.Error("failed lookup was cached")
+	}
+
+	// Expired results are dropped on save
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadResultCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.entries) != 1 {
+		t.Errorf("reloaded %d entries, want 1", len(reloaded.entries))
+	}
+	if _, ok := reloaded.Get("taken.com", 0, now); !ok {
+		t.Error("taken result was not saved")
+	}
+}
+
+func TestLookupEngineUsesCache(t *testing.T) {
+	cache, err := LoadResultCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Put(DomainSearchResult{Domain: "kopi.com", Status: "registered"}, time.Now())
+
+	var calls atomic.Int32
+	e := stubEngine(LookupOptions{Cache: cache}, func(ctx context.Context, domain string) (DomainSearchResult, error) {
+		calls.Add(1)
+		return availableResult(domain), nil
+	})
+
+	results := e.Search(context.Background(), []string{"kopi.com", "kopi.io"})
+	if !results[0].Cached || results[1].Cached || calls.Load() != 1 {
+		t.Errorf("results = %+v after %d lookups, want kopi.com from the cache", results, calls.Load())
+	}
+	if _, ok := cache.Get("kopi.io", 0, time.Now()); !ok {
+		t.Error("new result was not cached")
+	}
+
+	// --max-age skips older results
+	e.options.MaxAge = time.Nanosecond
+	e.queryRDAP = func(ctx context.Context, server *url.URL, domain string) (DomainSearchResult, error) {
+		calls.Add(1)
+		return availableResult(domain), nil
+	}
+	time.Sleep(time.Millisecond)
+	if result := e.Lookup(context.Background(), "kopi.com"); result.Cached || calls.Load() != 2 {
+		t.Errorf("result = %+v, want a fresh lookup", result)
+	}
+}
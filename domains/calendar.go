@@ -0,0 +1,156 @@
+package domains
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// calendarLineLimit is the maximum line length in octets (RFC 5545 3.1)
+const calendarLineLimit = 75
+
+// WriteCalendar writes an iCalendar feed with one all-day event per domain on
+// its expiry date. Each event carries a reminder for every warning threshold
+// so calendar apps alert at the same offsets as `domains notify`. now is used
+// as the DTSTAMP of every event.
+func WriteCalendar(w io.Writer, domainList []ManagedDomain, warningDays []int, now time.Time) error {
+	if len(warningDays) == 0 {
+		warningDays = DefaultExpiryWarningDays
+	}
+	alarms := append([]int(nil), warningDays...)
+	sort.Sort(sort.Reverse(sort.IntSlice(alarms)))
+
+	sorted := make([]ManagedDomain, 0, len(domainList))
+	for _, domain := range domainList {
+		if !domain.ExpiryDate.IsZero() {
+			sorted = append(sorted, domain)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].ExpiryDate.Equal(sorted[j].ExpiryDate) {
+			return sorted[i].ExpiryDate.Before(sorted[j].ExpiryDate)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	cw := &calendarWriter{w: bufio.NewWriter(w)}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//indietool//Domain renewals//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("X-WR-CALNAME:Domain renewals")
+	cw.line("REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, domain := range sorted {
+		expiry := domain.ExpiryDate.UTC()
+
+		summary := fmt.Sprintf("Renew %s", domain.Name)
+		if domain.AutoRenewal {
+			summary = fmt.Sprintf("%s auto-renews", domain.Name)
+		}
+
+		cw.line("BEGIN:VEVENT")
+		cw.line("UID:" + domain.Name + "-" + expiry.Format("20060102") + "@indietool")
+		cw.line("DTSTAMP:" + stamp)
+		cw.line("DTSTART;VALUE=DATE:" + expiry.Format("20060102"))
+		cw.line("DTEND;VALUE=DATE:" + expiry.AddDate(0, 0, 1).Format("20060102"))
+		cw.line("SUMMARY:" + escapeCalendarText(summary))
+		cw.line("DESCRIPTION:" + escapeCalendarText(calendarDescription(domain)))
+		cw.line("CATEGORIES:Domains")
+		cw.line("TRANSP:TRANSPARENT")
+
+		for _, days := range alarms {
+			if days < 0 {
+				continue
+			}
+			trigger := fmt.Sprintf("-P%dD", days)
+			if days == 0 {
+				trigger = "PT0S"
+			}
+			cw.line("BEGIN:VALARM")
+			cw.line("ACTION:DISPLAY")
+			cw.line("DESCRIPTION:" + escapeCalendarText(alarmDescription(domain.Name, days)))
+			cw.line("TRIGGER:" + trigger)
+			cw.line("END:VALARM")
+		}
+
+		cw.line("END:VEVENT")
+	}
+
+	cw.line("END:VCALENDAR")
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+func calendarDescription(domain ManagedDomain) string {
+	autoRenew := "off"
+	if domain.AutoRenewal {
+		autoRenew = "on"
+	}
+
+	cost := "unknown"
+	if domain.Cost != nil && domain.Cost.RenewalPrice > 0 {
+		cost = fmt.Sprintf("%.2f %s", domain.Cost.RenewalPrice, domain.Cost.Currency)
+	}
+
+	return fmt.Sprintf("Provider: %s\nAuto-renew: %s\nRenewal cost: %s\nExpires: %s",
+		domain.Provider, autoRenew, cost, domain.ExpiryDate.UTC().Format("2006-01-02"))
+}
+
+func alarmDescription(name string, days int) string {
+	switch days {
+	case 0:
+		return fmt.Sprintf("%s expires today", name)
+	case 1:
+		return fmt.Sprintf("%s expires tomorrow", name)
+	default:
+		return fmt.Sprintf("%s expires in %d days", name, days)
+	}
+}
+
+// escapeCalendarText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// calendarWriter writes CRLF-terminated content lines, folding those longer
+// than 75 octets without splitting UTF-8 sequences
+type calendarWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (c *calendarWriter) line(s string) {
+	if c.err != nil {
+		return
+	}
+
+	limit := calendarLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if _, c.err = c.w.WriteString(s[:cut] + "\r\n "); c.err != nil {
+			return
+		}
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = calendarLineLimit - 1
+	}
+	_, c.err = c.w.WriteString(s + "\r\n")
+}
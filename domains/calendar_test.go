@@ -0,0 +1,75 @@
+package domains
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domainList := []ManagedDomain{
+		{
+			Name:        "later.dev",
+			Provider:    "porkbun",
+			ExpiryDate:  time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+			AutoRenewal: true,
+			Cost:        &DomainCost{Currency: "USD", RenewalPrice: 10.37},
+		},
+		{
+			Name:       "soon.com",
+			Provider:   "namecheap",
+			ExpiryDate: time.Date(2026, 2, 14, 8, 30, 0, 0, time.UTC),
+		},
+		{Name: "unknown.org", Provider: "mock"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCalendar(&buf, domainList, []int{30, 7}, now); err != nil {
+		t.Fatalf("WriteCalendar: %v", err)
+	}
+	ics := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:soon.com-20260214@indietool\r\n",
+		"DTSTAMP:20260101T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20260214\r\n",
+		"DTEND;VALUE=DATE:20260215\r\n",
+		"SUMMARY:Renew soon.com\r\n",
+		"SUMMARY:later.dev auto-renews\r\n",
+		`Renewal cost: 10.37 USD`,
+		`Provider: namecheap\nAuto-renew: off\nRenewal cost: unknown`,
+		"TRIGGER:-P30D\r\n",
+		"TRIGGER:-P7D\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("calendar missing %q:\n%s", want, ics)
+		}
+	}
+
+	if strings.Contains(ics, "unknown.org") {
+		t.Error("domain without expiry date should be skipped")
+	}
+	if got := strings.Count(ics, "BEGIN:VALARM"); got != 4 {
+		t.Errorf("got %d alarms, want 4", got)
+	}
+	if strings.Index(ics, "soon.com") > strings.Index(ics, "later.dev") {
+		t.Error("events should be ordered by expiry date")
+	}
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > calendarLineLimit {
+			t.Errorf("line exceeds %d octets: %q", calendarLineLimit, line)
+		}
+	}
+}
+
+func TestEscapeCalendarText(t *testing.T) {
+	got := escapeCalendarText("a,b;c\\d\ne")
+	if want := `a\,b\;c\\d\ne`; got != want {
+		t.Errorf("escapeCalendarText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,320 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CostRecord is the last known renewal price of a domain and the price it
+// had before the most recent change
+type CostRecord struct {
+	Currency      string    `json:"currency"`
+	RenewalPrice  float64   `json:"renewal_price"`
+	PreviousPrice float64   `json:"previous_price,omitempty"` // 0 when the price never changed
+	ChangedAt     time.Time `json:"changed_at,omitzero"`
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+// CostHistory caches renewal prices between runs so price changes can be
+// detected
+type CostHistory struct {
+	Domains map[string]CostRecord `json:"domains"`
+}
+
+// LoadCostHistory reads the history at path. A missing file yields an empty
+// history.
+func LoadCostHistory(path string) (*CostHistory, error) {
+	history := &CostHistory{Domains: make(map[string]CostRecord)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cost history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to parse cost history %s: %w", path, err)
+	}
+	if history.Domains == nil {
+		history.Domains = make(map[string]CostRecord)
+	}
+	return history, nil
+}
+
+// Save writes the history to path, creating parent directories as needed
+func (h *CostHistory) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cost history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cost history directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Record stores the current price of domain, remembering the old price when
+// it changed
+func (h *CostHistory) Record(domain string, cost DomainCost, now time.Time) CostRecord {
+	record, ok := h.Domains[domain]
+	switch {
+	case !ok:
+		record = CostRecord{Currency: cost.Currency, RenewalPrice: cost.RenewalPrice}
+	case record.Currency != cost.Currency:
+		// Prices in different currencies can't be compared
+		record = CostRecord{Currency: cost.Currency, RenewalPrice: cost.RenewalPrice, ChangedAt: now}
+	case record.RenewalPrice != cost.RenewalPrice:
+		record.PreviousPrice = record.RenewalPrice
+		record.RenewalPrice = cost.RenewalPrice
+		record.ChangedAt = now
+	}
+	record.CheckedAt = now
+	h.Domains[domain] = record
+	return record
+}
+
+// RenewalCost is one upcoming renewal in a cost report
+type RenewalCost struct {
+	Domain        string    `json:"domain"`
+	Provider      string    `json:"provider"`
+	RenewalDate   time.Time `json:"renewal_date"`
+	Month         string    `json:"month"` // YYYY-MM of the renewal date
+	AutoRenewal   bool      `json:"auto_renewal"`
+	Price         float64   `json:"price"` // 0 when unknown
+	Currency      string    `json:"currency"`
+	PreviousPrice float64   `json:"previous_price,omitempty"`
+	Change        float64   `json:"change"`     // Percent change from PreviousPrice
+	PriceJump     bool      `json:"price_jump"` // Change exceeds the report's threshold
+	Error         string    `json:"error,omitempty"`
+}
+
+// CostTotal sums renewal prices of one group in one currency
+type CostTotal struct {
+	Group    string  `json:"group"`              // Month, provider or "total"
+	Subgroup string  `json:"subgroup,omitempty"` // Inner group when grouping by two keys; empty for the group's subtotal
+	Currency string  `json:"currency"`
+	Domains  int     `json:"domains"`
+	Amount   float64 `json:"amount"`
+}
+
+// CostReport forecasts renewal spend across the portfolio
+type CostReport struct {
+	Renewals []RenewalCost `json:"renewals"`
+	Totals   []CostTotal   `json:"totals"`  // Per group, subgroup and currency
+	Overall  []CostTotal   `json:"overall"` // Per currency
+	Unknown  int           `json:"unknown"` // Renewals without a known price
+}
+
+// CostReportOptions configures BuildCostReport
+type CostReportOptions struct {
+	Within        time.Duration // Only renewals due within this window; 0 means all
+	GroupBy       []string      // CostGroupings to group by, outermost first; month when empty
+	JumpThreshold float64       // Percent increase flagged as a price jump
+	Now           time.Time
+}
+
+// CostGroupings lists the keys a cost report can group renewals by
+var CostGroupings = []string{"month", "provider"}
+
+// ParseCostGroupBy parses a comma-separated list of groupings such as
+// "month,provider", outermost first
+func ParseCostGroupBy(value string) ([]string, error) {
+	var groupBy []string
+	for _, part := range strings.Split(value, ",") {
+		key := strings.ToLower(strings.TrimSpace(part))
+		if !slices.Contains(CostGroupings, key) {
+			return nil, fmt.Errorf("invalid grouping %q (use month, provider or month,provider)", part)
+		}
+		if slices.Contains(groupBy, key) {
+			return nil, fmt.Errorf("grouping %q given twice", key)
+		}
+		groupBy = append(groupBy, key)
+	}
+	return groupBy, nil
+}
+
+// costGroup returns the value of renewal's grouping key
+func costGroup(renewal RenewalCost, key string) string {
+	if key == "provider" {
+		return renewal.Provider
+	}
+	return renewal.Month
+}
+
+// LookupRenewalCosts asks the registrars for the current renewal price of
+// every domain in domainList, replacing any cached Cost. Domains whose price
+// could not be retrieved are returned with their error. The prices found are
+// written back to the inventory.
+func (d *Manager) LookupRenewalCosts(ctx context.Context, domainList []ManagedDomain) (map[string]error, error) {
+	errs := make(map[string]error)
+	found := make(map[string]*DomainCost)
+
+	for i := range domainList {
+		registrar, ok := d.Registrar(domainList[i].Provider)
+		if !ok {
+			errs[domainList[i].Name] = fmt.Errorf("provider %s is not configured", domainList[i].Provider)
+			continue
+		}
+
+		cost, err := registrar.GetRenewalInfo(ctx, domainList[i].Name)
+		if err != nil {
+			errs[domainList[i].Name] = err
+			continue
+		}
+		domainList[i].Cost = cost
+		found[domainList[i].Name] = cost
+	}
+
+	if len(found) == 0 {
+		return errs, nil
+	}
+
+	inventory, err := d.loadInventory()
+	if err != nil {
+		return errs, err
+	}
+	for i := range inventory.Domains {
+		if cost, ok := found[inventory.Domains[i].Name]; ok {
+			inventory.Domains[i].Cost = cost
+		}
+	}
+	return errs, d.saveInventory(inventory)
+}
+
+// BuildCostReport forecasts the renewals of domainList, recording current
+// prices in history and flagging increases above options.JumpThreshold.
+// lookupErrs holds the per-domain errors from LookupRenewalCosts.
+func BuildCostReport(domainList []ManagedDomain, history *CostHistory, lookupErrs map[string]error, options CostReportOptions) *CostReport {
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	report := &CostReport{Renewals: []RenewalCost{}}
+	for _, domain := range domainList {
+		if domain.ExpiryDate.IsZero() {
+			continue
+		}
+		if options.Within > 0 && domain.ExpiryDate.After(now.Add(options.Within)) {
+			continue
+		}
+
+		renewal := RenewalCost{
+			Domain:      domain.Name,
+			Provider:    domain.Provider,
+			RenewalDate: domain.ExpiryDate,
+			Month:       domain.ExpiryDate.Format("2006-01"),
+			AutoRenewal: domain.AutoRenewal,
+		}
+
+		if domain.Cost != nil && domain.Cost.RenewalPrice > 0 {
+			record := history.Record(domain.Name, *domain.Cost, now)
+			renewal.Price = record.RenewalPrice
+			renewal.Currency = record.Currency
+			if record.PreviousPrice > 0 {
+				renewal.PreviousPrice = record.PreviousPrice
+				renewal.Change = math.Round((record.RenewalPrice-record.PreviousPrice)/record.PreviousPrice*1000) / 10
+				renewal.PriceJump = renewal.Change > options.JumpThreshold
+			}
+		} else {
+			report.Unknown++
+			if err := lookupErrs[domain.Name]; err != nil {
+				renewal.Error = err.Error()
+			}
+		}
+
+		report.Renewals = append(report.Renewals, renewal)
+	}
+
+	groupBy := options.GroupBy
+	if len(groupBy) == 0 {
+		groupBy = []string{"month"}
+	}
+	groupOf := func(r RenewalCost) (string, string) { return costGroup(r, groupBy[0]), "" }
+	if len(groupBy) > 1 {
+		groupOf = func(r RenewalCost) (string, string) { return costGroup(r, groupBy[0]), costGroup(r, groupBy[1]) }
+	}
+
+	sort.SliceStable(report.Renewals, func(i, j int) bool {
+		a, b := report.Renewals[i], report.Renewals[j]
+		groupA, subgroupA := groupOf(a)
+		groupB, subgroupB := groupOf(b)
+		if groupA != groupB {
+			return groupA < groupB
+		}
+		if subgroupA != subgroupB {
+			return subgroupA < subgroupB
+		}
+		if !a.RenewalDate.Equal(b.RenewalDate) {
+			return a.RenewalDate.Before(b.RenewalDate)
+		}
+		return a.Domain < b.Domain
+	})
+
+	report.Totals = sumCosts(report.Renewals, groupOf)
+	if len(groupBy) > 1 {
+		// Each group's subtotal comes before its subgroups
+		report.Totals = append(report.Totals, sumCosts(report.Renewals, func(r RenewalCost) (string, string) {
+			return costGroup(r, groupBy[0]), ""
+		})...)
+		sortCostTotals(report.Totals)
+	}
+	report.Overall = sumCosts(report.Renewals, func(RenewalCost) (string, string) { return "total", "" })
+	return report
+}
+
+// sumCosts totals known prices per group, subgroup and currency, sorted by
+// sortCostTotals
+func sumCosts(renewals []RenewalCost, groupOf func(RenewalCost) (string, string)) []CostTotal {
+	type key struct{ group, subgroup, currency string }
+	sums := make(map[key]*CostTotal)
+
+	for _, renewal := range renewals {
+		if renewal.Currency == "" {
+			continue
+		}
+		group, subgroup := groupOf(renewal)
+		k := key{group, subgroup, renewal.Currency}
+		total, ok := sums[k]
+		if !ok {
+			total = &CostTotal{Group: group, Subgroup: subgroup, Currency: renewal.Currency}
+			sums[k] = total
+		}
+		total.Domains++
+		total.Amount += renewal.Price
+	}
+
+	totals := make([]CostTotal, 0, len(sums))
+	for _, total := range sums {
+		total.Amount = math.Round(total.Amount*100) / 100
+		totals = append(totals, *total)
+	}
+	sortCostTotals(totals)
+	return totals
+}
+
+// sortCostTotals orders totals by group, then subgroup with the group's
+// subtotal first, then currency
+func sortCostTotals(totals []CostTotal) {
+	sort.Slice(totals, func(i, j int) bool {
+		a, b := totals[i], totals[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Subgroup != b.Subgroup {
+			return a.Subgroup < b.Subgroup
+		}
+		return a.Currency < b.Currency
+	})
+}
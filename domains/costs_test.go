@@ -0,0 +1,130 @@
+package domains
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCostHistoryRecord(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := &CostHistory{Domains: map[string]CostRecord{}}
+
+	history.Record("example.com", DomainCost{Currency: "USD", RenewalPrice: 10}, now)
+	record := history.Record("example.com", DomainCost{Currency: "USD", RenewalPrice: 10}, now.AddDate(0, 1, 0))
+	if record.PreviousPrice != 0 || !record.ChangedAt.IsZero() {
+		t.Errorf("unchanged price recorded as a change: %+v", record)
+	}
+
+	record = history.Record("example.com", DomainCost{Currency: "USD", RenewalPrice: 15}, now.AddDate(0, 2, 0))
+	if record.PreviousPrice != 10 || record.RenewalPrice != 15 {
+		t.Errorf("record = %+v, want 10 -> 15", record)
+	}
+
+	// The previous price sticks until the price changes again
+	record = history.Record("example.com", DomainCost{Currency: "USD", RenewalPrice: 15}, now.AddDate(0, 3, 0))
+	if record.PreviousPrice != 10 {
+		t.Errorf("previous price lost on an unchanged run: %+v", record)
+	}
+
+	record = history.Record("example.com", DomainCost{Currency: "EUR", RenewalPrice: 14}, now.AddDate(0, 4, 0))
+	if record.PreviousPrice != 0 {
+		t.Errorf("prices in different currencies compared: %+v", record)
+	}
+}
+
+func TestBuildCostReport(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "costs.json")
+
+	history, err := LoadCostHistory(path)
+	if err != nil {
+		t.Fatalf("LoadCostHistory: %v", err)
+	}
+	history.Record("jump.io", DomainCost{Currency: "USD", RenewalPrice: 40}, now.AddDate(-1, 0, 0))
+	if err := history.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if history, err = LoadCostHistory(path); err != nil {
+		t.Fatalf("LoadCostHistory: %v", err)
+	}
+
+	domainList := []ManagedDomain{
+		{Name: "a.com", Provider: "porkbun", ExpiryDate: now.AddDate(0, 1, 5), Cost: &DomainCost{Currency: "USD", RenewalPrice: 10.5}},
+		{Name: "jump.io", Provider: "namecheap", ExpiryDate: now.AddDate(0, 1, 10), Cost: &DomainCost{Currency: "USD", RenewalPrice: 50}},
+		{Name: "b.de", Provider: "porkbun", ExpiryDate: now.AddDate(0, 3, 0), Cost: &DomainCost{Currency: "EUR", RenewalPrice: 6}},
+		{Name: "nocost.dev", Provider: "godaddy", ExpiryDate: now.AddDate(0, 3, 0)},
+		{Name: "far.net", Provider: "porkbun", ExpiryDate: now.AddDate(3, 0, 0), Cost: &DomainCost{Currency: "USD", RenewalPrice: 12}},
+	}
+
+	report := BuildCostReport(domainList, history, nil, CostReportOptions{Within: 365 * 24 * time.Hour, JumpThreshold: 10, Now: now})
+
+	if len(report.Renewals) != 4 {
+		t.Fatalf("got %d renewals, want 4 within a year", len(report.Renewals))
+	}
+	if report.Unknown != 1 {
+		t.Errorf("Unknown = %d, want 1", report.Unknown)
+	}
+
+	for _, renewal := range report.Renewals {
+		if renewal.Domain == "jump.io" && (!renewal.PriceJump || renewal.Change != 25 || renewal.PreviousPrice != 40) {
+			t.Errorf("jump.io = %+v, want a 25%% jump from 40", renewal)
+		}
+		if renewal.Domain == "a.com" && renewal.PriceJump {
+			t.Error("a.com has no price history and should not be flagged")
+		}
+	}
+
+	wantTotals := []CostTotal{
+		{Group: "2026-02", Currency: "USD", Domains: 2, Amount: 60.5},
+		{Group: "2026-04", Currency: "EUR", Domains: 1, Amount: 6},
+	}
+	if len(report.Totals) != len(wantTotals) {
+		t.Fatalf("totals = %+v, want %+v", report.Totals, wantTotals)
+	}
+	for i := range wantTotals {
+		if report.Totals[i] != wantTotals[i] {
+			t.Errorf("totals[%d] = %+v, want %+v", i, report.Totals[i], wantTotals[i])
+		}
+	}
+	if len(report.Overall) != 2 || report.Overall[1].Currency != "USD" || report.Overall[1].Amount != 60.5 {
+		t.Errorf("overall = %+v", report.Overall)
+	}
+
+	byProvider := BuildCostReport(domainList, history, nil, CostReportOptions{Within: 365 * 24 * time.Hour, GroupBy: []string{"provider"}, Now: now})
+	if byProvider.Totals[0].Group != "namecheap" || byProvider.Renewals[0].Provider != "godaddy" {
+		t.Errorf("provider grouping not applied: %+v", byProvider.Totals)
+	}
+
+	// Providers within each month, with the month's subtotal first
+	nested := BuildCostReport(domainList, history, nil, CostReportOptions{Within: 365 * 24 * time.Hour, GroupBy: []string{"month", "provider"}, Now: now})
+	wantNested := []CostTotal{
+		{Group: "2026-02", Currency: "USD", Domains: 2, Amount: 60.5},
+		{Group: "2026-02", Subgroup: "namecheap", Currency: "USD", Domains: 1, Amount: 50},
+		{Group: "2026-02", Subgroup: "porkbun", Currency: "USD", Domains: 1, Amount: 10.5},
+		{Group: "2026-04", Currency: "EUR", Domains: 1, Amount: 6},
+		{Group: "2026-04", Subgroup: "porkbun", Currency: "EUR", Domains: 1, Amount: 6},
+	}
+	if !reflect.DeepEqual(nested.Totals, wantNested) {
+		t.Errorf("nested totals = %+v, want %+v", nested.Totals, wantNested)
+	}
+	var order []string
+	for _, renewal := range nested.Renewals {
+		order = append(order, renewal.Domain)
+	}
+	if want := []string{"jump.io", "a.com", "nocost.dev", "b.de"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("nested order = %v, want %v", order, want)
+	}
+}
+
+func TestParseCostGroupBy(t *testing.T) {
+	if got, err := ParseCostGroupBy("Month, provider"); err != nil || !reflect.DeepEqual(got, []string{"month", "provider"}) {
+		t.Errorf("ParseCostGroupBy = %v, %v", got, err)
+	}
+	for _, value := range []string{"", "tld", "month,month", "month,"} {
+		if got, err := ParseCostGroupBy(value); err == nil {
+			t.Errorf("ParseCostGroupBy(%q) = %v, want an error", value, got)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"indietool/cli/dns"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DelegationCheck identifies a kind of delegation problem
+type DelegationCheck string
+
+const (
+	CheckNoNameservers        DelegationCheck = "no-nameservers"        // The registrar reports no nameservers
+	CheckUnknownProvider      DelegationCheck = "unknown-provider"      // Nameservers of an unrecognized DNS host
+	CheckUnconfiguredProvider DelegationCheck = "unconfigured-provider" // Delegated to a provider indietool has no credentials for
+	CheckZoneMissing          DelegationCheck = "zone-missing"          // Delegated to a provider without a zone for the domain
+	CheckStrayZone            DelegationCheck = "stray-zone"            // A zone exists at a provider the domain isn't delegated to
+	CheckPublicMismatch       DelegationCheck = "public-mismatch"       // Public DNS serves other nameservers than the registrar has
+	CheckPublicLookup         DelegationCheck = "public-lookup"         // Public DNS has no delegation for the domain
+	CheckLameDelegation       DelegationCheck = "lame-delegation"       // A delegated nameserver doesn't answer for the zone
+)
+
+// Finding severities, from most to least serious
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// DelegationFinding is one problem found with a domain's delegation, with a
+// suggested fix
+type DelegationFinding struct {
+	Domain   string          `json:"domain"`
+	Check    DelegationCheck `json:"check"`
+	Severity string          `json:"severity"`
+	Message  string          `json:"message"`
+	Fix      string          `json:"fix,omitempty"`
+}
+
+// DelegationReport joins what the registrar, public DNS and the configured DNS
+// providers know about one domain
+type DelegationReport struct {
+	Domain               string              `json:"domain"`
+	Registrar            string              `json:"registrar"`
+	RegistrarNameservers []string            `json:"registrar_nameservers"`
+	DelegatedTo          string              `json:"delegated_to,omitempty"` // Provider detected from the registrar's nameservers
+	PublicNameservers    []string            `json:"public_nameservers"`
+	PublicProvider       string              `json:"public_provider,omitempty"`
+	ZonesAt              []string            `json:"zones_at"` // Configured DNS providers with a zone for the domain
+	Findings             []DelegationFinding `json:"findings"`
+}
+
+// Healthy reports whether no warnings or errors were found
+func (r DelegationReport) Healthy() bool {
+	for _, finding := range r.Findings {
+		if finding.Severity != SeverityInfo {
+			return false
+		}
+	}
+	return true
+}
+
+// DoctorOptions configures DiagnoseDelegation
+type DoctorOptions struct {
+	// DNSProviders are the configured DNS providers whose zones are checked
+	DNSProviders []dns.Provider
+
+	// SkipProbe skips querying each delegated nameserver directly
+	SkipProbe bool
+
+	// Concurrency bounds how many domains are checked at once (default 8)
+	Concurrency int
+
+	// LookupPublic and ProbeNameserver default to dns.DetectProvider and
+	// dns.QueryNameserver; tests replace them
+	LookupPublic    func(ctx context.Context, domain string) (*dns.DetectorResult, error)
+	ProbeNameserver func(ctx context.Context, nameserver, domain string) error
+}
+
+// DiagnoseDelegation checks every domain's delegation at its registrar
+// against public DNS and the zones held by the configured DNS providers
+func DiagnoseDelegation(ctx context.Context, domainList []ManagedDomain, options DoctorOptions) []DelegationReport {
+	if options.LookupPublic == nil {
+		options.LookupPublic = func(ctx context.Context, domain string) (*dns.DetectorResult, error) {
+			return dns.DetectProvider(domain)
+		}
+	}
+	if options.ProbeNameserver == nil {
+		options.ProbeNameserver = func(ctx context.Context, nameserver, domain string) error {
+			_, err := dns.QueryNameserver(ctx, nameserver, domain)
+			return err
+		}
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+
+	zones := newZoneIndex(options.DNSProviders)
+	reports := make([]DelegationReport, len(domainList))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, options.Concurrency)
+	for i, domain := range domainList {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			reports[i] = diagnoseDomain(ctx, domain, zones, options)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Domain < reports[j].Domain })
+	return reports
+}
+
+func diagnoseDomain(ctx context.Context, domain ManagedDomain, zones *zoneIndex, options DoctorOptions) DelegationReport {
+	report := DelegationReport{
+		Domain:               domain.Name,
+		Registrar:            domain.Provider,
+		RegistrarNameservers: dns.NormalizeNameservers(domain.Nameservers),
+		PublicNameservers:    []string{},
+		ZonesAt:              zones.providersWithZone(ctx, domain.Name),
+		Findings:             []DelegationFinding{},
+	}
+	report.DelegatedTo = dns.DetectNameserverProvider(report.RegistrarNameservers)
+
+	add := func(check DelegationCheck, severity, message, fix string) {
+		report.Findings = append(report.Findings, DelegationFinding{
+			Domain:   domain.Name,
+			Check:    check,
+			Severity: severity,
+			Message:  message,
+			Fix:      fix,
+		})
+	}
+
+	// Public view
+	public, err := options.LookupPublic(ctx, domain.Name)
+	publicOK := public != nil && len(public.Nameservers) > 0
+	if publicOK {
+		report.PublicNameservers = dns.NormalizeNameservers(public.Nameservers)
+		report.PublicProvider = public.Provider
+	} else if err != nil {
+		add(CheckPublicLookup, SeverityWarning,
+			fmt.Sprintf("public DNS has no delegation for %s: %v", domain.Name, err),
+			fmt.Sprintf("indietool domains ns get %s", domain.Name))
+	}
+
+	// Registrar view
+	if len(report.RegistrarNameservers) == 0 {
+		add(CheckNoNameservers, SeverityWarning,
+			fmt.Sprintf("%s reports no nameservers", domain.Provider),
+			fmt.Sprintf("indietool domains sync %s", domain.Provider))
+	} else if publicOK && !dns.SameNameservers(report.RegistrarNameservers, report.PublicNameservers) {
+		add(CheckPublicMismatch, SeverityInfo,
+			fmt.Sprintf("public DNS serves %s but %s delegates to %s (a recent change may still be propagating)",
+				strings.Join(report.PublicNameservers, ", "), domain.Provider, strings.Join(report.RegistrarNameservers, ", ")),
+			fmt.Sprintf("indietool domains ns get %s", domain.Name))
+	}
+
+	delegatedTo := report.DelegatedTo
+	switch {
+	case len(report.RegistrarNameservers) == 0:
+	case delegatedTo == "":
+		add(CheckUnknownProvider, SeverityInfo,
+			fmt.Sprintf("delegated to unrecognized nameservers %s", strings.Join(report.RegistrarNameservers, ", ")),
+			"")
+	case !zones.configured(delegatedTo):
+		fix := fmt.Sprintf("indietool config add provider %s", delegatedTo)
+		switch {
+		case len(report.ZonesAt) > 0:
+			fix = fmt.Sprintf("indietool domains ns set %s --preset %s", domain.Name, report.ZonesAt[0])
+		case delegatedTo == "godaddy":
+			// indietool can't manage GoDaddy DNS, so there is nothing to configure
+			fix = ""
+		}
+		add(CheckUnconfiguredProvider, SeverityWarning,
+			fmt.Sprintf("delegated to %s, which is not configured as a DNS provider", delegatedTo)+zonesElsewhere(report.ZonesAt),
+			fix)
+	case !slices.Contains(report.ZonesAt, delegatedTo):
+		if len(report.ZonesAt) > 0 {
+			add(CheckZoneMissing, SeverityError,
+				fmt.Sprintf("delegated to %s but the zone only exists in %s", delegatedTo, strings.Join(report.ZonesAt, ", ")),
+				fmt.Sprintf("indietool domains ns set %s --preset %s", domain.Name, report.ZonesAt[0]))
+		} else {
+			add(CheckZoneMissing, SeverityError,
+				fmt.Sprintf("delegated to %s but no configured provider has a zone for it", delegatedTo),
+				fmt.Sprintf("create the %s zone at %s, then run indietool domains doctor again", domain.Name, delegatedTo))
+		}
+	}
+
+	// Zones at providers where zones are created on purpose are probably
+	// meant to serve the domain
+	for _, provider := range report.ZonesAt {
+		if provider != delegatedTo && zones.explicit(provider) && delegatedTo != "" {
+			add(CheckStrayZone, SeverityInfo,
+				fmt.Sprintf("a zone exists in %s but the domain is delegated to %s", provider, delegatedTo),
+				fmt.Sprintf("indietool domains ns set %s --preset %s", domain.Name, provider))
+		}
+	}
+
+	// Ask each delegated nameserver whether it serves the zone
+	if !options.SkipProbe && len(report.RegistrarNameservers) > 0 {
+		var healthy, lame []string
+		for _, ns := range report.RegistrarNameservers {
+			if err := options.ProbeNameserver(ctx, ns, domain.Name); err != nil {
+				lame = append(lame, ns)
+			} else {
+				healthy = append(healthy, ns)
+			}
+		}
+		if len(lame) > 0 {
+			severity := SeverityWarning
+			fix := fmt.Sprintf("indietool domains ns set %s %s", domain.Name, strings.Join(healthy, " "))
+			message := fmt.Sprintf("%s %s not answer for the zone", strings.Join(lame, ", "), pluralVerb(len(lame), "does", "do"))
+			if len(healthy) == 0 {
+				severity = SeverityError
+				fix = ""
+				for _, provider := range report.ZonesAt {
+					if provider == delegatedTo {
+						// Moving elsewhere won't help; the zone itself isn't being served
+						message += fmt.Sprintf(" (the zone exists at %s; check that it is active)", provider)
+						fix = ""
+						break
+					}
+					if fix == "" {
+						fix = fmt.Sprintf("indietool domains ns set %s --preset %s", domain.Name, provider)
+					}
+				}
+			}
+			add(CheckLameDelegation, severity, message, fix)
+		}
+	}
+
+	return report
+}
+
+func zonesElsewhere(zonesAt []string) string {
+	if len(zonesAt) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("; the zone exists in %s", strings.Join(zonesAt, ", "))
+}
+
+func pluralVerb(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// zoneIndex answers which configured DNS providers hold a zone. Providers
+// that list zones are asked once; others are probed per domain by listing
+// its records.
+type zoneIndex struct {
+	providers map[string]dns.Provider
+	mu        sync.Mutex
+	listed    map[string]map[string]bool // provider -> zone names, for ZoneListers
+}
+
+func newZoneIndex(providers []dns.Provider) *zoneIndex {
+	index := &zoneIndex{
+		providers: make(map[string]dns.Provider, len(providers)),
+		listed:    make(map[string]map[string]bool),
+	}
+	for _, provider := range providers {
+		index.providers[strings.ToLower(provider.Name())] = provider
+	}
+	return index
+}
+
+func (z *zoneIndex) configured(name string) bool {
+	_, ok := z.providers[name]
+	return ok
+}
+
+// explicit reports whether zones at the provider exist only when created
+func (z *zoneIndex) explicit(name string) bool {
+	_, ok := z.providers[name].(dns.ZoneLister)
+	return ok
+}
+
+// providersWithZone returns the sorted names of providers holding a zone for
+// domain
+func (z *zoneIndex) providersWithZone(ctx context.Context, domain string) []string {
+	found := []string{}
+	for name, provider := range z.providers {
+		if z.hasZone(ctx, name, provider, domain) {
+			found = append(found, name)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+func (z *zoneIndex) hasZone(ctx context.Context, name string, provider dns.Provider, domain string) bool {
+	lister, ok := provider.(dns.ZoneLister)
+	if !ok {
+		_, err := provider.ListRecords(ctx, domain)
+		return err == nil
+	}
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	zones, ok := z.listed[name]
+	if !ok {
+		zones = make(map[string]bool)
+		// A failed listing counts as no zones rather than failing the report
+		if names, err := lister.ListZones(ctx); err == nil {
+			for _, zone := range names {
+				zones[strings.ToLower(zone)] = true
+			}
+		}
+		z.listed[name] = zones
+	}
+	return zones[strings.ToLower(domain)]
+}
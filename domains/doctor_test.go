@@ -0,0 +1,131 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"indietool/cli/dns"
+	"strings"
+	"testing"
+)
+
+// zoneFake is a DNS provider holding a fixed set of zones
+type zoneFake struct {
+	name  string
+	zones []string
+}
+
+func (f *zoneFake) Name() string { return f.name }
+
+func (f *zoneFake) ListRecords(ctx context.Context, domain string) ([]dns.Record, error) {
+	for _, zone := range f.zones {
+		if zone == domain {
+			return []dns.Record{}, nil
+		}
+	}
+	return nil, errors.New("zone not found")
+}
+
+func (f *zoneFake) SetRecord(ctx context.Context, domain string, record dns.Record) error {
+	return errors.New("not implemented")
+}
+
+func (f *zoneFake) DeleteRecord(ctx context.Context, domain, recordID string) error {
+	return errors.New("not implemented")
+}
+
+func (f *zoneFake) GetRecord(ctx context.Context, domain, name, recordType string) (*dns.Record, error) {
+	return nil, errors.New("not implemented")
+}
+
+// zoneListerFake is a provider where zones are created explicitly
+type zoneListerFake struct{ zoneFake }
+
+func (f *zoneListerFake) ListZones(ctx context.Context) ([]string, error) {
+	return f.zones, nil
+}
+
+var (
+	cloudflareNS = []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}
+	porkbunNS    = []string{"curitiba.ns.porkbun.com", "fortaleza.ns.porkbun.com"}
+)
+
+func TestDiagnoseDelegation(t *testing.T) {
+	cloudflare := &zoneListerFake{zoneFake{name: "cloudflare", zones: []string{"good.com", "stray.com"}}}
+	porkbun := &zoneFake{name: "porkbun", zones: []string{"good.com", "stray.com", "empty.com", "lame.com"}}
+
+	domainList := []ManagedDomain{
+		{Name: "good.com", Provider: "porkbun", Nameservers: cloudflareNS},
+		{Name: "stray.com", Provider: "porkbun", Nameservers: porkbunNS},    // Cloudflare zone unused
+		{Name: "empty.com", Provider: "porkbun", Nameservers: cloudflareNS}, // No Cloudflare zone
+		{Name: "gd.com", Provider: "godaddy", Nameservers: []string{"ns01.domaincontrol.com", "ns02.domaincontrol.com"}},
+		{Name: "lame.com", Provider: "porkbun", Nameservers: porkbunNS},
+		{Name: "bare.com", Provider: "porkbun"},
+	}
+
+	options := DoctorOptions{
+		DNSProviders: []dns.Provider{cloudflare, porkbun},
+		LookupPublic: func(ctx context.Context, domain string) (*dns.DetectorResult, error) {
+			for _, d := range domainList {
+				if d.Name == domain && len(d.Nameservers) > 0 {
+					return &dns.DetectorResult{Nameservers: d.Nameservers}, nil
+				}
+			}
+			return &dns.DetectorResult{}, errors.New("no such host")
+		},
+		ProbeNameserver: func(ctx context.Context, nameserver, domain string) error {
+			if domain == "lame.com" && strings.HasPrefix(nameserver, "curitiba") {
+				return errors.New("refused")
+			}
+			return nil
+		},
+	}
+
+	reports := DiagnoseDelegation(context.Background(), domainList, options)
+	byDomain := make(map[string]DelegationReport)
+	for _, report := range reports {
+		byDomain[report.Domain] = report
+	}
+
+	checks := func(domain string) []DelegationCheck {
+		var found []DelegationCheck
+		for _, finding := range byDomain[domain].Findings {
+			found = append(found, finding.Check)
+		}
+		return found
+	}
+
+	// Porkbun holds a zone for every domain it registers, so it isn't stray
+	if report := byDomain["good.com"]; len(report.Findings) != 0 || report.DelegatedTo != "cloudflare" {
+		t.Errorf("good.com = %+v, want healthy delegation to cloudflare", report)
+	}
+
+	if got := checks("stray.com"); len(got) != 1 || got[0] != CheckStrayZone {
+		t.Errorf("stray.com findings = %v, want a stray Cloudflare zone", got)
+	}
+	if !byDomain["stray.com"].Healthy() {
+		t.Error("a stray zone alone should not make a domain unhealthy")
+	}
+
+	empty := byDomain["empty.com"]
+	if len(empty.Findings) != 1 || empty.Findings[0].Check != CheckZoneMissing ||
+		!strings.Contains(empty.Findings[0].Message, "only exists in porkbun") ||
+		empty.Findings[0].Fix != "indietool domains ns set empty.com --preset porkbun" {
+		t.Errorf("empty.com findings = %+v", empty.Findings)
+	}
+
+	if got := checks("gd.com"); len(got) != 1 || got[0] != CheckUnconfiguredProvider {
+		t.Errorf("gd.com findings = %v, want unconfigured godaddy", got)
+	}
+
+	lame := byDomain["lame.com"]
+	if len(lame.Findings) != 1 || lame.Findings[0].Check != CheckLameDelegation || lame.Findings[0].Severity != SeverityWarning {
+		t.Fatalf("lame.com findings = %+v", lame.Findings)
+	}
+	if lame.Findings[0].Fix != "indietool domains ns set lame.com fortaleza.ns.porkbun.com" {
+		t.Errorf("lame.com fix = %q, want the answering nameserver kept", lame.Findings[0].Fix)
+	}
+
+	if got := checks("bare.com"); len(got) != 2 || got[0] != CheckPublicLookup || got[1] != CheckNoNameservers {
+		t.Errorf("bare.com findings = %v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package domains
+
+import (
+	"sort"
+	"strings"
+)
+
+// EPPStatus is a domain status code with what it means for the domain
+type EPPStatus struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// eppStatusDescriptions explains the EPP status codes (RFC 5731, ICANN's
+// "EPP status codes" guide) and the RDAP statuses without an EPP equivalent
+// (RFC 8056), keyed by canonical spelling
+var eppStatusDescriptions = map[string]string{
+	"ok":                       "No pending operations or restrictions",
+	"active":                   "No pending operations or restrictions",
+	"inactive":                 "No nameservers set, so the domain doesn't resolve",
+	"addPeriod":                "Recently registered; the registrar can still cancel it",
+	"autoRenewPeriod":          "Expired and renewed by the registry; the registrar can still delete it",
+	"renewPeriod":              "Recently renewed",
+	"transferPeriod":           "Recently transferred to another registrar",
+	"redemptionPeriod":         "Deleted; only the previous owner can restore it, for a fee",
+	"pendingRestore":           "Being restored from redemption",
+	"pendingDelete":            "About to be released by the registry",
+	"pendingCreate":            "Registration is being processed",
+	"pendingRenew":             "Renewal is being processed",
+	"pendingTransfer":          "Transfer to another registrar is being processed",
+	"pendingUpdate":            "An update is being processed",
+	"clientHold":               "Suspended by the registrar; the domain doesn't resolve",
+	"serverHold":               "Suspended by the registry; the domain doesn't resolve",
+	"clientDeleteProhibited":   "The registrar blocks deleting it",
+	"serverDeleteProhibited":   "The registry blocks deleting it",
+	"clientRenewProhibited":    "The registrar blocks renewing it",
+	"serverRenewProhibited":    "The registry blocks renewing it",
+	"clientTransferProhibited": "The registrar blocks transfers (the usual transfer lock)",
+	"serverTransferProhibited": "The registry blocks transfers",
+	"clientUpdateProhibited":   "The registrar blocks changes",
+	"serverUpdateProhibited":   "The registry blocks changes",
+	"locked":                   "Locked against changes",
+	"associated":               "Linked to other registry objects",
+	"transferProhibited":       "Transfers are blocked",
+	"updateProhibited":         "Changes are blocked",
+	"deleteProhibited":         "Deleting it is blocked",
+	"renewProhibited":          "Renewing it is blocked",
+}
+
+// eppStatusCodes maps the lowercased, unspaced spelling of each known status
+// to its canonical spelling
+var eppStatusCodes = func() map[string]string {
+	codes := make(map[string]string, len(eppStatusDescriptions))
+	for code := range eppStatusDescriptions {
+		codes[strings.ToLower(code)] = code
+	}
+	return codes
+}()
+
+// NormalizeEPPStatus turns the spellings registries use for a status into
+// the EPP code: RDAP's "client transfer prohibited", WHOIS's
+// "clientTransferProhibited https://icann.org/epp#clientTransferProhibited"
+// and the parser's "clienttransferprohibited" all become
+// "clientTransferProhibited". Unknown statuses are returned trimmed.
+func NormalizeEPPStatus(status string) string {
+	status = strings.TrimSpace(status)
+	if i := strings.Index(status, "http"); i > 0 {
+		status = strings.TrimSpace(status[:i])
+	}
+	key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(status))
+	if code, ok := eppStatusCodes[key]; ok {
+		return code
+	}
+	return status
+}
+
+// NormalizeEPPStatuses normalizes statuses, dropping blanks and duplicates
+func NormalizeEPPStatuses(statuses []string) []string {
+	var codes []string
+	seen := make(map[string]bool)
+	for _, status := range statuses {
+		code := NormalizeEPPStatus(status)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// DescribeEPPStatuses pairs status codes with their explanations
+func DescribeEPPStatuses(codes []string) []EPPStatus {
+	statuses := make([]EPPStatus, 0, len(codes))
+	for _, code := range codes {
+		statuses = append(statuses, EPPStatus{Code: code, Description: eppStatusDescriptions[code]})
+	}
+	return statuses
+}
+
+// EPPStatusLegend explains each status code found in results once, sorted
+// by code, for printing below a table
+func EPPStatusLegend(results []DomainSearchResult) []EPPStatus {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, result := range results {
+		for _, code := range result.EPPStatus {
+			if !seen[code] {
+				seen[code] = true
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+	return DescribeEPPStatuses(codes)
+}
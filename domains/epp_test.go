@@ -0,0 +1,45 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeEPPStatus(t *testing.T) {
+	tests := map[string]string{
+		"client transfer prohibited": "clientTransferProhibited",
+		"clientTransferProhibited https://icann.org/epp#clientTransferProhibited": "clientTransferProhibited",
+		"clienttransferprohibited": "clientTransferProhibited",
+		"redemption period":        "redemptionPeriod",
+		"pending_delete":           "pendingDelete",
+		" active ":                 "active",
+		"registry-lock":            "registry-lock",
+	}
+	for input, want := range tests {
+		if got := NormalizeEPPStatus(input); got != want {
+			t.Errorf("NormalizeEPPStatus(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEPPStatusLegend(t *testing.T) {
+	results := []DomainSearchResult{
+		{Domain: "kopi.com", EPPStatus: NormalizeEPPStatuses([]string{"client hold", "clientHold", ""})},
+		{Domain: "kopi.dev", EPPStatus: []string{"redemptionPeriod", "clientHold", "registry-lock"}},
+	}
+	if got := results[0].EPPStatus; !reflect.DeepEqual(got, []string{"clientHold"}) {
+		t.Fatalf("NormalizeEPPStatuses = %v, want [clientHold]", got)
+	}
+
+	legend := EPPStatusLegend(results)
+	var codes []string
+	for _, status := range legend {
+		codes = append(codes, status.Code)
+	}
+	if want := []string{"clientHold", "redemptionPeriod", "registry-lock"}; !reflect.DeepEqual(codes, want) {
+		t.Fatalf("legend codes = %v, want %v", codes, want)
+	}
+	if legend[0].Description == "" || legend[2].Description != "" {
+		t.Errorf("descriptions = %+v, want known codes explained and unknown ones blank", legend)
+	}
+}
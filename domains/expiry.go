@@ -0,0 +1,220 @@
+package domains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/indietool/notify"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ExpiredThreshold is the pseudo-threshold used for domains that have
+// already expired. It is always checked in addition to the configured ones.
+const ExpiredThreshold = -1
+
+// DefaultExpiryWarningDays is used when no warning thresholds are configured
+var DefaultExpiryWarningDays = []int{30, 7, 1}
+
+// ExpiryAlert is raised when a domain crosses one of the expiry warning
+// thresholds
+type ExpiryAlert struct {
+	Domain      string          `json:"domain"`
+	Provider    string          `json:"provider"`
+	ExpiryDate  time.Time       `json:"expiry_date"`
+	DaysLeft    int             `json:"days_left"`
+	Threshold   int             `json:"threshold"` // Days, or ExpiredThreshold
+	AutoRenewal bool            `json:"auto_renewal"`
+	Severity    notify.Severity `json:"severity"`
+
+	// passed are the less urgent thresholds the domain has also crossed
+	passed []int
+}
+
+// Notification renders the alert for delivery through notify sinks
+func (a ExpiryAlert) Notification() notify.Notification {
+	var title string
+	switch {
+	case a.Threshold == ExpiredThreshold:
+		title = fmt.Sprintf("%s has expired", a.Domain)
+	case a.DaysLeft == 0:
+		title = fmt.Sprintf("%s expires today", a.Domain)
+	case a.DaysLeft == 1:
+		title = fmt.Sprintf("%s expires tomorrow", a.Domain)
+	default:
+		title = fmt.Sprintf("%s expires in %d days", a.Domain, a.DaysLeft)
+	}
+
+	renewal := "Auto-renewal is off, renew it manually."
+	if a.AutoRenewal {
+		renewal = "Auto-renewal is on."
+	}
+
+	return notify.Notification{
+		Event:    "domain.expiry",
+		Title:    title,
+		Body:     fmt.Sprintf("%s (%s) expires on %s. %s", a.Domain, a.Provider, a.ExpiryDate.Format("2006-01-02"), renewal),
+		Severity: a.Severity,
+		Data: map[string]any{
+			"domain":       a.Domain,
+			"provider":     a.Provider,
+			"expiry_date":  a.ExpiryDate,
+			"days_left":    a.DaysLeft,
+			"threshold":    a.Threshold,
+			"auto_renewal": a.AutoRenewal,
+		},
+	}
+}
+
+// ExpiryNotifyState remembers which thresholds already alerted for each
+// domain so every threshold fires once per registration period
+type ExpiryNotifyState struct {
+	Domains map[string]ExpiryNotifyRecord `json:"domains"`
+	LastRun time.Time                     `json:"last_run"`
+}
+
+// ExpiryNotifyRecord is the alert history of a single domain. It is reset
+// whenever the expiry date changes, i.e. after a renewal.
+type ExpiryNotifyRecord struct {
+	ExpiryDate time.Time `json:"expiry_date"`
+	Notified   []int     `json:"notified"`
+}
+
+// LoadExpiryNotifyState reads the state at path. A missing file yields an
+// empty state.
+func LoadExpiryNotifyState(path string) (*ExpiryNotifyState, error) {
+	state := &ExpiryNotifyState{Domains: make(map[string]ExpiryNotifyRecord)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse notification state %s: %w", path, err)
+	}
+	if state.Domains == nil {
+		state.Domains = make(map[string]ExpiryNotifyRecord)
+	}
+	return state, nil
+}
+
+// Save writes the state to path, creating parent directories as needed
+func (s *ExpiryNotifyState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode notification state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create notification state directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Due returns the alerts that should fire for domainList at now. When a
+// domain crossed several thresholds since the last run only the most urgent
+// one is returned. Due does not modify the state; call MarkNotified once an
+// alert has been delivered.
+func (s *ExpiryNotifyState) Due(domainList []ManagedDomain, thresholds []int, now time.Time) []ExpiryAlert {
+	if len(thresholds) == 0 {
+		thresholds = DefaultExpiryWarningDays
+	}
+
+	// Most urgent first so the first crossed threshold is the one to report
+	ordered := append([]int{ExpiredThreshold}, thresholds...)
+	sort.Ints(ordered)
+
+	var alerts []ExpiryAlert
+	for _, domain := range domainList {
+		if domain.ExpiryDate.IsZero() {
+			continue
+		}
+
+		daysLeft := int(domain.ExpiryDate.Sub(now).Hours() / 24)
+		if domain.ExpiryDate.Before(now) {
+			daysLeft = -1
+		}
+
+		record := s.record(domain)
+		for i, threshold := range ordered {
+			if daysLeft > threshold {
+				continue
+			}
+			if !slices.Contains(record.Notified, threshold) {
+				alerts = append(alerts, ExpiryAlert{
+					Domain:      domain.Name,
+					Provider:    domain.Provider,
+					ExpiryDate:  domain.ExpiryDate,
+					DaysLeft:    daysLeft,
+					Threshold:   threshold,
+					AutoRenewal: domain.AutoRenewal,
+					Severity:    expirySeverity(daysLeft),
+					passed:      ordered[i+1:],
+				})
+			}
+			break
+		}
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].DaysLeft < alerts[j].DaysLeft
+	})
+	return alerts
+}
+
+// MarkNotified records that alert was delivered. Less urgent thresholds are
+// marked too so they never fire after a more urgent one.
+func (s *ExpiryNotifyState) MarkNotified(alert ExpiryAlert) {
+	record := s.Domains[alert.Domain]
+	if !record.ExpiryDate.Equal(alert.ExpiryDate) {
+		record = ExpiryNotifyRecord{ExpiryDate: alert.ExpiryDate}
+	}
+
+	for _, threshold := range append([]int{alert.Threshold}, alert.passed...) {
+		if !slices.Contains(record.Notified, threshold) {
+			record.Notified = append(record.Notified, threshold)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(record.Notified)))
+	s.Domains[alert.Domain] = record
+}
+
+// Prune forgets domains that are no longer managed and records the run time
+func (s *ExpiryNotifyState) Prune(domainList []ManagedDomain, now time.Time) {
+	managed := make(map[string]bool, len(domainList))
+	for _, domain := range domainList {
+		managed[domain.Name] = true
+	}
+	for name := range s.Domains {
+		if !managed[name] {
+			delete(s.Domains, name)
+		}
+	}
+	s.LastRun = now
+}
+
+// record returns the alert history for domain, reset if it was renewed
+func (s *ExpiryNotifyState) record(domain ManagedDomain) ExpiryNotifyRecord {
+	record, ok := s.Domains[domain.Name]
+	if !ok || !record.ExpiryDate.Equal(domain.ExpiryDate) {
+		return ExpiryNotifyRecord{ExpiryDate: domain.ExpiryDate}
+	}
+	return record
+}
+
+func expirySeverity(daysLeft int) notify.Severity {
+	switch {
+	case daysLeft <= 1:
+		return notify.SeverityCritical
+	case daysLeft <= 7:
+		return notify.SeverityWarning
+	default:
+		return notify.SeverityInfo
+	}
+}
@@ -0,0 +1,106 @@
+package domains
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestExpiryAlertsFireOncePerThreshold(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := ManagedDomain{Name: "example.com", Provider: "mock", ExpiryDate: now.Add(40 * 24 * time.Hour)}
+	thresholds := []int{30, 7, 1}
+	state := &ExpiryNotifyState{Domains: map[string]ExpiryNotifyRecord{}}
+
+	// Walk day by day towards expiry, delivering every alert
+	var fired []int
+	for day := 0; day <= 41; day++ {
+		at := now.Add(time.Duration(day) * 24 * time.Hour)
+		for _, alert := range state.Due([]ManagedDomain{domain}, thresholds, at) {
+			fired = append(fired, alert.Threshold)
+			state.MarkNotified(alert)
+		}
+	}
+
+	want := []int{30, 7, 1, ExpiredThreshold}
+	if len(fired) != len(want) {
+		t.Fatalf("fired %v, want %v", fired, want)
+	}
+	for i := range want {
+		if fired[i] != want[i] {
+			t.Fatalf("fired %v, want %v", fired, want)
+		}
+	}
+}
+
+func TestExpiryAlertsOnlyMostUrgent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := ManagedDomain{Name: "example.com", ExpiryDate: now.Add(5 * 24 * time.Hour)}
+	state := &ExpiryNotifyState{Domains: map[string]ExpiryNotifyRecord{}}
+
+	alerts := state.Due([]ManagedDomain{domain}, []int{30, 7, 1}, now)
+	if len(alerts) != 1 || alerts[0].Threshold != 7 || alerts[0].DaysLeft != 5 {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+	state.MarkNotified(alerts[0])
+
+	if alerts := state.Due([]ManagedDomain{domain}, []int{30, 7, 1}, now); len(alerts) != 0 {
+		t.Fatalf("30-day threshold fired after the 7-day one: %+v", alerts)
+	}
+	if notified := state.Domains["example.com"].Notified; !slices.Equal(notified, []int{30, 7}) {
+		t.Errorf("notified = %v, want [30 7] so the skipped threshold is recorded", notified)
+	}
+}
+
+func TestExpiryAlertsResetAfterRenewal(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domain := ManagedDomain{Name: "example.com", ExpiryDate: now.Add(20 * 24 * time.Hour)}
+	state := &ExpiryNotifyState{Domains: map[string]ExpiryNotifyRecord{}}
+
+	for _, alert := range state.Due([]ManagedDomain{domain}, []int{30}, now) {
+		state.MarkNotified(alert)
+	}
+
+	// Renewed for a year; a year later the threshold fires again
+	domain.ExpiryDate = domain.ExpiryDate.AddDate(1, 0, 0)
+	if alerts := state.Due([]ManagedDomain{domain}, []int{30}, now); len(alerts) != 0 {
+		t.Fatalf("unexpected alerts right after renewal: %+v", alerts)
+	}
+	alerts := state.Due([]ManagedDomain{domain}, []int{30}, now.AddDate(1, 0, 0))
+	if len(alerts) != 1 {
+		t.Fatalf("expected renewed domain to alert again, got %+v", alerts)
+	}
+}
+
+func TestExpiryNotifyStatePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains", "notify-state.json")
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	domainList := []ManagedDomain{
+		{Name: "example.com", ExpiryDate: now.Add(3 * 24 * time.Hour)},
+		{Name: "gone.dev", ExpiryDate: now.Add(3 * 24 * time.Hour)},
+	}
+
+	state, err := LoadExpiryNotifyState(path)
+	if err != nil {
+		t.Fatalf("LoadExpiryNotifyState on missing file: %v", err)
+	}
+	for _, alert := range state.Due(domainList, nil, now) {
+		state.MarkNotified(alert)
+	}
+	state.Prune(domainList[:1], now)
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadExpiryNotifyState(path)
+	if err != nil {
+		t.Fatalf("LoadExpiryNotifyState: %v", err)
+	}
+	if _, ok := loaded.Domains["gone.dev"]; ok {
+		t.Error("pruned domain still in state")
+	}
+	if alerts := loaded.Due(domainList[:1], nil, now); len(alerts) != 0 {
+		t.Errorf("alert fired again after reload: %+v", alerts)
+	}
+}
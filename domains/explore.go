@@ -11,6 +11,8 @@ type ExploreResult struct {
 	Available  []DomainSearchResult `json:"available"`
 	Taken      []DomainSearchResult `json:"taken"`
 	Errors     []DomainSearchResult `json:"errors"`
+
+	rankTLDs []string // Set by Rank
 }
 
 // OrganizeExploreResults categorizes domain search results into available, taken, and error categories
@@ -45,4 +47,13 @@ func OrganizeExploreResults(baseDomain string, results []DomainSearchResult) Exp
 	})
 	
 	return exploreResult
-}
\ No newline at end of file
+}
+
+// Rank orders results by ScoreDomain instead of by name, best first, with
+// tlds giving the TLD preference
+func (er *ExploreResult) Rank(tlds []string) {
+	er.rankTLDs = tlds
+	RankSearchResults(er.Available, tlds)
+	RankSearchResults(er.Taken, tlds)
+	RankSearchResults(er.Errors, tlds)
+}
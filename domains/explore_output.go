@@ -2,6 +2,7 @@ package domains
 
 import (
 	"fmt"
+	"indietool/cli/dns"
 	"indietool/cli/output"
 	"io"
 	"sort"
@@ -13,9 +14,10 @@ import (
 var ExploreTableConfig = output.TableConfig{
 	DefaultColumns: []output.Column{
 		{
-			Name:     "DOMAIN",
-			JSONPath: "domain",
-			Required: true,
+			Name:      "DOMAIN",
+			JSONPath:  "domain",
+			Formatter: DomainNameFormatter,
+			Required:  true,
 		},
 		{
 			Name:      "STATUS",
@@ -166,24 +168,32 @@ func getStatusPriority(result DomainSearchResult) int {
 // ConvertExploreResultsToTableRows converts ExploreResult to table rows for rendering
 func (er *ExploreResult) ConvertToTableRows() []map[string]interface{} {
 	// Sort results first
-	SortExploreResults(er.Results)
+	if er.rankTLDs != nil {
+		RankSearchResults(er.Results, er.rankTLDs)
+		sort.SliceStable(er.Results, func(i, j int) bool {
+			return getStatusPriority(er.Results[i]) < getStatusPriority(er.Results[j])
+		})
+	} else {
+		SortExploreResults(er.Results)
+	}
 
 	rows := make([]map[string]interface{}, 0, len(er.Results))
 
 	for _, result := range er.Results {
-		tld := extractTLD(result.Domain)
+		tld := dns.ToUnicode(extractTLD(result.Domain))
 
 		row := map[string]interface{}{
-			"domain":        result.Domain,
-			"status":        getExploreStatus(result),
-			"tld":           tld,
-			"registrar":     "",                  // Not available in DomainSearchResult
-			"cost":          0.0,                 // Not available in DomainSearchResult
-			"expiry_date":   result.ExpiryDate,   // Now available from RDAP/WHOIS
-			"creation_date": result.CreationDate, // Now available from RDAP/WHOIS
-			"last_updated":  result.LastUpdated,  // Now available from RDAP/WHOIS
-			"last_changed":  result.LastChanged,  // Now available from RDAP/WHOIS
-			"error":         result.Error,
+			"domain":         result.Domain,
+			"unicode_domain": result.UnicodeName,
+			"status":         getExploreStatus(result),
+			"tld":            tld,
+			"registrar":      "",                  // Not available in DomainSearchResult
+			"cost":           0.0,                 // Not available in DomainSearchResult
+			"expiry_date":    result.ExpiryDate,   // Now available from RDAP/WHOIS
+			"creation_date":  result.CreationDate, // Now available from RDAP/WHOIS
+			"last_updated":   result.LastUpdated,  // Now available from RDAP/WHOIS
+			"last_changed":   result.LastChanged,  // Now available from RDAP/WHOIS
+			"error":          result.Error,
 		}
 		rows = append(rows, row)
 	}
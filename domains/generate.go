@@ -0,0 +1,263 @@
+package domains
+
+import (
+	"bufio"
+	"fmt"
+	"indietool/cli/dns"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultPrefixes and DefaultSuffixes are the affixes used by
+// `domain explore --affixes` when none are given
+var (
+	DefaultPrefixes = []string{"get", "try", "use", "go", "my", "join"}
+	DefaultSuffixes = []string{"app", "hq", "labs", "hub", "kit", "ly"}
+)
+
+// DefaultMaxCandidates caps how many domains one exploration checks
+const DefaultMaxCandidates = 250
+
+// NameGeneratorOptions selects how candidate names are built from a base
+// name. With no options set only the base name itself is generated.
+type NameGeneratorOptions struct {
+	Prefixes []string // Prepended to the base name, e.g. get, try
+	Suffixes []string // Appended to the base name, e.g. app, hq
+	Words    []string // Combined with the base name in both orders
+	Combine  bool     // Also combine Words with each other in pairs
+	Plurals  bool     // Add plural forms of every name
+	Hyphens  bool     // Add hyphenated forms of multi-word names
+
+	// MaxCandidates caps the number of domains after crossing names with
+	// TLDs; the best scoring are kept (default DefaultMaxCandidates)
+	MaxCandidates int
+}
+
+// NameCandidate is one generated domain to check
+type NameCandidate struct {
+	Domain string `json:"domain"`
+	Name   string `json:"name"`
+	TLD    string `json:"tld"`
+	Score  int    `json:"score"` // Lower is better, see ScoreDomain
+}
+
+// GenerateNames builds the candidate names (without TLD) for base, in the
+// order they were generated and without duplicates
+func GenerateNames(base string, options NameGeneratorOptions) []string {
+	base = normalizeWord(base)
+
+	// Names are kept as their words so hyphenated forms and plurals can be
+	// built from them
+	var combos [][]string
+	seen := make(map[string]bool)
+	add := func(parts ...string) {
+		key := strings.Join(parts, " ")
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		combos = append(combos, parts)
+	}
+
+	add(base)
+	for _, prefix := range normalizeWords(options.Prefixes) {
+		add(prefix, base)
+	}
+	for _, suffix := range normalizeWords(options.Suffixes) {
+		add(base, suffix)
+	}
+	words := normalizeWords(options.Words)
+	for _, word := range words {
+		if word != base {
+			add(base, word)
+			add(word, base)
+		}
+	}
+	if options.Combine {
+		for _, first := range words {
+			for _, second := range words {
+				if first != second {
+					add(first, second)
+				}
+			}
+		}
+	}
+	if options.Plurals {
+		for _, parts := range combos {
+			last := parts[len(parts)-1]
+			if plural := pluralize(last); plural != last {
+				add(append(append([]string{}, parts[:len(parts)-1]...), plural)...)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(combos))
+	seenNames := make(map[string]bool)
+	appendName := func(name string) {
+		if !seenNames[name] && validLabel(name) {
+			seenNames[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, parts := range combos {
+		appendName(strings.Join(parts, ""))
+		if options.Hyphens && len(parts) > 1 {
+			appendName(strings.Join(parts, "-"))
+		}
+	}
+	return names
+}
+
+// GenerateCandidates crosses the names generated for base with tlds, sorted
+// best first and capped at options.MaxCandidates. It also returns how many
+// candidates were dropped by the cap.
+func GenerateCandidates(base string, tlds []string, options NameGeneratorOptions) ([]NameCandidate, int) {
+	limit := options.MaxCandidates
+	if limit <= 0 {
+		limit = DefaultMaxCandidates
+	}
+
+	names := GenerateNames(base, options)
+	candidates := make([]NameCandidate, 0, len(names)*len(tlds))
+	for _, name := range names {
+		for _, tld := range tlds {
+			domain := name + "." + tld
+			candidates = append(candidates, NameCandidate{
+				Domain: domain,
+				Name:   name,
+				TLD:    tld,
+				Score:  ScoreDomain(domain, tlds),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score < candidates[j].Score })
+	if len(candidates) > limit {
+		return candidates[:limit], len(candidates) - limit
+	}
+	return candidates, 0
+}
+
+// ScoreDomain ranks a domain for the explore results: shorter names and TLDs
+// earlier in tlds (the preference order) score lower, which is better. Each
+// hyphen counts as two extra characters.
+func ScoreDomain(domain string, tlds []string) int {
+	// Internationalized names count the characters people see
+	name, tld, found := strings.Cut(dns.ToUnicode(domain), ".")
+	if !found {
+		return utf8.RuneCountInString(name)
+	}
+	if ascii, err := dns.ToASCII(tld); err == nil {
+		tld = ascii
+	}
+
+	preference := len(tlds)
+	for i, t := range tlds {
+		if strings.EqualFold(t, tld) {
+			preference = i
+			break
+		}
+	}
+	return utf8.RuneCountInString(name) + 2*strings.Count(name, "-") + preference
+}
+
+// RankSearchResults orders results by ScoreDomain, ties broken by domain
+func RankSearchResults(results []DomainSearchResult, tlds []string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		si, sj := ScoreDomain(results[i].Domain, tlds), ScoreDomain(results[j].Domain, tlds)
+		if si != sj {
+			return si < sj
+		}
+		return results[i].Domain < results[j].Domain
+	})
+}
+
+// ParseWordList parses a word list (comma-separated or @filename, one word
+// per line). Surrounding hyphens are dropped so "get-" and "-app" can be
+// written as they would appear.
+func ParseWordList(input string) ([]string, error) {
+	var raw []string
+	if strings.HasPrefix(input, "@") {
+		filename := input[1:]
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file %s: %v", filename, err)
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				raw = append(raw, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading file %s: %v", filename, err)
+		}
+	} else {
+		raw = strings.Split(input, ",")
+	}
+
+	words := normalizeWords(raw)
+	for _, word := range words {
+		if !validLabel(word) {
+			return nil, fmt.Errorf("%q can't be used in a domain name", word)
+		}
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no words found in %s", input)
+	}
+	return words, nil
+}
+
+func normalizeWord(word string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(word)), "-")
+}
+
+func normalizeWords(words []string) []string {
+	result := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = normalizeWord(word); word != "" {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+// validLabel reports whether name can be the first label of a domain.
+// Internationalized names are checked in their A-label form.
+func validLabel(name string) bool {
+	ascii, err := dns.ToASCII(name)
+	if err != nil || strings.Contains(ascii, ".") {
+		return false
+	}
+	name = ascii
+	if name == "" || len(name) > 63 || strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// pluralize applies the common English plural rules; words already ending
+// in s are left alone
+func pluralize(word string) string {
+	switch {
+	case word == "" || strings.HasSuffix(word, "s"):
+		return word
+	case strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	default:
+		return word + "s"
+	}
+}
@@ -0,0 +1,111 @@
+package domains
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		options NameGeneratorOptions
+		want    []string
+	}{
+		{
+			name: "base only",
+			want: []string{"kopi"},
+		},
+		{
+			name:    "affixes with hyphens",
+			options: NameGeneratorOptions{Prefixes: []string{"get-"}, Suffixes: []string{"-hq"}, Hyphens: true},
+			want:    []string{"kopi", "getkopi", "get-kopi", "kopihq", "kopi-hq"},
+		},
+		{
+			name:    "words",
+			options: NameGeneratorOptions{Words: []string{"Shop", "kopi"}},
+			want:    []string{"kopi", "kopishop", "shopkopi"},
+		},
+		{
+			name:    "combine",
+			options: NameGeneratorOptions{Words: []string{"cat", "box"}, Combine: true},
+			want:    []string{"kopi", "kopicat", "catkopi", "kopibox", "boxkopi", "catbox", "boxcat"},
+		},
+		{
+			name:    "plurals",
+			options: NameGeneratorOptions{Suffixes: []string{"box", "party", "day", "news"}, Plurals: true},
+			want: []string{"kopi", "kopibox", "kopiparty", "kopiday", "kopinews",
+				"kopis", "kopiboxes", "kopiparties", "kopidays"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateNames("kopi", tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCandidates(t *testing.T) {
+	tlds := []string{"com", "io", "dev"}
+	options := NameGeneratorOptions{Prefixes: []string{"get"}, Hyphens: true, MaxCandidates: 4}
+
+	candidates, skipped := GenerateCandidates("kopi", tlds, options)
+	if skipped != 5 {
+		t.Errorf("skipped = %d, want 5 of 9", skipped)
+	}
+
+	var got []string
+	for _, candidate := range candidates {
+		got = append(got, candidate.Domain)
+	}
+	want := []string{"kopi.com", "kopi.io", "kopi.dev", "getkopi.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %v, want %v", got, want)
+	}
+}
+
+func TestRankSearchResults(t *testing.T) {
+	tlds := []string{"com", "io"}
+	results := []DomainSearchResult{
+		{Domain: "get-kopi.com"},
+		{Domain: "kopi.xyz"},
+		{Domain: "getkopi.io"},
+		{Domain: "kopi.io"},
+		{Domain: "getkopi.com"},
+	}
+
+	RankSearchResults(results, tlds)
+
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Domain)
+	}
+	want := []string{"kopi.io", "kopi.xyz", "getkopi.com", "getkopi.io", "get-kopi.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked = %v, want %v", got, want)
+	}
+}
+
+func TestParseWordList(t *testing.T) {
+	words, err := ParseWordList(" get-, Try ,, -app")
+	if err != nil || !reflect.DeepEqual(words, []string{"get", "try", "app"}) {
+		t.Errorf("ParseWordList = %v, %v", words, err)
+	}
+
+	if _, err := ParseWordList("good,bad word"); err == nil {
+		t.Error("expected an error for a word with a space")
+	}
+
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("# nouns\ncat\n\nshop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	words, err = ParseWordList("@" + path)
+	if err != nil || !reflect.DeepEqual(words, []string{"cat", "shop"}) {
+		t.Errorf("ParseWordList(@file) = %v, %v", words, err)
+	}
+}
@@ -0,0 +1,87 @@
+package domains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Inventory is the local cache of domains pulled from all registrars. It is
+// written by Manager.SyncDomains and read by Manager.ListManagedDomains, so
+// listing works instantly and offline.
+type Inventory struct {
+	Domains     []ManagedDomain       `json:"domains"`
+	SyncResults map[string]SyncResult `json:"sync_results"`
+	LastSynced  time.Time             `json:"last_synced"`
+}
+
+// LoadInventory reads the inventory at path. A missing file yields an empty
+// inventory so first runs don't need special casing.
+func LoadInventory(path string) (*Inventory, error) {
+	inventory := &Inventory{SyncResults: make(map[string]SyncResult)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return inventory, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domain inventory: %w", err)
+	}
+
+	if err := json.Unmarshal(data, inventory); err != nil {
+		return nil, fmt.Errorf("failed to parse domain inventory %s: %w", path, err)
+	}
+	if inventory.SyncResults == nil {
+		inventory.SyncResults = make(map[string]SyncResult)
+	}
+
+	return inventory, nil
+}
+
+// Save writes the inventory to path, creating parent directories as needed.
+// The file is replaced atomically so a crash never leaves a truncated cache.
+func (inv *Inventory) Save(path string) error {
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode domain inventory: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create inventory directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write domain inventory: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write domain inventory: %w", err)
+	}
+
+	return nil
+}
+
+// HasSynced reports whether a sync of provider has been attempted
+func (inv *Inventory) HasSynced(provider string) bool {
+	_, ok := inv.SyncResults[provider]
+	return ok
+}
+
+// replaceProvider swaps all cached domains of provider for domainList
+func (inv *Inventory) replaceProvider(provider string, domainList []ManagedDomain) {
+	kept := make([]ManagedDomain, 0, len(inv.Domains)+len(domainList))
+	for _, domain := range inv.Domains {
+		if domain.Provider != provider {
+			kept = append(kept, domain)
+		}
+	}
+	for _, domain := range domainList {
+		domain.Provider = provider
+		kept = append(kept, domain)
+	}
+	inv.Domains = kept
+}
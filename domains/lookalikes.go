@@ -0,0 +1,406 @@
+package domains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/indietool/notify"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// LookalikeKind is how a lookalike domain was derived from the original
+type LookalikeKind string
+
+const (
+	LookalikeOmission      LookalikeKind = "omission"      // A character left out: kopi → kpi
+	LookalikeTransposition LookalikeKind = "transposition" // Neighbouring characters swapped: kopi → okpi
+	LookalikeHomoglyph     LookalikeKind = "homoglyph"     // A character swapped for one that looks alike: kopi → k0pi, kоpi (Cyrillic о)
+	LookalikeBitsquatting  LookalikeKind = "bitsquatting"  // One bit flipped in a character: kopi → copi
+	LookalikeTLDSwap       LookalikeKind = "tld-swap"      // Same name under another TLD: kopi.com → kopi.co
+	LookalikeHyphenation   LookalikeKind = "hyphenation"   // A hyphen added or removed: kopi → ko-pi
+)
+
+// LookalikeKinds lists every kind of lookalike, in the order they are
+// generated
+var LookalikeKinds = []LookalikeKind{
+	LookalikeOmission,
+	LookalikeTransposition,
+	LookalikeHomoglyph,
+	LookalikeBitsquatting,
+	LookalikeTLDSwap,
+	LookalikeHyphenation,
+}
+
+// ParseLookalikeKinds validates kind names given on the command line
+func ParseLookalikeKinds(names []string) ([]LookalikeKind, error) {
+	kinds := make([]LookalikeKind, 0, len(names))
+	for _, name := range names {
+		kind := LookalikeKind(strings.ToLower(strings.TrimSpace(name)))
+		known := false
+		for _, k := range LookalikeKinds {
+			if k == kind {
+				known = true
+				break
+			}
+		}
+		if !known {
+			valid := make([]string, len(LookalikeKinds))
+			for i, k := range LookalikeKinds {
+				valid[i] = string(k)
+			}
+			return nil, fmt.Errorf("unknown lookalike kind %q (valid: %s)", name, strings.Join(valid, ", "))
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// asciiHomoglyphs are ASCII sequences read as one another at a glance
+var asciiHomoglyphs = []struct{ from, to string }{
+	{"o", "0"}, {"0", "o"},
+	{"l", "1"}, {"l", "i"}, {"i", "1"}, {"i", "l"}, {"1", "l"}, {"1", "i"},
+	{"m", "rn"}, {"m", "nn"}, {"rn", "m"},
+	{"w", "vv"}, {"vv", "w"},
+	{"d", "cl"}, {"cl", "d"},
+	{"g", "q"}, {"q", "g"},
+	{"u", "v"}, {"v", "u"},
+}
+
+// Lookalike is a domain that could be mistaken for another
+type Lookalike struct {
+	Domain string        `json:"domain"` // A-label (punycode) form
+	Kind   LookalikeKind `json:"kind"`
+}
+
+// LookalikeOptions tunes GenerateLookalikes
+type LookalikeOptions struct {
+	Kinds []LookalikeKind // Kinds to generate; all when empty
+	TLDs  []string        // TLDs for tld-swap lookalikes; PopularTLDs when empty
+}
+
+// GenerateLookalikes derives typosquatting and homograph candidates from the
+// registrable part of domain, e.g. kopi.co.uk for www.kopi.co.uk. The
+// domain itself and duplicates are left out; each candidate keeps the first
+// kind that produced it.
+func GenerateLookalikes(domain string, options LookalikeOptions) ([]Lookalike, error) {
+	ascii, err := dns.ToASCII(domain)
+	if err != nil {
+		return nil, err
+	}
+	registrable, err := publicsuffix.EffectiveTLDPlusOne(ascii)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a registrable domain: %w", domain, err)
+	}
+	suffix, _ := publicsuffix.PublicSuffix(registrable)
+	label := strings.TrimSuffix(registrable, "."+suffix)
+
+	kinds := options.Kinds
+	if len(kinds) == 0 {
+		kinds = LookalikeKinds
+	}
+
+	seen := map[string]bool{registrable: true}
+	var lookalikes []Lookalike
+	add := func(kind LookalikeKind, candidateLabel, candidateSuffix string) {
+		candidate, ok := lookalikeDomain(candidateLabel, candidateSuffix)
+		if !ok || seen[candidate] {
+			return
+		}
+		seen[candidate] = true
+		lookalikes = append(lookalikes, Lookalike{Domain: candidate, Kind: kind})
+	}
+
+	// Typos are made in the name people see, so IDNs are permuted in
+	// Unicode; bit flips happen on the wire, in the A-label
+	runes := []rune(dns.ToUnicode(label))
+	for _, kind := range kinds {
+		switch kind {
+		case LookalikeOmission:
+			if len(runes) > 1 {
+				for i := range runes {
+					add(kind, string(runes[:i])+string(runes[i+1:]), suffix)
+				}
+			}
+		case LookalikeTransposition:
+			for i := 0; i+1 < len(runes); i++ {
+				if runes[i] == runes[i+1] {
+					continue
+				}
+				swapped := append([]rune(nil), runes...)
+				swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+				add(kind, string(swapped), suffix)
+			}
+		case LookalikeHomoglyph:
+			for i := range runes {
+				rest := string(runes[i:])
+				for _, swap := range asciiHomoglyphs {
+					if strings.HasPrefix(rest, swap.from) {
+						add(kind, string(runes[:i])+swap.to+rest[len(swap.from):], suffix)
+					}
+				}
+				for _, r := range dns.Homoglyphs(runes[i]) {
+					add(kind, string(runes[:i])+string(r)+string(runes[i+1:]), suffix)
+				}
+			}
+		case LookalikeBitsquatting:
+			for i := 0; i < len(label); i++ {
+				for bit := 0; bit < 8; bit++ {
+					flipped := label[i] ^ (1 << bit)
+					if isLDHByte(flipped) {
+						add(kind, label[:i]+string(flipped)+label[i+1:], suffix)
+					}
+				}
+			}
+		case LookalikeTLDSwap:
+			tlds := options.TLDs
+			if len(tlds) == 0 {
+				tlds = PopularTLDs
+			}
+			for _, tld := range tlds {
+				tld, err := dns.ToASCII(strings.TrimPrefix(tld, "."))
+				if err == nil && tld != "" && tld != suffix {
+					add(kind, label, tld)
+				}
+			}
+		case LookalikeHyphenation:
+			for i := 1; i < len(runes); i++ {
+				if runes[i-1] != '-' && runes[i] != '-' {
+					add(kind, string(runes[:i])+"-"+string(runes[i:]), suffix)
+				}
+			}
+			if strings.Contains(label, "-") {
+				add(kind, strings.ReplaceAll(string(runes), "-", ""), suffix)
+			}
+		}
+	}
+
+	sort.SliceStable(lookalikes, func(i, j int) bool {
+		return kindOrder(lookalikes[i].Kind) < kindOrder(lookalikes[j].Kind)
+	})
+	return lookalikes, nil
+}
+
+// lookalikeDomain joins a candidate label and suffix into an A-label domain,
+// reporting false when the result isn't a valid hostname
+func lookalikeDomain(label, suffix string) (string, bool) {
+	if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+		return "", false
+	}
+	ascii, err := dns.ToASCII(label)
+	if err != nil || len(ascii) > 63 {
+		return "", false
+	}
+	for i := 0; i < len(ascii); i++ {
+		if !isLDHByte(ascii[i]) {
+			return "", false
+		}
+	}
+	// "ab--" is reserved for encodings like punycode's xn--
+	if len(ascii) >= 4 && ascii[2:4] == "--" && !strings.HasPrefix(ascii, "xn--") {
+		return "", false
+	}
+	return ascii + "." + suffix, true
+}
+
+// isLDHByte reports whether c may appear in a lowercase hostname label
+func isLDHByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-'
+}
+
+// kindOrder is the position of kind in LookalikeKinds
+func kindOrder(kind LookalikeKind) int {
+	for i, k := range LookalikeKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(LookalikeKinds)
+}
+
+// LookalikeSighting is a lookalike found registered
+type LookalikeSighting struct {
+	Domain       string        `json:"domain"`
+	Kind         LookalikeKind `json:"kind"`
+	FirstSeen    time.Time     `json:"first_seen"`
+	CreationDate *time.Time    `json:"creation_date,omitempty"`
+	Nameservers  []string      `json:"nameservers,omitempty"`
+	Baseline     bool          `json:"baseline,omitempty"`   // Registered when first checked, so never seen available
+	AlertedAt    *time.Time    `json:"alerted_at,omitempty"` // When the alert went out
+}
+
+// AlertDue reports whether the sighting is new and nobody was told yet
+func (s *LookalikeSighting) AlertDue() bool {
+	return !s.Baseline && s.AlertedAt == nil
+}
+
+// MarkAlerted records that the alert was delivered
+func (s *LookalikeSighting) MarkAlerted(now time.Time) {
+	s.AlertedAt = &now
+}
+
+// Notification renders the alert that a lookalike of brand was registered,
+// for delivery through notify sinks
+func (s *LookalikeSighting) Notification(brand string) notify.Notification {
+	body := fmt.Sprintf("%s (%s of %s) has been registered.", dns.DisplayName(s.Domain), s.Kind, dns.DisplayName(brand))
+	if s.CreationDate != nil {
+		body += fmt.Sprintf(" Created %s.", s.CreationDate.Format("2006-01-02"))
+	}
+	if len(s.Nameservers) > 0 {
+		body += fmt.Sprintf(" Nameservers: %s.", strings.Join(s.Nameservers, ", "))
+	}
+
+	return notify.Notification{
+		Event:    "domain.lookalike.registered",
+		Title:    fmt.Sprintf("Lookalike of %s registered: %s", dns.DisplayName(brand), dns.DisplayName(s.Domain)),
+		Body:     body,
+		Severity: notify.SeverityWarning,
+		Data: map[string]any{
+			"domain":        brand,
+			"lookalike":     s.Domain,
+			"kind":          s.Kind,
+			"creation_date": s.CreationDate,
+			"nameservers":   s.Nameservers,
+		},
+	}
+}
+
+// LookalikeWatch is what the monitor knows about the lookalikes of one
+// domain
+type LookalikeWatch struct {
+	Domain     string                        `json:"domain"`
+	CheckedAt  *time.Time                    `json:"checked_at,omitempty"`
+	Registered map[string]*LookalikeSighting `json:"registered"`
+	Available  map[string]bool               `json:"available,omitempty"` // Lookalikes last seen available
+	Unknown    map[string]bool               `json:"unknown,omitempty"`   // Lookalikes no lookup has answered for yet
+}
+
+// Update records which lookalikes the search results found registered and
+// returns the ones that were confirmed available before. Lookalikes found
+// registered without having been seen available, such as on the first
+// check or after failed lookups, are kept as baseline sightings and never
+// alerted on. Failed lookups keep what was known, or mark the lookalike
+// unknown if nothing was. A check where every lookup failed doesn't count,
+// so it can't leave an empty baseline.
+func (w *LookalikeWatch) Update(lookalikes []Lookalike, results []DomainSearchResult, now time.Time) []*LookalikeSighting {
+	kinds := make(map[string]LookalikeKind, len(lookalikes))
+	for _, lookalike := range lookalikes {
+		kinds[lookalike.Domain] = lookalike.Kind
+	}
+
+	var sightings []*LookalikeSighting
+	for _, result := range results {
+		switch ClassifyWatchStatus(result) {
+		case WatchUnknown:
+			if _, registered := w.Registered[result.Domain]; !registered && !w.Available[result.Domain] {
+				w.Unknown[result.Domain] = true
+			}
+			continue
+		case WatchAvailable:
+			w.CheckedAt = &now
+			delete(w.Registered, result.Domain)
+			delete(w.Unknown, result.Domain)
+			w.Available[result.Domain] = true
+			continue
+		}
+		w.CheckedAt = &now
+		delete(w.Unknown, result.Domain)
+
+		sighting, ok := w.Registered[result.Domain]
+		if !ok {
+			wasAvailable := w.Available[result.Domain]
+			sighting = &LookalikeSighting{
+				Domain:    result.Domain,
+				Kind:      kinds[result.Domain],
+				FirstSeen: now,
+				Baseline:  !wasAvailable,
+			}
+			w.Registered[result.Domain] = sighting
+			delete(w.Available, result.Domain)
+			if wasAvailable {
+				sightings = append(sightings, sighting)
+			}
+		}
+		if result.CreationDate != nil {
+			sighting.CreationDate = result.CreationDate
+		}
+		if len(result.Nameservers) > 0 {
+			sighting.Nameservers = result.Nameservers
+		}
+	}
+	return sightings
+}
+
+// LookalikeMonitor holds the monitored domains and their registered
+// lookalikes
+type LookalikeMonitor struct {
+	Domains map[string]*LookalikeWatch `json:"domains"`
+}
+
+// LoadLookalikeMonitor reads the monitor state at path. A missing file
+// yields an empty monitor.
+func LoadLookalikeMonitor(path string) (*LookalikeMonitor, error) {
+	monitor := &LookalikeMonitor{Domains: make(map[string]*LookalikeWatch)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return monitor, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lookalike monitor: %w", err)
+	}
+
+	if err := json.Unmarshal(data, monitor); err != nil {
+		return nil, fmt.Errorf("failed to parse lookalike monitor %s: %w", path, err)
+	}
+	if monitor.Domains == nil {
+		monitor.Domains = make(map[string]*LookalikeWatch)
+	}
+	for _, watch := range monitor.Domains {
+		if watch.Registered == nil {
+			watch.Registered = make(map[string]*LookalikeSighting)
+		}
+		if watch.Available == nil {
+			watch.Available = make(map[string]bool)
+		}
+		if watch.Unknown == nil {
+			watch.Unknown = make(map[string]bool)
+		}
+	}
+	return monitor, nil
+}
+
+// Save writes the monitor state to path, creating parent directories as
+// needed
+func (m *LookalikeMonitor) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lookalike monitor: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create lookalike monitor directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Watch returns the state for domain, starting to monitor it if needed
+func (m *LookalikeMonitor) Watch(domain string) *LookalikeWatch {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	watch, ok := m.Domains[domain]
+	if !ok {
+		watch = &LookalikeWatch{
+			Domain:     domain,
+			Registered: make(map[string]*LookalikeSighting),
+			Available:  make(map[string]bool),
+			Unknown:    make(map[string]bool),
+		}
+		m.Domains[domain] = watch
+	}
+	return watch
+}
@@ -0,0 +1,217 @@
+package domains
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateLookalikes(t *testing.T) {
+	lookalikes, err := GenerateLookalikes("www.kopi.com", LookalikeOptions{TLDs: []string{"com", ".net", "io"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds := make(map[string]LookalikeKind)
+	for _, lookalike := range lookalikes {
+		if _, dup := kinds[lookalike.Domain]; dup {
+			t.Errorf("%s generated twice", lookalike.Domain)
+		}
+		kinds[lookalike.Domain] = lookalike.Kind
+	}
+
+	want := map[string]LookalikeKind{
+		"kpi.com":         LookalikeOmission,
+		"okpi.com":        LookalikeTransposition,
+		"k0pi.com":        LookalikeHomoglyph,
+		"kop1.com":        LookalikeHomoglyph,
+		"xn--kpi-sed.com": LookalikeHomoglyph, // Cyrillic о
+		"copi.com":        LookalikeBitsquatting,
+		"kopi.net":        LookalikeTLDSwap,
+		"kopi.io":         LookalikeTLDSwap,
+		"ko-pi.com":       LookalikeHyphenation,
+	}
+	for domain, kind := range want {
+		if got, ok := kinds[domain]; !ok || got != kind {
+			t.Errorf("%s = %q, want %q", domain, got, kind)
+		}
+	}
+	for _, unwanted := range []string{"kopi.com", "www.kopi.com", "-kopi.com", "kopi-.com"} {
+		if _, ok := kinds[unwanted]; ok {
+			t.Errorf("%s should not be a lookalike", unwanted)
+		}
+	}
+
+	// Kinds come out in canonical order whatever order was asked for
+	lookalikes, err = GenerateLookalikes("kopi.co.uk", LookalikeOptions{Kinds: []LookalikeKind{LookalikeHyphenation, LookalikeOmission}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lookalikes) == 0 || lookalikes[0].Kind != LookalikeOmission || lookalikes[len(lookalikes)-1].Kind != LookalikeHyphenation {
+		t.Fatalf("lookalikes = %v, want omissions then hyphenations", lookalikes)
+	}
+	for _, lookalike := range lookalikes {
+		if !strings.HasSuffix(lookalike.Domain, ".co.uk") {
+			t.Errorf("%s lost the public suffix", lookalike.Domain)
+		}
+	}
+
+	// Hyphens are also taken out
+	lookalikes, _ = GenerateLookalikes("my-app.dev", LookalikeOptions{Kinds: []LookalikeKind{LookalikeHyphenation}})
+	found := false
+	for _, lookalike := range lookalikes {
+		found = found || lookalike.Domain == "myapp.dev"
+	}
+	if !found {
+		t.Errorf("lookalikes = %v, want myapp.dev", lookalikes)
+	}
+
+	if _, err := GenerateLookalikes("com", LookalikeOptions{}); err == nil {
+		t.Error("a bare TLD should not have lookalikes")
+	}
+}
+
+func TestGenerateLookalikesIDN(t *testing.T) {
+	lookalikes, err := GenerateLookalikes("bücher.de", LookalikeOptions{Kinds: []LookalikeKind{LookalikeOmission}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lookalike := range lookalikes {
+		if lookalike.Domain == "bcher.de" {
+			return
+		}
+	}
+	t.Errorf("lookalikes = %v, want bcher.de from dropping the ü", lookalikes)
+}
+
+func TestParseLookalikeKinds(t *testing.T) {
+	kinds, err := ParseLookalikeKinds([]string{"Homoglyph", " tld-swap"})
+	if err != nil || len(kinds) != 2 || kinds[0] != LookalikeHomoglyph || kinds[1] != LookalikeTLDSwap {
+		t.Errorf("kinds = %v, err = %v", kinds, err)
+	}
+	if _, err := ParseLookalikeKinds([]string{"typo"}); err == nil || !strings.Contains(err.Error(), "omission") {
+		t.Errorf("err = %v, want the valid kinds listed", err)
+	}
+}
+
+func TestLookalikeWatchUpdate(t *testing.T) {
+	lookalikes := []Lookalike{
+		{Domain: "kpi.com", Kind: LookalikeOmission},
+		{Domain: "k0pi.com", Kind: LookalikeHomoglyph},
+		{Domain: "kopi.net", Kind: LookalikeTLDSwap},
+	}
+	created := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "lookalikes.json")
+	monitor, err := LoadLookalikeMonitor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watch := monitor.Watch("Kopi.com.")
+
+	// The first check is the baseline
+	now := time.Now()
+	sightings := watch.Update(lookalikes, []DomainSearchResult{
+		{Domain: "kpi.com", Status: "active", CreationDate: &created, Nameservers: []string{"ns1.parked.example"}},
+		availableResult("k0pi.com"),
+		availableResult("kopi.net"),
+	}, now)
+	if len(sightings) != 0 {
+		t.Fatalf("baseline alerted on %v", sightings)
+	}
+	if s := watch.Registered["kpi.com"]; s == nil || !s.Baseline || s.AlertDue() || s.Kind != LookalikeOmission || len(s.Nameservers) != 1 {
+		t.Fatalf("kpi.com = %+v, want a baseline sighting", s)
+	}
+
+	// New registrations alert; failed lookups change nothing
+	sightings = watch.Update(lookalikes, []DomainSearchResult{
+		{Domain: "kpi.com", Error: "timeout"},
+		{Domain: "k0pi.com", Status: "client transfer prohibited"},
+		availableResult("kopi.net"),
+	}, now.Add(time.Hour))
+	if len(sightings) != 1 || sightings[0].Domain != "k0pi.com" || !sightings[0].AlertDue() {
+		t.Fatalf("sightings = %+v, want k0pi.com", sightings)
+	}
+	if _, ok := watch.Registered["kpi.com"]; !ok {
+		t.Error("a failed lookup forgot kpi.com")
+	}
+	sightings[0].MarkAlerted(now)
+	n := sightings[0].Notification("kopi.com")
+	if n.Event != "domain.lookalike.registered" || !strings.Contains(n.Title, "k0pi.com") {
+		t.Errorf("notification = %+v", n)
+	}
+
+	// Survives a reload
+	if err := monitor.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	monitor, err = LoadLookalikeMonitor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watch = monitor.Watch("kopi.com")
+	if s := watch.Registered["k0pi.com"]; s == nil || s.AlertDue() {
+		t.Fatalf("k0pi.com = %+v, want alerted", s)
+	}
+
+	// Dropped and registered again alerts again
+	watch.Update(lookalikes, []DomainSearchResult{availableResult("k0pi.com")}, now.Add(2*time.Hour))
+	sightings = watch.Update(lookalikes, []DomainSearchResult{{Domain: "k0pi.com", Status: "active"}}, now.Add(3*time.Hour))
+	if len(sightings) != 1 || !sightings[0].AlertDue() {
+		t.Errorf("sightings = %+v, want k0pi.com again", sightings)
+	}
+}
+
+func TestLookalikeWatchFailedFirstCheck(t *testing.T) {
+	lookalikes := []Lookalike{{Domain: "kpi.com", Kind: LookalikeOmission}}
+	watch := (&LookalikeMonitor{Domains: make(map[string]*LookalikeWatch)}).Watch("kopi.com")
+
+	now := time.Now()
+	watch.Update(lookalikes, []DomainSearchResult{{Domain: "kpi.com", Error: "no network"}}, now)
+	if watch.CheckedAt != nil {
+		t.Fatal("a check where every lookup failed became the baseline")
+	}
+	if !watch.Unknown["kpi.com"] {
+		t.Error("failed baseline lookup not recorded as unknown")
+	}
+	watch.Update(lookalikes, []DomainSearchResult{{Domain: "kpi.com", Status: "active"}}, now)
+	if s := watch.Registered["kpi.com"]; s == nil || !s.Baseline {
+		t.Errorf("kpi.com = %+v, want part of the baseline", s)
+	}
+	if watch.Unknown["kpi.com"] {
+		t.Error("kpi.com still unknown after a successful lookup")
+	}
+}
+
+func TestLookalikeWatchFailedBaselineLookup(t *testing.T) {
+	lookalikes := []Lookalike{
+		{Domain: "kpi.com", Kind: LookalikeOmission},
+		{Domain: "k0pi.com", Kind: LookalikeHomoglyph},
+	}
+	watch := (&LookalikeMonitor{Domains: make(map[string]*LookalikeWatch)}).Watch("kopi.com")
+
+	// k0pi.com's lookup fails on the first check, so its state is unknown
+	now := time.Now()
+	watch.Update(lookalikes, []DomainSearchResult{availableResult("kpi.com"), {Domain: "k0pi.com", Error: "timeout"}}, now)
+	if !watch.Unknown["k0pi.com"] || !watch.Available["kpi.com"] {
+		t.Fatalf("unknown = %v, available = %v; want k0pi.com unknown and kpi.com available", watch.Unknown, watch.Available)
+	}
+
+	// Found registered later, it may have been all along: no alert
+	sightings := watch.Update(lookalikes, []DomainSearchResult{availableResult("kpi.com"), {Domain: "k0pi.com", Status: "active"}}, now.Add(time.Hour))
+	if len(sightings) != 0 {
+		t.Fatalf("alerted on %+v after a failed baseline lookup", sightings)
+	}
+	if s := watch.Registered["k0pi.com"]; s == nil || !s.Baseline || s.AlertDue() {
+		t.Errorf("k0pi.com = %+v, want a baseline sighting", s)
+	}
+
+	// kpi.com was confirmed available, so its registration alerts
+	sightings = watch.Update(lookalikes, []DomainSearchResult{{Domain: "kpi.com", Status: "active"}}, now.Add(2*time.Hour))
+	if len(sightings) != 1 || sightings[0].Domain != "kpi.com" || !sightings[0].AlertDue() {
+		t.Errorf("sightings = %+v, want kpi.com", sightings)
+	}
+	if watch.Available["kpi.com"] {
+		t.Error("kpi.com still recorded as available")
+	}
+}
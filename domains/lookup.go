@@ -0,0 +1,477 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"indietool/cli/dns"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openrdap/rdap"
+	"github.com/openrdap/rdap/bootstrap"
+)
+
+// Defaults for LookupOptions
+const (
+	DefaultLookupWorkers   = 16
+	DefaultLookupPerServer = 4
+	DefaultServerInterval  = 100 * time.Millisecond
+	DefaultLookupRetries   = 2
+	DefaultRetryDelay      = time.Second
+	DefaultLookupTimeout   = 15 * time.Second
+
+	// maxRetryAfter caps how long a server's Retry-After can hold up a lookup
+	maxRetryAfter = 30 * time.Second
+)
+
+// LookupOptions tunes a LookupEngine. Zero values use the defaults above.
+type LookupOptions struct {
+	Workers        int           // Lookups running at once
+	PerServer      int           // Lookups running at once against one RDAP server
+	ServerInterval time.Duration // Minimum time between requests to one RDAP server
+	Retries        int           // Retries of transient failures; negative for none
+	RetryDelay     time.Duration // Delay before the first retry, doubled for each one and jittered
+	Timeout        time.Duration // Timeout of a single request
+
+	// Cache, when set, answers lookups checked recently enough and records
+	// new results. Cached results older than MaxAge aren't used, if set.
+	Cache  *ResultCache
+	MaxAge time.Duration
+
+	// BootstrapCacheDir, when set, keeps the IANA RDAP bootstrap files on
+	// disk for DefaultBootstrapTTL instead of fetching them on every run
+	BootstrapCacheDir string
+
+	// Progress is called after each domain is checked. Calls are serialized,
+	// so the callback doesn't need to be safe for concurrent use.
+	Progress func(LookupProgress)
+}
+
+// LookupProgress reports one finished lookup
+type LookupProgress struct {
+	Done   int
+	Total  int
+	Result DomainSearchResult
+}
+
+// LookupEngine checks domain availability with a bounded worker pool. RDAP
+// requests are limited per server, found through the IANA bootstrap
+// registry, so large searches don't get throttled by registries. Transient
+// failures are retried with jittered backoff, and WHOIS is used when RDAP
+// has no answer.
+type LookupEngine struct {
+	options LookupOptions
+	client  *rdap.Client
+
+	// bootstrap.Client isn't safe for concurrent use
+	bootstrapMu sync.Mutex
+	bootstrap   *bootstrap.Client
+
+	limitersMu sync.Mutex
+	limiters   map[string]*serverLimiter
+
+	// Lookup steps, replaced in tests
+	serverFor  func(ctx context.Context, domain string) (*url.URL, error)
+	queryRDAP  func(ctx context.Context, server *url.URL, domain string) (DomainSearchResult, error)
+	queryWHOIS func(ctx context.Context, domain string) DomainSearchResult
+}
+
+// NewLookupEngine creates a lookup engine. Engines cache the bootstrap
+// registry, so reuse one for related searches.
+func NewLookupEngine(options LookupOptions) *LookupEngine {
+	if options.Workers <= 0 {
+		options.Workers = DefaultLookupWorkers
+	}
+	if options.PerServer <= 0 {
+		options.PerServer = DefaultLookupPerServer
+	}
+	if options.ServerInterval <= 0 {
+		options.ServerInterval = DefaultServerInterval
+	}
+	if options.Retries == 0 {
+		options.Retries = DefaultLookupRetries
+	} else if options.Retries < 0 {
+		options.Retries = 0
+	}
+	if options.RetryDelay <= 0 {
+		options.RetryDelay = DefaultRetryDelay
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultLookupTimeout
+	}
+
+	httpClient := &http.Client{}
+	e := &LookupEngine{
+		options: options,
+		// Everything the client would set lazily is set here, so one client
+		// can be shared by the workers
+		client: &rdap.Client{
+			HTTP:      httpClient,
+			Bootstrap: &bootstrap.Client{HTTP: httpClient},
+			Verbose:   func(string) {},
+		},
+		bootstrap: &bootstrap.Client{HTTP: httpClient},
+		limiters:  make(map[string]*serverLimiter),
+	}
+	if options.BootstrapCacheDir != "" {
+		// Without the disk cache the files are still cached in memory
+		if registryCache, err := newBootstrapCache(options.BootstrapCacheDir); err == nil {
+			e.bootstrap.Cache = registryCache
+		}
+	}
+	e.serverFor = e.rdapServer
+	e.queryRDAP = e.rdapQuery
+	e.queryWHOIS = func(ctx context.Context, domain string) DomainSearchResult {
+		return searchDomainWHOIS(domain)
+	}
+	return e
+}
+
+var (
+	defaultEngineOnce sync.Once
+	defaultEngine     *LookupEngine
+)
+
+// defaultLookupEngine is shared by SearchDomain and SearchDomainsConcurrent
+func defaultLookupEngine() *LookupEngine {
+	defaultEngineOnce.Do(func() {
+		defaultEngine = NewLookupEngine(LookupOptions{})
+	})
+	return defaultEngine
+}
+
+// Search checks domains and returns their results in the same order. When
+// ctx is canceled, domains that weren't checked get the context's error.
+func (e *LookupEngine) Search(ctx context.Context, domains []string) []DomainSearchResult {
+	results := make([]DomainSearchResult, len(domains))
+	checked := make([]bool, len(domains))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+
+	workers := min(e.options.Workers, len(domains))
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = e.Lookup(ctx, domains[i])
+				checked[i] = true
+
+				progressMu.Lock()
+				done++
+				if e.options.Progress != nil {
+					e.options.Progress(LookupProgress{Done: done, Total: len(domains), Result: results[i]})
+				}
+				progressMu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i := range domains {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, domain := range domains {
+		if !checked[i] {
+			results[i] = DomainSearchResult{Domain: domain, Error: ctx.Err().Error()}
+		}
+	}
+	return results
+}
+
+// Lookup checks a single domain using RDAP with WHOIS fallback
+func (e *LookupEngine) Lookup(ctx context.Context, domain string) DomainSearchResult {
+	// Registries only understand A-labels
+	ascii, err := dns.ToASCII(domain)
+	if err != nil {
+		return DomainSearchResult{Domain: domain, Error: err.Error()}
+	}
+	if e.options.Cache != nil {
+		if result, ok := e.options.Cache.Get(ascii, e.options.MaxAge, time.Now()); ok {
+			return result
+		}
+	}
+
+	result := e.lookup(ctx, ascii)
+	if dns.IsIDN(ascii) {
+		result.UnicodeName = dns.ToUnicode(ascii)
+	}
+	if e.options.Cache != nil {
+		e.options.Cache.Put(result, time.Now())
+	}
+	return result
+}
+
+// lookup checks an A-label domain
+func (e *LookupEngine) lookup(ctx context.Context, domain string) DomainSearchResult {
+	// Try RDAP first; TLDs without an RDAP server go straight to WHOIS
+	var rdapErr error
+	server, err := e.serverFor(ctx, domain)
+	if err != nil {
+		rdapErr = err
+	} else {
+		var result DomainSearchResult
+		err := e.withRetries(ctx, server.Host, func(ctx context.Context) error {
+			var err error
+			result, err = e.queryRDAP(ctx, server, domain)
+			return err
+		})
+		if err == nil {
+			return result
+		}
+		rdapErr = err
+	}
+	if ctx.Err() != nil {
+		return DomainSearchResult{Domain: domain, Error: ctx.Err().Error()}
+	}
+
+	// WHOIS servers throttle too, so they share the per-server limits
+	var whoisResult DomainSearchResult
+	err = e.withRetries(ctx, "whois:"+extractTLD(domain), func(ctx context.Context) error {
+		whoisResult = e.queryWHOIS(ctx, domain)
+		return nil
+	})
+	if err != nil {
+		return DomainSearchResult{Domain: domain, Error: err.Error()}
+	}
+	// If WHOIS succeeded, use it; otherwise keep the RDAP error
+	if whoisResult.Error == "" {
+		return whoisResult
+	}
+	return DomainSearchResult{
+		Domain: domain,
+		Error:  fmt.Sprintf("RDAP failed (%s), WHOIS fallback also failed (%s)", rdapErr, whoisResult.Error),
+	}
+}
+
+// withRetries runs query within key's server limits, retrying transient
+// errors with exponential backoff and jitter
+func (e *LookupEngine) withRetries(ctx context.Context, key string, query func(context.Context) error) error {
+	limiter := e.limiter(key)
+	for attempt := 0; ; attempt++ {
+		if err := limiter.acquire(ctx); err != nil {
+			return err
+		}
+		err := query(ctx)
+		limiter.release()
+
+		var transient *transientError
+		if err == nil || !errors.As(err, &transient) || attempt >= e.options.Retries || ctx.Err() != nil {
+			return err
+		}
+
+		// Full delay doubles each attempt; jitter keeps workers that failed
+		// together from retrying together
+		delay := e.options.RetryDelay << attempt
+		delay = delay/2 + rand.N(delay/2+1)
+		if transient.retryAfter > delay {
+			delay = transient.retryAfter
+		}
+		if transient.retryAfter > 0 {
+			// The server asked everyone to back off, not only this lookup
+			limiter.pause(delay)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// limiter returns the limiter for one server, creating it on first use
+func (e *LookupEngine) limiter(key string) *serverLimiter {
+	e.limitersMu.Lock()
+	defer e.limitersMu.Unlock()
+
+	limiter, ok := e.limiters[key]
+	if !ok {
+		limiter = &serverLimiter{
+			slots:    make(chan struct{}, e.options.PerServer),
+			interval: e.options.ServerInterval,
+		}
+		e.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// rdapServer finds the RDAP server for domain's TLD in the bootstrap
+// registry, preferring HTTPS
+func (e *LookupEngine) rdapServer(ctx context.Context, domain string) (*url.URL, error) {
+	e.bootstrapMu.Lock()
+	question := &bootstrap.Question{RegistryType: bootstrap.DNS, Query: domain}
+	answer, err := e.bootstrap.Lookup(question.WithContext(ctx))
+	e.bootstrapMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("RDAP bootstrap failed: %w", err)
+	}
+	if len(answer.URLs) == 0 {
+		return nil, fmt.Errorf("no RDAP server for .%s", extractTLD(domain))
+	}
+
+	for _, u := range answer.URLs {
+		if u.Scheme == "https" {
+			return u, nil
+		}
+	}
+	return answer.URLs[0], nil
+}
+
+// rdapQuery asks one RDAP server about domain. Errors worth retrying are
+// returned as *transientError.
+func (e *LookupEngine) rdapQuery(ctx context.Context, server *url.URL, domain string) (DomainSearchResult, error) {
+	requestCtx, cancel := context.WithTimeout(ctx, e.options.Timeout)
+	defer cancel()
+
+	req := rdap.NewDomainRequest(domain).WithServer(server).WithContext(requestCtx)
+	resp, err := e.client.Do(req)
+	if err == nil {
+		switch object := resp.Object.(type) {
+		case *rdap.Domain:
+			return rdapDomainResult(domain, object), nil
+		case *rdap.Error:
+			return DomainSearchResult{}, fmt.Errorf("RDAP server returned an error: %s", object.Title)
+		default:
+			return DomainSearchResult{}, fmt.Errorf("unexpected RDAP response %T", resp.Object)
+		}
+	}
+
+	// A 404 means the domain is available
+	var clientErr *rdap.ClientError
+	if errors.As(err, &clientErr) && clientErr.Type == rdap.ObjectDoesNotExist {
+		return availableResult(domain), nil
+	}
+	if ctx.Err() != nil {
+		return DomainSearchResult{}, ctx.Err()
+	}
+
+	// Throttling, server errors, timeouts and network failures are transient
+	if resp != nil {
+		for _, h := range resp.HTTP {
+			if h.Response == nil {
+				continue
+			}
+			if code := h.Response.StatusCode; code == http.StatusTooManyRequests || code >= 500 {
+				return DomainSearchResult{}, &transientError{
+					err:        fmt.Errorf("RDAP server %s returned %s", server.Host, h.Response.Status),
+					retryAfter: parseRetryAfter(h.Response.Header.Get("Retry-After")),
+				}
+			}
+		}
+	}
+	if requestCtx.Err() != nil || (clientErr != nil && clientErr.Type == rdap.NoWorkingServers) {
+		return DomainSearchResult{}, &transientError{err: err}
+	}
+	return DomainSearchResult{}, err
+}
+
+func availableResult(domain string) DomainSearchResult {
+	return DomainSearchResult{
+		Domain:    domain,
+		Available: true,
+		Status:    "available",
+		Source:    SourceRDAP,
+	}
+}
+
+// transientError is a lookup failure worth retrying
+type transientError struct {
+	err        error
+	retryAfter time.Duration // Requested by the server, if it said
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date,
+// capped at maxRetryAfter
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		delay = time.Until(t)
+	}
+	return max(0, min(delay, maxRetryAfter))
+}
+
+// serverLimiter bounds the requests in flight to one server and spaces out
+// their starts
+type serverLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest start of the next request
+}
+
+// acquire waits for a free slot and the server's next start time
+func (l *serverLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	if err := sleep(ctx, time.Until(start)); err != nil {
+		<-l.slots
+		return err
+	}
+	return nil
+}
+
+func (l *serverLimiter) release() {
+	<-l.slots
+}
+
+// pause holds back requests to the server for d
+func (l *serverLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,146 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubEngine returns an engine whose RDAP lookups go to query, with one
+// server per TLD
+func stubEngine(options LookupOptions, query func(ctx context.Context, domain string) (DomainSearchResult, error)) *LookupEngine {
+	e := NewLookupEngine(options)
+	e.serverFor = func(ctx context.Context, domain string) (*url.URL, error) {
+		return &url.URL{Scheme: "https", Host: "rdap." + extractTLD(domain)}, nil
+	}
+	e.queryRDAP = func(ctx context.Context, server *url.URL, domain string) (DomainSearchResult, error) {
+		return query(ctx, domain)
+	}
+	e.queryWHOIS = func(ctx context.Context, domain string) DomainSearchResult {
+		return DomainSearchResult{Domain: domain, Error: "whois unavailable"}
+	}
+	return e
+}
+
+func TestLookupEngineConcurrencyLimits(t *testing.T) {
+	var mu sync.Mutex
+	inFlight := make(map[string]int)
+	peak := make(map[string]int)
+	total, peakTotal := 0, 0
+
+	options := LookupOptions{Workers: 6, PerServer: 2, ServerInterval: time.Millisecond}
+	e := stubEngine(options, func(ctx context.Context, domain string) (DomainSearchResult, error) {
+		tld := extractTLD(domain)
+		mu.Lock()
+		inFlight[tld]++
+		total++
+		peak[tld] = max(peak[tld], inFlight[tld])
+		peakTotal = max(peakTotal, total)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight[tld]--
+		total--
+		mu.Unlock()
+		return availableResult(domain), nil
+	})
+
+	var domains []string
+	for _, name := range strings.Split("a,b,c,d,e,f,g,h", ",") {
+		domains = append(domains, name+".com", name+".io", name+".dev")
+	}
+
+	var progress []int
+	e.options.Progress = func(p LookupProgress) {
+		if p.Total != len(domains) {
+			t.Errorf("progress total = %d, want %d", p.Total, len(domains))
+		}
+		progress = append(progress, p.Done)
+	}
+
+	results := e.Search(context.Background(), domains)
+	for i, result := range results {
+		if result.Domain != domains[i] || !result.Available {
+			t.Errorf("result %d = %+v, want %s available", i, result, domains[i])
+		}
+	}
+	for tld, n := range peak {
+		if n > 2 {
+			t.Errorf("%d lookups at once against .%s, want at most 2", n, tld)
+		}
+	}
+	if peakTotal > 6 {
+		t.Errorf("%d lookups at once, want at most 6 workers", peakTotal)
+	}
+	if len(progress) != len(domains) || progress[len(progress)-1] != len(domains) {
+		t.Errorf("progress = %v", progress)
+	}
+}
+
+func TestLookupEngineRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	options := LookupOptions{Retries: 2, RetryDelay: time.Millisecond, ServerInterval: time.Millisecond}
+	e := stubEngine(options, func(ctx context.Context, domain string) (DomainSearchResult, error) {
+		if calls.Add(1) < 3 {
+			return DomainSearchResult{}, &transientError{err: errors.New("503 Service Unavailable")}
+		}
+		return DomainSearchResult{Domain: domain, Status: "registered"}, nil
+	})
+
+	result := e.Lookup(context.Background(), "kopi.com")
+	if result.Error != "" || result.Status != "registered" || calls.Load() != 3 {
+		t.Errorf("result = %+v after %d calls, want registered after 3", result, calls.Load())
+	}
+
+	// Permanent errors aren't retried, and fall back to WHOIS
+	calls.Store(0)
+	e.queryRDAP = func(ctx context.Context, server *url.URL, domain string) (DomainSearchResult, error) {
+		calls.Add(1)
+		return DomainSearchResult{}, errors.New("malformed response")
+	}
+	result = e.Lookup(context.Background(), "kopi.com")
+	if calls.Load() != 1 {
+		t.Errorf("permanent error was tried %d times", calls.Load())
+	}
+	if !strings.Contains(result.Error, "malformed response") || !strings.Contains(result.Error, "whois unavailable") {
+		t.Errorf("error = %q", result.Error)
+	}
+}
+
+func TestLookupEngineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	options := LookupOptions{Workers: 1, ServerInterval: time.Millisecond}
+	e := stubEngine(options, func(ctx context.Context, domain string) (DomainSearchResult, error) {
+		cancel()
+		return availableResult(domain), nil
+	})
+
+	results := e.Search(ctx, []string{"a.com", "b.com", "c.com"})
+	if !results[0].Available {
+		t.Errorf("first result = %+v, want available", results[0])
+	}
+	for _, result := range results[1:] {
+		if result.Domain == "" || result.Error != context.Canceled.Error() {
+			t.Errorf("result = %+v, want canceled", result)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("3"); got != 3*time.Second {
+		t.Errorf("parseRetryAfter(3) = %v", got)
+	}
+	if got := parseRetryAfter("3600"); got != maxRetryAfter {
+		t.Errorf("parseRetryAfter(3600) = %v, want cap", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v", got)
+	}
+}
@@ -2,15 +2,22 @@ package domains
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // DomainListResult for command output
 type DomainListResult struct {
-	Domains    []ManagedDomain `json:"domains"`
-	Summary    DomainSummary   `json:"summary"`
-	LastSynced time.Time       `json:"last_synced"`
+	Domains     []ManagedDomain       `json:"domains"`
+	Summary     DomainSummary         `json:"summary"`
+	LastSynced  time.Time             `json:"last_synced"`
+	SyncResults map[string]SyncResult `json:"sync_results,omitempty"` // Latest sync of each configured provider
 }
 
 type DomainSummary struct {
@@ -26,6 +33,9 @@ type ListOptions struct {
 	Provider   string // Filter by provider name
 	ExpiringIn string // Filter by expiry timeframe (e.g., "30d", "1w")
 	Status     string // Filter by status (healthy, warning, critical, expired)
+	Refresh    bool   // Sync from all registrars before listing instead of serving the cache
+	Tag        string // Filter by tag
+	Project    string // Filter by project
 }
 
 // SyncResult represents the result of syncing domains from a provider
@@ -50,6 +60,22 @@ type ManagedDomain struct {
 	LastUpdated time.Time    `json:"last_updated"`
 	Cost        *DomainCost  `json:"cost,omitempty"`
 	DNSRecords  []DNSRecord  `json:"dns_records,omitempty"`
+
+	// Local metadata from the portfolio metadata file, never from registrars
+	Tags    []string      `json:"tags,omitempty"`
+	Project string        `json:"project,omitempty"`
+	Owner   string        `json:"owner,omitempty"`
+	Action  PlannedAction `json:"action,omitempty"`
+	Notes   string        `json:"notes,omitempty"`
+}
+
+// SetMetadata fills in the domain's local metadata
+func (d *ManagedDomain) SetMetadata(metadata DomainMetadata) {
+	d.Tags = slices.Clone(metadata.Tags)
+	d.Project = metadata.Project
+	d.Owner = metadata.Owner
+	d.Action = metadata.Action
+	d.Notes = metadata.Notes
 }
 
 // GetStatus calculates and returns the appropriate DomainStatus based on
@@ -108,6 +134,10 @@ type DNSRecord struct {
 	TTL     int    `json:"ttl"`
 }
 
+// ErrNotSupported is wrapped by registrars for operations their API does not
+// offer, so callers can tell "can't" apart from "failed"
+var ErrNotSupported = errors.New("not supported by this registrar")
+
 type Registrar interface {
 
 	// Domain Operations
@@ -125,6 +155,16 @@ type Registrar interface {
 
 type Manager struct {
 	Registrars []Registrar
+
+	// InventoryPath is where the local domain inventory is cached. When empty
+	// the inventory only lives for the lifetime of the Manager.
+	InventoryPath string
+
+	// MetadataPath is the portfolio metadata file merged into listed
+	// domains. When empty domains are listed without metadata.
+	MetadataPath string
+
+	inventory *Inventory
 }
 
 func NewManager(registrars []Registrar) *Manager {
@@ -133,14 +173,299 @@ func NewManager(registrars []Registrar) *Manager {
 	}
 }
 
-// ListManagedDomains retrieves domains from all configured providers
+// ListManagedDomains serves domains from the local inventory. Registrars that
+// have never been synced are synced first, and options.Refresh forces a sync
+// of every registrar.
 func (d *Manager) ListManagedDomains(options ListOptions) (*DomainListResult, error) {
-	// TODO: Implement domain listing logic
-	return nil, fmt.Errorf("not implemented")
+	inventory, err := d.loadInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, registrar := range d.Registrars {
+		name := RegistrarName(registrar)
+		if options.Refresh || !inventory.HasSynced(name) {
+			pending = append(pending, name)
+		}
+	}
+
+	if len(pending) > 0 {
+		if _, err := d.SyncDomains(pending); err != nil {
+			return nil, err
+		}
+		if inventory, err = d.loadInventory(); err != nil {
+			return nil, err
+		}
+	}
+
+	var expiringWithin time.Duration
+	if options.ExpiringIn != "" {
+		if expiringWithin, err = ParseTimeframe(options.ExpiringIn); err != nil {
+			return nil, err
+		}
+	}
+	if options.Status != "" && !isValidStatus(DomainStatus(strings.ToLower(options.Status))) {
+		return nil, fmt.Errorf("invalid status %q (valid: healthy, warning, critical, expired)", options.Status)
+	}
+
+	metadata := &PortfolioMetadata{Domains: make(map[string]*DomainMetadata)}
+	if d.MetadataPath != "" {
+		if metadata, err = LoadPortfolioMetadata(d.MetadataPath); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only show domains from registrars that are still configured
+	configured := make(map[string]bool, len(d.Registrars))
+	syncResults := make(map[string]SyncResult, len(d.Registrars))
+	for _, registrar := range d.Registrars {
+		name := RegistrarName(registrar)
+		configured[name] = true
+		if result, ok := inventory.SyncResults[name]; ok {
+			syncResults[name] = result
+		}
+	}
+
+	domainList := []ManagedDomain{}
+	for _, domain := range inventory.Domains {
+		if !configured[domain.Provider] {
+			continue
+		}
+		if options.Provider != "" && !strings.EqualFold(domain.Provider, options.Provider) {
+			continue
+		}
+
+		// Status depends on the current time, so it is recalculated rather
+		// than trusted from the cache
+		domain.SetStatus()
+
+		if options.Status != "" && !strings.EqualFold(string(domain.Status), options.Status) {
+			continue
+		}
+		if expiringWithin > 0 && time.Until(domain.ExpiryDate) > expiringWithin {
+			continue
+		}
+
+		if entry := metadata.Get(domain.Name); entry != nil {
+			domain.SetMetadata(*entry)
+		}
+		if options.Tag != "" && !slices.ContainsFunc(domain.Tags, func(tag string) bool { return strings.EqualFold(tag, options.Tag) }) {
+			continue
+		}
+		if options.Project != "" && !strings.EqualFold(domain.Project, options.Project) {
+			continue
+		}
+		domainList = append(domainList, domain)
+	}
+
+	sort.SliceStable(domainList, func(i, j int) bool {
+		return domainList[i].Name < domainList[j].Name
+	})
+
+	return &DomainListResult{
+		Domains:     domainList,
+		Summary:     Summarize(domainList),
+		LastSynced:  inventory.LastSynced,
+		SyncResults: syncResults,
+	}, nil
 }
 
-// SyncDomains syncs domains from specified providers
+// SyncDomains pulls domains from the named registrars concurrently and
+// persists them to the local inventory. All registrars are synced when
+// providerNames is empty. A failing registrar keeps its previously cached
+// domains; its error is reported in the returned SyncResult. Nothing is
+// synced if any name doesn't match a configured registrar.
 func (d *Manager) SyncDomains(providerNames []string) (map[string]SyncResult, error) {
-	// TODO: Implement domain sync logic
-	return nil, fmt.Errorf("not implemented")
+	wanted := make(map[string]bool, len(providerNames))
+	for _, name := range providerNames {
+		if _, ok := d.Registrar(name); !ok {
+			return nil, fmt.Errorf("no configured registrar named %s", name)
+		}
+		wanted[strings.ToLower(name)] = true
+	}
+
+	type syncOutcome struct {
+		result  SyncResult
+		domains []ManagedDomain
+	}
+
+	var wg sync.WaitGroup
+	outcomes := make(chan syncOutcome, len(d.Registrars))
+
+	for _, registrar := range d.Registrars {
+		name := RegistrarName(registrar)
+		if len(wanted) > 0 && !wanted[name] {
+			continue
+		}
+
+		wg.Add(1)
+		go func(reg Registrar, name string) {
+			defer wg.Done()
+
+			domainList, err := reg.ListDomains(context.TODO())
+			outcome := syncOutcome{
+				result: SyncResult{
+					Provider:     name,
+					DomainsCount: len(domainList),
+					Success:      err == nil,
+					SyncedAt:     time.Now(),
+				},
+				domains: domainList,
+			}
+			if err != nil {
+				outcome.result.Error = err.Error()
+			}
+			outcomes <- outcome
+		}(registrar, name)
+	}
+
+	wg.Wait()
+	close(outcomes)
+
+	inventory, err := d.loadInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]SyncResult)
+	for outcome := range outcomes {
+		results[outcome.result.Provider] = outcome.result
+		inventory.SyncResults[outcome.result.Provider] = outcome.result
+		if outcome.result.Success {
+			inventory.replaceProvider(outcome.result.Provider, outcome.domains)
+		}
+	}
+
+	if len(results) > 0 {
+		inventory.LastSynced = time.Now()
+	}
+
+	if err := d.saveInventory(inventory); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// Registrar returns the configured registrar with the given provider name
+func (d *Manager) Registrar(name string) (Registrar, bool) {
+	for _, registrar := range d.Registrars {
+		if strings.EqualFold(RegistrarName(registrar), name) {
+			return registrar, true
+		}
+	}
+	return nil, false
+}
+
+// FillRenewalCosts asks each domain's registrar for its renewal price when
+// the listing did not include one. Registrars that cannot report pricing are
+// skipped; the cost simply stays unknown.
+func (d *Manager) FillRenewalCosts(ctx context.Context, domainList []ManagedDomain) {
+	for i := range domainList {
+		if domainList[i].Cost != nil {
+			continue
+		}
+		registrar, ok := d.Registrar(domainList[i].Provider)
+		if !ok {
+			continue
+		}
+		if cost, err := registrar.GetRenewalInfo(ctx, domainList[i].Name); err == nil {
+			domainList[i].Cost = cost
+		}
+	}
+}
+
+// RegistrarName returns the provider name of a registrar, or "unknown" when
+// the implementation does not expose one
+func RegistrarName(registrar Registrar) string {
+	if named, ok := registrar.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return "unknown"
+}
+
+// Summarize counts domains by status
+func Summarize(domainList []ManagedDomain) DomainSummary {
+	summary := DomainSummary{
+		Total: len(domainList),
+	}
+
+	for _, domain := range domainList {
+		switch domain.Status {
+		case StatusHealthy:
+			summary.Healthy++
+		case StatusWarning:
+			summary.Warning++
+		case StatusCritical:
+			summary.Critical++
+		case StatusExpired:
+			summary.Expired++
+		}
+	}
+
+	return summary
+}
+
+// ParseTimeframe parses timeframes such as "30d", "2w", "6mo", "1y" or any
+// Go duration like "36h". Months count as 30 days and years as 365. A bare
+// "6m" is rejected: Go durations read it as minutes, other tools as months.
+func ParseTimeframe(timeframe string) (time.Duration, error) {
+	timeframe = strings.TrimSpace(strings.ToLower(timeframe))
+	if timeframe == "" {
+		return 0, fmt.Errorf("empty timeframe")
+	}
+
+	units := map[string]time.Duration{
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"mo": 30 * 24 * time.Hour,
+		"y":  365 * 24 * time.Hour,
+	}
+
+	number := strings.TrimRight(timeframe, "abcdefghijklmnopqrstuvwxyz")
+	n, err := strconv.Atoi(number)
+	if err == nil && n >= 0 {
+		suffix := timeframe[len(number):]
+		if unit, ok := units[suffix]; ok {
+			return time.Duration(n) * unit, nil
+		}
+		if suffix == "m" {
+			return 0, fmt.Errorf("ambiguous timeframe %q: use %dmo for months (units: d, w, mo, y, or a Go duration like 36h)", timeframe, n)
+		}
+	}
+
+	duration, err := time.ParseDuration(timeframe)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeframe %q (units: d, w, mo, y, e.g. 30d, 2w, 6mo, 1y, or a Go duration like 36h)", timeframe)
+	}
+	return duration, nil
+}
+
+func isValidStatus(status DomainStatus) bool {
+	switch status {
+	case StatusHealthy, StatusWarning, StatusCritical, StatusExpired:
+		return true
+	}
+	return false
+}
+
+// loadInventory returns the cached inventory, from disk when a path is set
+func (d *Manager) loadInventory() (*Inventory, error) {
+	if d.InventoryPath == "" {
+		if d.inventory == nil {
+			d.inventory = &Inventory{SyncResults: make(map[string]SyncResult)}
+		}
+		return d.inventory, nil
+	}
+	return LoadInventory(d.InventoryPath)
+}
+
+// saveInventory stores the inventory, on disk when a path is set
+func (d *Manager) saveInventory(inventory *Inventory) error {
+	if d.InventoryPath == "" {
+		d.inventory = inventory
+		return nil
+	}
+	return inventory.Save(d.InventoryPath)
 }
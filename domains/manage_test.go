@@ -0,0 +1,210 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeRegistrar struct {
+	name         string
+	domains      []ManagedDomain
+	err          error
+	calls        int
+	autoRenewErr error
+}
+
+func (f *fakeRegistrar) Name() string { return f.name }
+
+func (f *fakeRegistrar) ListDomains(ctx context.Context) ([]ManagedDomain, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.domains, nil
+}
+
+func (f *fakeRegistrar) GetDomain(ctx context.Context, name string) (*ManagedDomain, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistrar) UpdateAutoRenewal(ctx context.Context, name string, enabled bool) error {
+	if f.autoRenewErr != nil {
+		return f.autoRenewErr
+	}
+	for i := range f.domains {
+		if f.domains[i].Name == name {
+			f.domains[i].AutoRenewal = enabled
+		}
+	}
+	return nil
+}
+
+func (f *fakeRegistrar) GetRenewalInfo(ctx context.Context, name string) (*DomainCost, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistrar) GetNameservers(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRegistrar) UpdateNameservers(ctx context.Context, name string, nameservers []string) error {
+	return errors.New("not implemented")
+}
+
+func TestManagerServesFromInventory(t *testing.T) {
+	now := time.Now()
+	alpha := &fakeRegistrar{name: "alpha", domains: []ManagedDomain{
+		{Name: "zeta.com", Provider: "alpha", ExpiryDate: now.AddDate(1, 0, 0), AutoRenewal: true},
+		{Name: "soon.com", Provider: "alpha", ExpiryDate: now.AddDate(0, 0, 3), AutoRenewal: true},
+	}}
+	beta := &fakeRegistrar{name: "beta", domains: []ManagedDomain{
+		{Name: "beta.dev", Provider: "beta", ExpiryDate: now.AddDate(0, 6, 0)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	manager := NewManager([]Registrar{alpha, beta})
+	manager.InventoryPath = path
+
+	// First listing syncs every registrar
+	result, err := manager.ListManagedDomains(ListOptions{})
+	if err != nil {
+		t.Fatalf("ListManagedDomains: %v", err)
+	}
+	if len(result.Domains) != 3 || result.Domains[0].Name != "beta.dev" {
+		t.Fatalf("got %+v, want 3 domains sorted by name", result.Domains)
+	}
+	if result.LastSynced.IsZero() {
+		t.Error("LastSynced not populated")
+	}
+	if result.Summary.Total != 3 || result.Summary.Critical != 1 {
+		t.Errorf("summary = %+v, want 3 total with 1 critical", result.Summary)
+	}
+
+	// A new manager over the same inventory must not hit the registrars
+	manager = NewManager([]Registrar{alpha, beta})
+	manager.InventoryPath = path
+	result, err = manager.ListManagedDomains(ListOptions{Provider: "alpha", ExpiringIn: "1w"})
+	if err != nil {
+		t.Fatalf("ListManagedDomains: %v", err)
+	}
+	if alpha.calls != 1 || beta.calls != 1 {
+		t.Errorf("registrars called %d/%d times, want once each", alpha.calls, beta.calls)
+	}
+	if len(result.Domains) != 1 || result.Domains[0].Name != "soon.com" {
+		t.Errorf("filtered domains = %+v, want only soon.com", result.Domains)
+	}
+
+	// A failing refresh keeps the cached domains and reports the error
+	beta.err = errors.New("api down")
+	result, err = manager.ListManagedDomains(ListOptions{Refresh: true})
+	if err != nil {
+		t.Fatalf("ListManagedDomains: %v", err)
+	}
+	if alpha.calls != 2 || beta.calls != 2 {
+		t.Errorf("registrars called %d/%d times after refresh, want twice each", alpha.calls, beta.calls)
+	}
+	if len(result.Domains) != 3 {
+		t.Errorf("got %d domains after failed refresh, want 3 cached", len(result.Domains))
+	}
+	if sync := result.SyncResults["beta"]; sync.Success || sync.Error != "api down" {
+		t.Errorf("beta sync result = %+v, want failure with error", sync)
+	}
+}
+
+func TestSyncDomainsUnknownProvider(t *testing.T) {
+	alpha := &fakeRegistrar{name: "alpha"}
+	manager := NewManager([]Registrar{alpha})
+	if _, err := manager.SyncDomains([]string{"alpha", "nope"}); err == nil {
+		t.Error("SyncDomains with an unknown provider succeeded, want an error")
+	}
+	if alpha.calls != 0 {
+		t.Errorf("alpha called %d times, want no sync before the names are checked", alpha.calls)
+	}
+}
+
+func TestParseTimeframe(t *testing.T) {
+	day := 24 * time.Hour
+	cases := map[string]time.Duration{
+		"30d": 30 * day,
+		"2w":  14 * day,
+		"6mo": 180 * day,
+		"1y":  365 * day,
+		"36h": 36 * time.Hour,
+	}
+	for input, want := range cases {
+		got, err := ParseTimeframe(input)
+		if err != nil || got != want {
+			t.Errorf("ParseTimeframe(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"soon", "2m", "6M"} {
+		if got, err := ParseTimeframe(input); err == nil {
+			t.Errorf("ParseTimeframe(%q) = %v, want an error", input, got)
+		}
+	}
+	if _, err := ParseTimeframe("2m"); err == nil || !strings.Contains(err.Error(), "2mo") {
+		t.Errorf("ParseTimeframe(\"2m\") error = %v, want it to suggest 2mo", err)
+	}
+}
+
+func TestSetAutoRenewal(t *testing.T) {
+	expiry := time.Now().AddDate(1, 0, 0)
+	alpha := &fakeRegistrar{name: "alpha", domains: []ManagedDomain{
+		{Name: "off.com", Provider: "alpha", ExpiryDate: expiry},
+		{Name: "on.com", Provider: "alpha", ExpiryDate: expiry, AutoRenewal: true},
+	}}
+	beta := &fakeRegistrar{name: "beta", autoRenewErr: fmt.Errorf("beta: %w", ErrNotSupported), domains: []ManagedDomain{
+		{Name: "beta.dev", Provider: "beta", ExpiryDate: expiry},
+	}}
+	gamma := &fakeRegistrar{name: "gamma", autoRenewErr: errors.New("api down"), domains: []ManagedDomain{
+		{Name: "gamma.io", Provider: "gamma", ExpiryDate: expiry},
+	}}
+
+	manager := NewManager([]Registrar{alpha, beta, gamma})
+	manager.InventoryPath = filepath.Join(t.TempDir(), "inventory.json")
+
+	listed, err := manager.ListManagedDomains(ListOptions{})
+	if err != nil {
+		t.Fatalf("ListManagedDomains: %v", err)
+	}
+
+	// Turned off in the registrar's dashboard since the inventory was synced
+	alpha.domains[1].AutoRenewal = false
+
+	results, err := manager.SetAutoRenewal(context.Background(), listed.Domains, true)
+	if err != nil {
+		t.Fatalf("SetAutoRenewal: %v", err)
+	}
+
+	want := map[string]AutoRenewStatus{
+		"off.com":  AutoRenewUpdated,
+		"on.com":   AutoRenewUpdated,
+		"beta.dev": AutoRenewUnsupported,
+		"gamma.io": AutoRenewFailed,
+	}
+	for _, result := range results {
+		if result.Status != want[result.Domain] {
+			t.Errorf("%s: status %s, want %s", result.Domain, result.Status, want[result.Domain])
+		}
+	}
+
+	if !alpha.domains[1].AutoRenewal {
+		t.Error("on.com left off at the registrar because the inventory showed it on")
+	}
+
+	domain, err := manager.FindDomain("OFF.com")
+	if err != nil {
+		t.Fatalf("FindDomain: %v", err)
+	}
+	if !domain.AutoRenewal {
+		t.Error("inventory not updated after successful change")
+	}
+	if _, err := manager.FindDomain("missing.com"); err == nil {
+		t.Error("FindDomain for an unmanaged domain succeeded, want an error")
+	}
+}
@@ -0,0 +1,153 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// PlannedAction is what the owner intends to do with a domain at renewal
+type PlannedAction string
+
+const (
+	ActionKeep      PlannedAction = "keep"
+	ActionSell      PlannedAction = "sell"
+	ActionLetExpire PlannedAction = "let-expire"
+	ActionUndecided PlannedAction = ""
+)
+
+// metadataFileHead starts every saved metadata file
+const metadataFileHead = "# Domain tags, projects and notes kept by indietool. Safe to commit.\n"
+
+// ParsePlannedAction validates a planned action. "none" clears it.
+func ParsePlannedAction(input string) (PlannedAction, error) {
+	switch action := PlannedAction(strings.ToLower(strings.TrimSpace(input))); action {
+	case ActionKeep, ActionSell, ActionLetExpire:
+		return action, nil
+	case "none", ActionUndecided:
+		return ActionUndecided, nil
+	default:
+		return "", fmt.Errorf("invalid action %q (valid: keep, sell, let-expire, none)", input)
+	}
+}
+
+// DomainMetadata is what the team knows about a domain that no registrar
+// does: which project it belongs to, who owns it and what to do with it
+type DomainMetadata struct {
+	Tags    []string      `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Project string        `yaml:"project,omitempty" json:"project,omitempty"`
+	Owner   string        `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Action  PlannedAction `yaml:"action,omitempty" json:"action,omitempty"`
+	Notes   string        `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// IsEmpty reports whether nothing is recorded for the domain
+func (m *DomainMetadata) IsEmpty() bool {
+	return len(m.Tags) == 0 && m.Project == "" && m.Owner == "" && m.Action == ActionUndecided && m.Notes == ""
+}
+
+// HasTag reports whether the domain is tagged tag, ignoring case
+func (m *DomainMetadata) HasTag(tag string) bool {
+	return slices.ContainsFunc(m.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+}
+
+// AddTags tags the domain, skipping tags it already has. Tags are kept
+// lowercase and sorted.
+func (m *DomainMetadata) AddTags(tags ...string) {
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !m.HasTag(tag) {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+	sort.Strings(m.Tags)
+}
+
+// RemoveTags untags the domain
+func (m *DomainMetadata) RemoveTags(tags ...string) {
+	m.Tags = slices.DeleteFunc(m.Tags, func(t string) bool {
+		return slices.ContainsFunc(tags, func(tag string) bool { return strings.EqualFold(strings.TrimSpace(tag), t) })
+	})
+}
+
+// PortfolioMetadata holds the metadata of every domain in the portfolio,
+// keyed by domain name. It is stored as YAML so a team can commit it
+// alongside their code and review changes to it.
+type PortfolioMetadata struct {
+	Domains map[string]*DomainMetadata `yaml:"domains"`
+}
+
+// LoadPortfolioMetadata reads the metadata file at path. A missing file
+// yields no metadata.
+func LoadPortfolioMetadata(path string) (*PortfolioMetadata, error) {
+	metadata := &PortfolioMetadata{Domains: make(map[string]*DomainMetadata)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return metadata, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domain metadata: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse domain metadata %s: %w", path, err)
+	}
+	if metadata.Domains == nil {
+		metadata.Domains = make(map[string]*DomainMetadata)
+	}
+
+	// Hand-edited files may use any case for domain names
+	for name, entry := range metadata.Domains {
+		if key := normalizeMetadataName(name); key != name {
+			delete(metadata.Domains, name)
+			metadata.Domains[key] = entry
+		}
+	}
+	return metadata, nil
+}
+
+// Save writes the metadata to path, creating parent directories as needed.
+// Domains with nothing recorded are left out.
+func (p *PortfolioMetadata) Save(path string) error {
+	for name, entry := range p.Domains {
+		if entry == nil || entry.IsEmpty() {
+			delete(p.Domains, name)
+		}
+	}
+
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode domain metadata: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create domain metadata directory: %w", err)
+	}
+	return os.WriteFile(path, append([]byte(metadataFileHead), data...), 0644)
+}
+
+// Get returns the metadata of domain, or nil when none is recorded
+func (p *PortfolioMetadata) Get(domain string) *DomainMetadata {
+	return p.Domains[normalizeMetadataName(domain)]
+}
+
+// Entry returns the metadata of domain for editing, adding it if needed
+func (p *PortfolioMetadata) Entry(domain string) *DomainMetadata {
+	name := normalizeMetadataName(domain)
+	entry, ok := p.Domains[name]
+	if !ok || entry == nil {
+		entry = &DomainMetadata{}
+		p.Domains[name] = entry
+	}
+	return entry
+}
+
+func normalizeMetadataName(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
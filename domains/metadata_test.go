@@ -0,0 +1,138 @@
+package domains
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPortfolioMetadataRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team", "metadata.yaml")
+
+	metadata, err := LoadPortfolioMetadata(path)
+	if err != nil {
+		t.Fatalf("LoadPortfolioMetadata on a missing file: %v", err)
+	}
+	entry := metadata.Entry("Kopi.dev.")
+	entry.AddTags("Shop", "client", "shop")
+	entry.Project = "kopi"
+	entry.Action = ActionLetExpire
+	metadata.Entry("unused.com") // Nothing recorded, so not saved
+
+	if err := metadata.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "unused.com") {
+		t.Errorf("saved file lists a domain with no metadata:\n%s", data)
+	}
+
+	loaded, err := LoadPortfolioMetadata(path)
+	if err != nil {
+		t.Fatalf("LoadPortfolioMetadata: %v", err)
+	}
+	got := loaded.Get("KOPI.DEV")
+	if got == nil {
+		t.Fatalf("kopi.dev missing after reload: %+v", loaded.Domains)
+	}
+	want := DomainMetadata{Tags: []string{"client", "shop"}, Project: "kopi", Action: ActionLetExpire}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("reloaded = %+v, want %+v", *got, want)
+	}
+
+	got.RemoveTags("SHOP")
+	if !reflect.DeepEqual(got.Tags, []string{"client"}) {
+		t.Errorf("after RemoveTags = %v, want [client]", got.Tags)
+	}
+}
+
+func TestLoadPortfolioMetadataHandEdited(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.yaml")
+	content := `domains:
+  Kopi.COM:
+    tags: [brand]
+    owner: ana
+    notes: |
+      Renew every year.
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := LoadPortfolioMetadata(path)
+	if err != nil {
+		t.Fatalf("LoadPortfolioMetadata: %v", err)
+	}
+	entry := metadata.Get("kopi.com")
+	if entry == nil || entry.Owner != "ana" || !entry.HasTag("BRAND") || entry.Notes != "Renew every year.\n" {
+		t.Errorf("kopi.com = %+v, want owner ana, tag brand and the note", entry)
+	}
+}
+
+func TestParsePlannedAction(t *testing.T) {
+	for input, want := range map[string]PlannedAction{"keep": ActionKeep, "Let-Expire": ActionLetExpire, "none": ActionUndecided} {
+		if got, err := ParsePlannedAction(input); err != nil || got != want {
+			t.Errorf("ParsePlannedAction(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParsePlannedAction("burn"); err == nil {
+		t.Error("ParsePlannedAction(burn) succeeded, want an error")
+	}
+}
+
+func TestListManagedDomainsMergesMetadata(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	registrar := &fakeRegistrar{name: "alpha", domains: []ManagedDomain{
+		{Name: "kopi.com", Provider: "alpha", ExpiryDate: now.AddDate(1, 0, 0), AutoRenewal: true},
+		{Name: "kopi.dev", Provider: "alpha", ExpiryDate: now.AddDate(1, 0, 0), AutoRenewal: true},
+		{Name: "other.io", Provider: "alpha", ExpiryDate: now.AddDate(1, 0, 0), AutoRenewal: true},
+	}}
+
+	metadata := &PortfolioMetadata{Domains: map[string]*DomainMetadata{
+		"kopi.com": {Tags: []string{"brand"}, Project: "kopi", Owner: "ana"},
+		"kopi.dev": {Tags: []string{"client"}, Project: "Kopi", Action: ActionSell},
+		"other.io": {Tags: []string{"brand"}, Project: "other"},
+	}}
+	metadataPath := filepath.Join(dir, "metadata.yaml")
+	if err := metadata.Save(metadataPath); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager([]Registrar{registrar})
+	manager.InventoryPath = filepath.Join(dir, "inventory.json")
+	manager.MetadataPath = metadataPath
+
+	result, err := manager.ListManagedDomains(ListOptions{Project: "kopi"})
+	if err != nil {
+		t.Fatalf("ListManagedDomains: %v", err)
+	}
+	if len(result.Domains) != 2 || result.Domains[0].Owner != "ana" || result.Domains[1].Action != ActionSell {
+		t.Fatalf("project kopi = %+v, want kopi.com and kopi.dev with their metadata", result.Domains)
+	}
+
+	result, err = manager.ListManagedDomains(ListOptions{Tag: "BRAND", Project: "kopi"})
+	if err != nil {
+		t.Fatalf("ListManagedDomains: %v", err)
+	}
+	if len(result.Domains) != 1 || result.Domains[0].Name != "kopi.com" {
+		t.Errorf("tag brand in project kopi = %+v, want only kopi.com", result.Domains)
+	}
+
+	// Metadata stays out of the registrar inventory
+	inventory, err := LoadInventory(manager.InventoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, domain := range inventory.Domains {
+		if len(domain.Tags) > 0 || domain.Project != "" {
+			t.Errorf("inventory entry %s carries metadata: %+v", domain.Name, domain)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Nameservers asks the registrar of domain which nameservers it delegates to
+func (d *Manager) Nameservers(ctx context.Context, domain ManagedDomain) ([]string, error) {
+	registrar, ok := d.Registrar(domain.Provider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s is not configured", domain.Provider)
+	}
+	return registrar.GetNameservers(ctx, domain.Name)
+}
+
+// SetNameservers changes the delegation of domain at its registrar and
+// records the new nameservers in the inventory
+func (d *Manager) SetNameservers(ctx context.Context, domain ManagedDomain, nameservers []string) error {
+	registrar, ok := d.Registrar(domain.Provider)
+	if !ok {
+		return fmt.Errorf("provider %s is not configured", domain.Provider)
+	}
+	if err := registrar.UpdateNameservers(ctx, domain.Name, nameservers); err != nil {
+		return err
+	}
+
+	inventory, err := d.loadInventory()
+	if err != nil {
+		return err
+	}
+	for i := range inventory.Domains {
+		if strings.EqualFold(inventory.Domains[i].Name, domain.Name) {
+			inventory.Domains[i].Nameservers = append([]string(nil), nameservers...)
+		}
+	}
+	return d.saveInventory(inventory)
+}
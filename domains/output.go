@@ -67,6 +67,30 @@ var DomainTableConfig = output.TableConfig{
 			Formatter: output.RelativeTimeFormatter,
 			WideOnly:  true,
 		},
+		{
+			Name:      "PROJECT",
+			JSONPath:  "project",
+			Formatter: DashIfEmptyFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "TAGS",
+			JSONPath:  "tags",
+			Formatter: ListFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "OWNER",
+			JSONPath:  "owner",
+			Formatter: DashIfEmptyFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "ACTION",
+			JSONPath:  "action",
+			Formatter: DashIfEmptyFormatter,
+			WideOnly:  true,
+		},
 	},
 
 	SummaryFunc: func(rows []map[string]interface{}) string {
@@ -74,8 +98,9 @@ var DomainTableConfig = output.TableConfig{
 		healthy, warning, critical, expired := 0, 0, 0, 0
 
 		for _, row := range rows {
-			if status, ok := row["status"].(string); ok {
-				switch DomainStatus(strings.ToLower(status)) {
+			// Rows built from ManagedDomain carry a DomainStatus, decoded JSON a string
+			if status, ok := row["status"]; ok {
+				switch DomainStatus(strings.ToLower(fmt.Sprint(status))) {
 				case StatusHealthy:
 					healthy++
 				case StatusWarning:
@@ -0,0 +1,303 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPriceTableTTL is how long registrar price lists are cached
+const DefaultPriceTableTTL = 24 * time.Hour
+
+// PremiumFactor flags a name as premium when a registrar quotes it at this
+// many times the TLD's cheapest list price or more
+const PremiumFactor = 3
+
+// TLDPrice is a registrar's standard yearly price for one TLD
+type TLDPrice struct {
+	TLD          string  `json:"tld"`
+	Currency     string  `json:"currency"`
+	Registration float64 `json:"registration"`
+	Renewal      float64 `json:"renewal"`
+	Transfer     float64 `json:"transfer"`
+}
+
+// PriceLister is implemented by registrars that publish a price list for
+// the TLDs they sell
+type PriceLister interface {
+	ListPrices(ctx context.Context) ([]TLDPrice, error)
+}
+
+// DomainPrice is what one name costs at one registrar
+type DomainPrice struct {
+	Registrar    string  `json:"registrar"`
+	Currency     string  `json:"currency"`
+	Registration float64 `json:"registration"`
+	Renewal      float64 `json:"renewal"`
+	Transfer     float64 `json:"transfer,omitempty"`
+	Fees         float64 `json:"fees,omitempty"` // ICANN or registry fees per year on top of Registration
+	Premium      bool    `json:"premium,omitempty"`
+}
+
+// DomainQuoter is implemented by registrars that price individual names,
+// which is how registry premium names are found. QuoteDomain returns nil
+// when the registrar's list price applies.
+type DomainQuoter interface {
+	QuoteDomain(ctx context.Context, domain string) (*DomainPrice, error)
+}
+
+// PriceQuote is the cheapest offer for a domain and every offer found.
+// Offers are only ranked against others in the same currency: the cheapest
+// is picked from the currency most offers use, and MixedCurrency is set when
+// some offers couldn't be compared.
+type PriceQuote struct {
+	DomainPrice
+	Domain        string        `json:"domain"`
+	Offers        []DomainPrice `json:"offers"` // Cheapest first, by currency
+	MixedCurrency bool          `json:"mixed_currency,omitempty"`
+}
+
+// RegistrarPrices is a cached price list
+type RegistrarPrices struct {
+	FetchedAt time.Time           `json:"fetched_at"`
+	Prices    map[string]TLDPrice `json:"prices"` // By A-label TLD
+}
+
+// PriceTable caches registrars' price lists between runs
+type PriceTable struct {
+	Registrars map[string]RegistrarPrices `json:"registrars"`
+}
+
+// LoadPriceTable reads the price table at path. A missing file yields an
+// empty table.
+func LoadPriceTable(path string) (*PriceTable, error) {
+	table := &PriceTable{Registrars: make(map[string]RegistrarPrices)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return table, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price table: %w", err)
+	}
+
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("failed to parse price table %s: %w", path, err)
+	}
+	if table.Registrars == nil {
+		table.Registrars = make(map[string]RegistrarPrices)
+	}
+	return table, nil
+}
+
+// Save writes the price table to path, creating parent directories as needed
+func (t *PriceTable) Save(path string) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode price table: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create price table directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// PricingOptions controls PriceDomains
+type PricingOptions struct {
+	Refresh    bool          // Fetch price lists even when the cached ones are fresh
+	MaxAge     time.Duration // Age after which cached price lists are fetched again (default DefaultPriceTableTTL)
+	QuoteNames bool          // Quote every name, not just those no price list covers, to find premium names
+}
+
+// pricingWorkers bounds the per-name quotes running at once
+const pricingWorkers = 4
+
+// PriceDomains finds what each of domainList costs at the configured
+// registrars: list prices come from registrars implementing PriceLister,
+// cached in table, and per-name quotes from those implementing DomainQuoter.
+// Since quotes cost an API call each, names are only quoted when no price
+// list covers their TLD, unless options.QuoteNames is set.
+// Domains no registrar prices are left out. Registrars that failed are
+// returned by name; the remaining ones are still used.
+func (d *Manager) PriceDomains(ctx context.Context, table *PriceTable, domainList []string, options PricingOptions, now time.Time) (map[string]*PriceQuote, map[string]error) {
+	maxAge := options.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultPriceTableTTL
+	}
+
+	errs := make(map[string]error)
+	var listers []string
+	var quoters []Registrar
+	for _, registrar := range d.Registrars {
+		name := RegistrarName(registrar)
+		if _, ok := registrar.(DomainQuoter); ok {
+			quoters = append(quoters, registrar)
+		}
+		lister, ok := registrar.(PriceLister)
+		if !ok {
+			continue
+		}
+		listers = append(listers, name)
+
+		cached, ok := table.Registrars[name]
+		if ok && !options.Refresh && now.Sub(cached.FetchedAt) < maxAge {
+			continue
+		}
+		prices, err := lister.ListPrices(ctx)
+		if err != nil {
+			// A stale list beats none
+			errs[name] = err
+			continue
+		}
+		fresh := RegistrarPrices{FetchedAt: now, Prices: make(map[string]TLDPrice, len(prices))}
+		for _, price := range prices {
+			tld := strings.ToLower(strings.TrimPrefix(price.TLD, "."))
+			price.TLD = tld
+			fresh.Prices[tld] = price
+		}
+		table.Registrars[name] = fresh
+	}
+
+	// Per-name quotes, bounded since some registrars price one name per call
+	var toQuote []string
+	for _, domain := range domainList {
+		if options.QuoteNames || !listed(table, listers, strings.ToLower(extractTLD(domain))) {
+			toQuote = append(toQuote, domain)
+		}
+	}
+	type quoteKey struct{ registrar, domain string }
+	quotes := make(map[quoteKey]*DomainPrice)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, pricingWorkers)
+	for _, registrar := range quoters {
+		name := RegistrarName(registrar)
+		quoter := registrar.(DomainQuoter)
+		for _, domain := range toQuote {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				slots <- struct{}{}
+				defer func() { <-slots }()
+
+				quote, err := quoter.QuoteDomain(ctx, domain)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if errors.Is(err, ErrNotSupported) {
+						return
+					}
+					if _, seen := errs[name]; !seen {
+						errs[name] = err
+					}
+					return
+				}
+				if quote != nil {
+					quote.Registrar = name
+					quotes[quoteKey{name, domain}] = quote
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	result := make(map[string]*PriceQuote)
+	for _, domain := range domainList {
+		tld := strings.ToLower(extractTLD(domain))
+
+		var offers []DomainPrice
+		cheapestList := make(map[string]float64) // By currency
+		for _, name := range listers {
+			price, ok := table.Registrars[name].Prices[tld]
+			if !ok || price.Registration <= 0 {
+				continue
+			}
+			if cheapest := cheapestList[price.Currency]; cheapest == 0 || price.Registration < cheapest {
+				cheapestList[price.Currency] = price.Registration
+			}
+			if _, quoted := quotes[quoteKey{name, domain}]; quoted {
+				continue
+			}
+			offers = append(offers, DomainPrice{
+				Registrar:    name,
+				Currency:     price.Currency,
+				Registration: price.Registration,
+				Renewal:      price.Renewal,
+				Transfer:     price.Transfer,
+			})
+		}
+		for _, registrar := range quoters {
+			if quote, ok := quotes[quoteKey{RegistrarName(registrar), domain}]; ok {
+				offers = append(offers, *quote)
+			}
+		}
+		if len(offers) == 0 {
+			continue
+		}
+
+		for i := range offers {
+			if cheapest := cheapestList[offers[i].Currency]; cheapest > 0 && offers[i].Registration >= PremiumFactor*cheapest {
+				offers[i].Premium = true
+			}
+		}
+
+		// Prices in different currencies can't be compared, so rank the
+		// currency most offers use first and the others after it
+		primary, mixed := primaryCurrency(offers)
+		sort.SliceStable(offers, func(i, j int) bool {
+			a, b := offers[i], offers[j]
+			if (a.Currency == primary) != (b.Currency == primary) {
+				return a.Currency == primary
+			}
+			if a.Currency != b.Currency {
+				return a.Currency < b.Currency
+			}
+			return a.Registration < b.Registration
+		})
+
+		quote := &PriceQuote{DomainPrice: offers[0], Domain: domain, Offers: offers, MixedCurrency: mixed}
+		// Registries set premium prices, so list prices elsewhere won't hold
+		// either once one registrar reports one
+		for _, offer := range offers {
+			if offer.Premium {
+				quote.Premium = true
+			}
+		}
+		result[domain] = quote
+	}
+	return result, errs
+}
+
+// listed reports whether any of the listers' price lists in table has a
+// price for tld
+func listed(table *PriceTable, listers []string, tld string) bool {
+	for _, name := range listers {
+		if price, ok := table.Registrars[name].Prices[tld]; ok && price.Registration > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryCurrency returns the currency most offers are in, the first
+// alphabetically on a tie, and whether any offer is in another currency
+func primaryCurrency(offers []DomainPrice) (string, bool) {
+	counts := make(map[string]int)
+	for _, offer := range offers {
+		counts[offer.Currency]++
+	}
+	primary := ""
+	for currency, count := range counts {
+		if primary == "" || count > counts[primary] || (count == counts[primary] && currency < primary) {
+			primary = currency
+		}
+	}
+	return primary, len(counts) > 1
+}
@@ -0,0 +1,71 @@
+package domains
+
+import (
+	"indietool/cli/output"
+	"slices"
+	"sort"
+)
+
+// WithPriceColumns shows pricing by default: the cheapest registrar, its
+// first-year and renewal prices and whether the name is premium
+func WithPriceColumns(config output.TableConfig) output.TableConfig {
+	config.DefaultColumns = append(slices.Clone(config.DefaultColumns),
+		output.Column{Name: "REGISTRAR", JSONPath: "registrar", Formatter: DashIfEmptyFormatter, Required: true},
+		output.Column{Name: "COST", JSONPath: "cost", Formatter: CostFormatter, Required: true},
+		output.Column{Name: "RENEWAL", JSONPath: "renewal_cost", Formatter: CostFormatter, Required: true},
+		output.Column{Name: "PREMIUM", JSONPath: "premium", Formatter: PremiumFormatter, Required: true},
+	)
+
+	wide := []output.Column{{Name: "CURRENCY", JSONPath: "currency", Formatter: DashIfEmptyFormatter, WideOnly: true}}
+	for _, column := range config.WideColumns {
+		if column.Name != "REGISTRAR" && column.Name != "COST" {
+			wide = append(wide, column)
+		}
+	}
+	config.WideColumns = wide
+	return config
+}
+
+// AddPricesToRows fills the pricing fields of search or explore rows from
+// quotes, keyed by domain
+func AddPricesToRows(rows []map[string]interface{}, quotes map[string]*PriceQuote) {
+	for _, row := range rows {
+		domain, _ := row["domain"].(string)
+		quote, ok := quotes[domain]
+		if !ok {
+			continue
+		}
+		row["registrar"] = quote.Registrar
+		row["cost"] = quote.Registration
+		row["renewal_cost"] = quote.Renewal
+		row["currency"] = quote.Currency
+		row["premium"] = quote.Premium
+		row["offers"] = quote.Offers
+	}
+}
+
+// SortRowsByPrice moves available, priced rows to the top, cheapest first.
+// The order of the other rows is kept.
+func SortRowsByPrice(rows []map[string]interface{}) {
+	price := func(row map[string]interface{}) (float64, bool) {
+		status, _ := row["status"].(string)
+		cost, ok := row["cost"].(float64)
+		return cost, ok && cost > 0 && status == "Available"
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		pi, oki := price(rows[i])
+		pj, okj := price(rows[j])
+		if oki != okj {
+			return oki
+		}
+		return oki && pi < pj
+	})
+}
+
+// PremiumFormatter flags premium names
+func PremiumFormatter(value interface{}) string {
+	if premium, ok := value.(bool); ok && premium {
+		return "premium"
+	}
+	return "-"
+}
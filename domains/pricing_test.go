@@ -0,0 +1,159 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// listingRegistrar publishes a price list
+type listingRegistrar struct {
+	fakeRegistrar
+	prices []TLDPrice
+	err    error
+	calls  int
+}
+
+func (l *listingRegistrar) ListPrices(ctx context.Context) ([]TLDPrice, error) {
+	l.calls++
+	return l.prices, l.err
+}
+
+// quotingRegistrar prices individual names
+type quotingRegistrar struct {
+	fakeRegistrar
+	quotes map[string]DomainPrice
+
+	mu     sync.Mutex
+	quoted []string
+}
+
+func (q *quotingRegistrar) QuoteDomain(ctx context.Context, domain string) (*DomainPrice, error) {
+	q.mu.Lock()
+	q.quoted = append(q.quoted, domain)
+	q.mu.Unlock()
+
+	price, ok := q.quotes[domain]
+	if !ok {
+		return nil, nil
+	}
+	return &price, nil
+}
+
+func TestPriceDomains(t *testing.T) {
+	cheap := &listingRegistrar{fakeRegistrar: fakeRegistrar{name: "cheap"}, prices: []TLDPrice{
+		{TLD: "com", Currency: "USD", Registration: 9.5, Renewal: 10.5},
+		{TLD: ".io", Currency: "USD", Registration: 40, Renewal: 45},
+	}}
+	pricey := &listingRegistrar{fakeRegistrar: fakeRegistrar{name: "pricey"}, prices: []TLDPrice{
+		{TLD: "com", Currency: "USD", Registration: 12, Renewal: 14},
+		{TLD: "io", Currency: "USD", Registration: 35, Renewal: 50},
+	}}
+	quoter := &quotingRegistrar{fakeRegistrar: fakeRegistrar{name: "quoter"}, quotes: map[string]DomainPrice{
+		"kopi.io":  {Currency: "USD", Registration: 30, Renewal: 60},
+		"ai.com":   {Currency: "USD", Registration: 2500, Renewal: 2500},
+		"kopi.dev": {Currency: "USD", Registration: 11, Renewal: 11},
+	}}
+	manager := NewManager([]Registrar{cheap, pricey, quoter, &fakeRegistrar{name: "none"}})
+
+	table := &PriceTable{Registrars: make(map[string]RegistrarPrices)}
+	now := time.Now()
+	quotes, errs := manager.PriceDomains(context.Background(), table, []string{"kopi.com", "kopi.io", "ai.com", "kopi.xyz"}, PricingOptions{QuoteNames: true}, now)
+	if len(errs) != 0 {
+		t.Fatalf("errs = %v", errs)
+	}
+
+	if quote := quotes["kopi.com"]; quote == nil || quote.Registrar != "cheap" || quote.Registration != 9.5 || len(quote.Offers) != 2 || quote.Premium {
+		t.Errorf("kopi.com = %+v, want cheap at 9.50 of 2 offers", quote)
+	}
+	if quote := quotes["kopi.io"]; quote == nil || quote.Registrar != "quoter" || quote.Renewal != 60 {
+		t.Errorf("kopi.io = %+v, want the quoter's 30.00", quote)
+	}
+	if quote := quotes["ai.com"]; quote == nil || !quote.Premium || quote.Registrar != "cheap" {
+		t.Errorf("ai.com = %+v, want flagged premium", quote)
+	}
+	if _, ok := quotes["kopi.xyz"]; ok {
+		t.Error("kopi.xyz has no prices but was quoted")
+	}
+
+	// Without QuoteNames only names no price list covers are quoted
+	quoter.quoted = nil
+	quotes, _ = manager.PriceDomains(context.Background(), table, []string{"kopi.com", "kopi.dev", "kopi.xyz"}, PricingOptions{}, now)
+	slices.Sort(quoter.quoted)
+	if want := []string{"kopi.dev", "kopi.xyz"}; !slices.Equal(quoter.quoted, want) {
+		t.Errorf("quoted %v, want only %v", quoter.quoted, want)
+	}
+	if quote := quotes["kopi.dev"]; quote == nil || quote.Registrar != "quoter" {
+		t.Errorf("kopi.dev = %+v, want the quoter's price", quote)
+	}
+
+	// Fresh price lists are served from the table, and survive a reload
+	path := filepath.Join(t.TempDir(), "prices.json")
+	if err := table.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	table, err := LoadPriceTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.PriceDomains(context.Background(), table, []string{"kopi.com"}, PricingOptions{}, now.Add(time.Hour))
+	if cheap.calls != 1 {
+		t.Errorf("price list fetched %d times, want once", cheap.calls)
+	}
+
+	// A failed refresh keeps the stale list
+	cheap.err = errors.New("rate limited")
+	quotes, errs = manager.PriceDomains(context.Background(), table, []string{"kopi.com"}, PricingOptions{Refresh: true}, now)
+	if errs["cheap"] == nil || quotes["kopi.com"].Registrar != "cheap" {
+		t.Errorf("quote = %+v, errs = %v; want the stale cheap price and its error", quotes["kopi.com"], errs)
+	}
+}
+
+func TestPriceDomainsMixedCurrency(t *testing.T) {
+	usd := &listingRegistrar{fakeRegistrar: fakeRegistrar{name: "usd"}, prices: []TLDPrice{{TLD: "com", Currency: "USD", Registration: 10, Renewal: 11}}}
+	usd2 := &listingRegistrar{fakeRegistrar: fakeRegistrar{name: "usd2"}, prices: []TLDPrice{{TLD: "com", Currency: "USD", Registration: 12, Renewal: 12}}}
+	inr := &listingRegistrar{fakeRegistrar: fakeRegistrar{name: "inr"}, prices: []TLDPrice{{TLD: "com", Currency: "INR", Registration: 850, Renewal: 900}}}
+	manager := NewManager([]Registrar{inr, usd2, usd})
+
+	table := &PriceTable{Registrars: make(map[string]RegistrarPrices)}
+	quotes, _ := manager.PriceDomains(context.Background(), table, []string{"kopi.com"}, PricingOptions{}, time.Now())
+	quote := quotes["kopi.com"]
+	if quote == nil || quote.Registrar != "usd" || !quote.MixedCurrency {
+		t.Fatalf("kopi.com = %+v, want usd's price flagged as mixed-currency", quote)
+	}
+	var order []string
+	for _, offer := range quote.Offers {
+		order = append(order, offer.Registrar)
+		if offer.Premium {
+			t.Errorf("%s flagged premium against another currency's price", offer.Registrar)
+		}
+	}
+	if want := []string{"usd", "usd2", "inr"}; !slices.Equal(order, want) {
+		t.Errorf("offers = %v, want %v", order, want)
+	}
+}
+
+func TestSortRowsByPrice(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"domain": "taken.com", "status": "Taken"},
+		{"domain": "b.io", "status": "Available", "cost": 35.0},
+		{"domain": "unpriced.dev", "status": "Available"},
+		{"domain": "a.com", "status": "Available", "cost": 9.5},
+	}
+	SortRowsByPrice(rows)
+
+	var got []string
+	for _, row := range rows {
+		got = append(got, row["domain"].(string))
+	}
+	want := []string{"a.com", "b.io", "taken.com", "unpriced.dev"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxRegistrationYears is the longest registration registries accept
+const MaxRegistrationYears = 10
+
+// Contact is the registrant contact a domain is registered to. Registrars
+// use it for the registrant, admin, tech and billing contacts alike.
+type Contact struct {
+	FirstName    string `yaml:"first_name" json:"first_name"`
+	LastName     string `yaml:"last_name" json:"last_name"`
+	Organization string `yaml:"organization,omitempty" json:"organization,omitempty"`
+	Email        string `yaml:"email" json:"email"`
+	Phone        string `yaml:"phone" json:"phone"` // +CC.NUMBER, e.g. +1.5555550100
+	Address1     string `yaml:"address1" json:"address1"`
+	Address2     string `yaml:"address2,omitempty" json:"address2,omitempty"`
+	City         string `yaml:"city" json:"city"`
+	State        string `yaml:"state" json:"state"`
+	PostalCode   string `yaml:"postal_code" json:"postal_code"`
+	Country      string `yaml:"country" json:"country"` // ISO 3166 alpha-2, e.g. US
+}
+
+// Validate checks that the fields every registrar requires are set
+func (c Contact) Validate() error {
+	required := []struct{ name, value string }{
+		{"first_name", c.FirstName},
+		{"last_name", c.LastName},
+		{"email", c.Email},
+		{"phone", c.Phone},
+		{"address1", c.Address1},
+		{"city", c.City},
+		{"state", c.State},
+		{"postal_code", c.PostalCode},
+		{"country", c.Country},
+	}
+
+	var missing []string
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("contact is missing %s", strings.Join(missing, ", "))
+	}
+	if len(c.Country) != 2 {
+		return fmt.Errorf("contact country must be a two-letter code, got %q", c.Country)
+	}
+	if !strings.HasPrefix(c.Phone, "+") || !strings.Contains(c.Phone, ".") {
+		return fmt.Errorf("contact phone must look like +1.5555550100, got %q", c.Phone)
+	}
+	return nil
+}
+
+// String summarises the contact on one line
+func (c Contact) String() string {
+	name := strings.TrimSpace(c.FirstName + " " + c.LastName)
+	if c.Organization != "" {
+		name += " (" + c.Organization + ")"
+	}
+	return fmt.Sprintf("%s <%s>, %s, %s", name, c.Email, c.City, strings.ToUpper(c.Country))
+}
+
+// RegistrationRequest describes a domain to buy
+type RegistrationRequest struct {
+	Domain      string
+	Years       int
+	Contact     Contact
+	Nameservers []string     // The registrar's defaults when empty
+	Price       *DomainPrice // The price the user agreed to; some registrars refuse to charge anything else
+	AutoRenew   bool         // Turn on auto-renewal, for registrars that set it at purchase
+}
+
+// Registration is the outcome of a successful registration
+type Registration struct {
+	Domain   string  `json:"domain"`
+	Provider string  `json:"provider"`
+	Years    int     `json:"years"`
+	OrderID  string  `json:"order_id,omitempty"`
+	Charged  float64 `json:"charged,omitempty"` // Zero when the registrar doesn't report it
+	Currency string  `json:"currency,omitempty"`
+	Sandbox  bool    `json:"sandbox,omitempty"`
+}
+
+// PurchaseInfo describes how a registrar registers domains
+type PurchaseInfo struct {
+	Sandbox        bool // Orders go to a test environment and nothing is charged
+	AccountContact bool // The account's default contact is used instead of the request's
+	MaxYears       int  // Longest period the registrar's API registers for; MaxRegistrationYears when zero
+	SetsAutoRenew  bool // Register applies the request's AutoRenew; otherwise the registrar's default applies
+}
+
+// MaxPeriod returns the longest registration period in years
+func (p PurchaseInfo) MaxPeriod() int {
+	if p.MaxYears > 0 {
+		return p.MaxYears
+	}
+	return MaxRegistrationYears
+}
+
+// Purchaser is implemented by registrars that can register new domains
+type Purchaser interface {
+	PurchaseInfo() PurchaseInfo
+	Register(ctx context.Context, request RegistrationRequest) (*Registration, error)
+}
+
+// RegistrationCost is what registering for years costs at price: the first
+// year at the registration price and the rest at the renewal price, plus
+// any fees for each year
+func RegistrationCost(price DomainPrice, years int) float64 {
+	if years < 1 {
+		return 0
+	}
+	renewal := price.Renewal
+	if renewal == 0 {
+		renewal = price.Registration
+	}
+	return price.Registration + float64(years-1)*renewal + float64(years)*price.Fees
+}
+
+// RegisterDomain registers a domain with the named registrar
+func (d *Manager) RegisterDomain(ctx context.Context, provider string, request RegistrationRequest) (*Registration, error) {
+	registrar, ok := d.Registrar(provider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s is not configured as a registrar", provider)
+	}
+	purchaser, ok := registrar.(Purchaser)
+	if !ok {
+		return nil, fmt.Errorf("%s: registering domains: %w", provider, ErrNotSupported)
+	}
+
+	info := purchaser.PurchaseInfo()
+	if request.Years < 1 || request.Years > info.MaxPeriod() {
+		return nil, fmt.Errorf("%s registers domains for 1 to %d years, not %d", provider, info.MaxPeriod(), request.Years)
+	}
+	if !info.AccountContact {
+		if err := request.Contact.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	registration, err := purchaser.Register(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s with %s: %w", request.Domain, provider, err)
+	}
+	registration.Provider = RegistrarName(registrar)
+	registration.Sandbox = info.Sandbox
+	if registration.Years == 0 {
+		registration.Years = request.Years
+	}
+	return registration, nil
+}
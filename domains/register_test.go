@@ -0,0 +1,73 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// purchasingRegistrar records the registrations it is asked for
+type purchasingRegistrar struct {
+	fakeRegistrar
+	info     PurchaseInfo
+	requests []RegistrationRequest
+}
+
+func (p *purchasingRegistrar) PurchaseInfo() PurchaseInfo { return p.info }
+
+func (p *purchasingRegistrar) Register(ctx context.Context, request RegistrationRequest) (*Registration, error) {
+	p.requests = append(p.requests, request)
+	return &Registration{Domain: request.Domain, OrderID: "order-1"}, nil
+}
+
+var testContact = Contact{
+	FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", Phone: "+1.5555550100",
+	Address1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US",
+}
+
+func TestRegisterDomain(t *testing.T) {
+	sandbox := &purchasingRegistrar{fakeRegistrar: fakeRegistrar{name: "sandbox"}, info: PurchaseInfo{Sandbox: true}}
+	account := &purchasingRegistrar{fakeRegistrar: fakeRegistrar{name: "account"}, info: PurchaseInfo{AccountContact: true, MaxYears: 1}}
+	manager := NewManager([]Registrar{sandbox, account, &fakeRegistrar{name: "plain"}})
+	ctx := context.Background()
+
+	registration, err := manager.RegisterDomain(ctx, "sandbox", RegistrationRequest{Domain: "kopi.dev", Years: 2, Contact: testContact})
+	if err != nil {
+		t.Fatalf("RegisterDomain: %v", err)
+	}
+	if registration.Provider != "sandbox" || !registration.Sandbox || registration.Years != 2 {
+		t.Errorf("registration = %+v", registration)
+	}
+
+	// Registrars using the account contact don't need one, but cap the period
+	if _, err := manager.RegisterDomain(ctx, "account", RegistrationRequest{Domain: "kopi.dev", Years: 1}); err != nil {
+		t.Errorf("account contact registration: %v", err)
+	}
+	if _, err := manager.RegisterDomain(ctx, "account", RegistrationRequest{Domain: "kopi.dev", Years: 2}); err == nil {
+		t.Error("2 years accepted by a one-year registrar")
+	}
+
+	if _, err := manager.RegisterDomain(ctx, "sandbox", RegistrationRequest{Domain: "kopi.dev", Years: 1}); err == nil || !strings.Contains(err.Error(), "first_name") {
+		t.Errorf("missing contact: err = %v", err)
+	}
+	if _, err := manager.RegisterDomain(ctx, "plain", RegistrationRequest{Domain: "kopi.dev", Years: 1, Contact: testContact}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("plain registrar: err = %v, want ErrNotSupported", err)
+	}
+	if len(sandbox.requests) != 1 || len(account.requests) != 1 {
+		t.Errorf("requests = %d, %d; rejected registrations reached the registrar", len(sandbox.requests), len(account.requests))
+	}
+}
+
+func TestRegistrationCost(t *testing.T) {
+	price := DomainPrice{Registration: 9.5, Renewal: 12}
+	if got := RegistrationCost(price, 3); got != 33.5 {
+		t.Errorf("RegistrationCost(3 years) = %v, want 33.5", got)
+	}
+	if got := RegistrationCost(DomainPrice{Registration: 10}, 2); got != 20 {
+		t.Errorf("RegistrationCost without renewal = %v, want 20", got)
+	}
+	if got := RegistrationCost(DomainPrice{Registration: 100, Renewal: 50, Fees: 0.25}, 2); got != 150.5 {
+		t.Errorf("RegistrationCost with fees = %v, want 150.5", got)
+	}
+}
@@ -2,20 +2,23 @@ package domains
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"indietool/cli/dns"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/likexian/whois"
 	whoisparser "github.com/likexian/whois-parser"
 	"github.com/openrdap/rdap"
+	"golang.org/x/net/publicsuffix"
 )
 
 // DomainSearchResult represents the result of a domain availability search
 type DomainSearchResult struct {
-	Domain       string     `json:"domain"`
+	Domain       string     `json:"domain"`                   // A-label (punycode) form
+	UnicodeName  string     `json:"unicode_domain,omitempty"` // U-label form, for internationalized names
 	Available    bool       `json:"available"`
 	Status       string     `json:"status,omitempty"`
 	Error        string     `json:"error,omitempty"`
@@ -23,8 +26,24 @@ type DomainSearchResult struct {
 	ExpiryDate   *time.Time `json:"expiry_date,omitempty"`
 	LastUpdated  *time.Time `json:"last_updated,omitempty"`
 	LastChanged  *time.Time `json:"last_changed,omitempty"`
+	Nameservers  []string   `json:"nameservers,omitempty"` // Delegated nameservers, when the registry lists them
+	Cached       bool       `json:"cached,omitempty"`      // Answered from the lookup cache
+
+	// Registration details, when the registry shares them
+	Registrar         string   `json:"registrar,omitempty"`
+	RegistrarIANAID   string   `json:"registrar_iana_id,omitempty"`
+	DNSSEC            *bool    `json:"dnssec,omitempty"`             // Whether the delegation is signed
+	EPPStatus         []string `json:"epp_status,omitempty"`         // EPP status codes, e.g. clientTransferProhibited
+	RegistrantCountry string   `json:"registrant_country,omitempty"` // Usually redacted
+	Source            string   `json:"source,omitempty"`             // rdap or whois
 }
 
+// Sources a search result can come from
+const (
+	SourceRDAP  = "rdap"
+	SourceWHOIS = "whois"
+)
+
 // PopularTLDs contains TLDs favored by indie hackers and small startups
 var PopularTLDs = []string{
 	"com", "net", "org", "dev", "app", "io", "co", "me", "ai", "sh",
@@ -36,45 +55,11 @@ var PopularTLDs = []string{
 
 // SearchDomain checks the availability of a single domain using RDAP with WHOIS fallback
 func SearchDomain(domain string) DomainSearchResult {
-	// Try RDAP first
-	result := searchDomainRDAP(domain)
-
-	// If RDAP failed with an error, fallback to WHOIS
-	if result.Error != "" {
-		whoisResult := searchDomainWHOIS(domain)
-		// If WHOIS succeeded, use it; otherwise keep the RDAP error
-		if whoisResult.Error == "" {
-			return whoisResult
-		}
-		// Keep the original RDAP error but note the fallback attempt
-		result.Error = fmt.Sprintf("RDAP failed (%s), WHOIS fallback also failed (%s)", result.Error, whoisResult.Error)
-	}
-
-	return result
+	return defaultLookupEngine().Lookup(context.Background(), domain)
 }
 
-// searchDomainRDAP checks domain availability using RDAP
-func searchDomainRDAP(domain string) DomainSearchResult {
-	client := &rdap.Client{}
-
-	resp, err := client.QueryDomain(domain)
-	if err != nil {
-		// Check if this is an ObjectDoesNotExist error (404), which indicates domain is available
-		if clientErr, ok := err.(*rdap.ClientError); ok && clientErr.Type == rdap.ObjectDoesNotExist {
-			return DomainSearchResult{
-				Domain:    domain,
-				Available: true,
-				Status:    "available",
-			}
-		}
-
-		return DomainSearchResult{
-			Domain:    domain,
-			Available: false,
-			Error:     err.Error(),
-		}
-	}
-
+// rdapDomainResult reads availability and dates from an RDAP domain object
+func rdapDomainResult(domain string, resp *rdap.Domain) DomainSearchResult {
 	// Check if domain is available based on RDAP response
 	available := false
 	status := "registered"
@@ -103,6 +88,37 @@ func searchDomainRDAP(domain string) DomainSearchResult {
 		Domain:    domain,
 		Available: available,
 		Status:    status,
+		Source:    SourceRDAP,
+	}
+
+	var nameservers []string
+	for _, nameserver := range resp.Nameservers {
+		nameservers = append(nameservers, nameserver.LDHName)
+	}
+	result.Nameservers = dns.NormalizeNameservers(nameservers)
+	if !available {
+		result.EPPStatus = NormalizeEPPStatuses(resp.Status)
+	}
+	if resp.SecureDNS != nil && resp.SecureDNS.DelegationSigned != nil {
+		signed := *resp.SecureDNS.DelegationSigned
+		result.DNSSEC = &signed
+	}
+	for _, entity := range resp.Entities {
+		for _, role := range entity.Roles {
+			switch strings.ToLower(role) {
+			case "registrar":
+				if entity.VCard != nil {
+					result.Registrar = entity.VCard.Name()
+				}
+				for _, id := range entity.PublicIDs {
+					if strings.EqualFold(id.Type, "IANA Registrar ID") {
+						result.RegistrarIANAID = id.Identifier
+					}
+				}
+			case "registrant":
+				result.RegistrantCountry = rdapCountry(entity.VCard)
+			}
+		}
 	}
 
 	// Parse events for date information
@@ -129,6 +145,20 @@ func searchDomainRDAP(domain string) DomainSearchResult {
 	return result
 }
 
+// rdapCountry returns the country of a contact's address: the ISO code
+// when given, otherwise the country name. Redacted contacts have none.
+func rdapCountry(vcard *rdap.VCard) string {
+	if vcard == nil {
+		return ""
+	}
+	if adr := vcard.GetFirst("adr"); adr != nil {
+		if cc := adr.Parameters["cc"]; len(cc) > 0 && cc[0] != "" {
+			return strings.ToUpper(cc[0])
+		}
+	}
+	return vcard.Country()
+}
+
 // searchDomainWHOIS checks domain availability using WHOIS as fallback
 func searchDomainWHOIS(domain string) DomainSearchResult {
 	// Query WHOIS data
@@ -173,6 +203,7 @@ func searchDomainWHOIS(domain string) DomainSearchResult {
 		Domain:    domain,
 		Available: available,
 		Status:    status,
+		Source:    SourceWHOIS,
 	}
 
 	if whoisInfo.Domain != nil {
@@ -191,6 +222,26 @@ func searchDomainWHOIS(domain string) DomainSearchResult {
 				result.LastUpdated = t
 			}
 		}
+		result.Nameservers = dns.NormalizeNameservers(whoisInfo.Domain.NameServers)
+		if !available {
+			result.EPPStatus = NormalizeEPPStatuses(whoisInfo.Domain.Status)
+		}
+		if whoisInfo.Domain.DNSSec {
+			signed := true
+			result.DNSSEC = &signed
+		}
+	}
+	if whoisInfo.Registrar != nil {
+		result.Registrar = whoisInfo.Registrar.Name
+		result.RegistrarIANAID = whoisInfo.Registrar.ID
+	}
+	if whoisInfo.Registrant != nil {
+		result.RegistrantCountry = whoisInfo.Registrant.Country
+	}
+
+	// The parser skips some fields registries commonly send
+	if !available {
+		applyRawWHOIS(&result, whoisRaw)
 	}
 
 	return result
@@ -206,29 +257,105 @@ func analyzeRawWHOIS(domain, whoisRaw string) DomainSearchResult {
 			Domain:    domain,
 			Available: true,
 			Status:    "available",
+			Source:    SourceWHOIS,
 		}
 	}
 
+	result := DomainSearchResult{
+		Domain:    domain,
+		Available: false,
+		Source:    SourceWHOIS,
+	}
+
 	// Check for registration indicators
 	if strings.Contains(lowerRaw, "registrar:") ||
 		strings.Contains(lowerRaw, "registrant:") ||
 		strings.Contains(lowerRaw, "creation date:") ||
 		strings.Contains(lowerRaw, "created:") {
-		return DomainSearchResult{
-			Domain:    domain,
-			Available: false,
-			Status:    "registered (via whois)",
+		result.Status = "registered (via whois)"
+	} else {
+		// If we can't determine, assume it's registered to be safe
+		result.Status = "unknown (whois inconclusive)"
+	}
+
+	applyRawWHOIS(&result, whoisRaw)
+	return result
+}
+
+// applyRawWHOIS fills the registration details result is still missing from
+// the "key: value" lines of a raw WHOIS response
+func applyRawWHOIS(result *DomainSearchResult, whoisRaw string) {
+	var nameservers, statuses []string
+	for _, line := range strings.Split(whoisRaw, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "registrar", "registrar name", "sponsoring registrar":
+			if result.Registrar == "" {
+				result.Registrar = value
+			}
+		case "registrar iana id", "sponsoring registrar iana id":
+			if result.RegistrarIANAID == "" {
+				result.RegistrarIANAID = value
+			}
+		case "name server", "nameserver", "nserver":
+			nameservers = append(nameservers, strings.Fields(value)[0])
+		case "dnssec":
+			if result.DNSSEC == nil {
+				result.DNSSEC = parseWHOISDNSSEC(value)
+			}
+		case "domain status", "status":
+			statuses = append(statuses, value)
+		case "registrant country":
+			if result.RegistrantCountry == "" && !strings.Contains(strings.ToLower(value), "redacted") {
+				result.RegistrantCountry = value
+			}
+		case "creation date", "created":
+			if result.CreationDate == nil {
+				result.CreationDate = parseWHOISDate(value)
+			}
+		case "registry expiry date", "registrar registration expiration date", "expiry date", "expires":
+			if result.ExpiryDate == nil {
+				result.ExpiryDate = parseWHOISDate(value)
+			}
+		case "updated date", "last updated", "changed":
+			if result.LastUpdated == nil {
+				result.LastUpdated = parseWHOISDate(value)
+			}
 		}
 	}
 
-	// If we can't determine, assume it's registered to be safe
-	return DomainSearchResult{
-		Domain:    domain,
-		Available: false,
-		Status:    "unknown (whois inconclusive)",
+	if len(result.Nameservers) == 0 {
+		result.Nameservers = dns.NormalizeNameservers(nameservers)
+	}
+	if len(result.EPPStatus) == 0 {
+		result.EPPStatus = NormalizeEPPStatuses(statuses)
 	}
 }
 
+// parseWHOISDNSSEC reads a WHOIS "DNSSEC:" value, returning nil when it
+// isn't a recognizable yes or no
+func parseWHOISDNSSEC(value string) *bool {
+	var signed bool
+	switch strings.ToLower(strings.ReplaceAll(value, " ", "")) {
+	case "signeddelegation", "signed", "yes", "active", "true":
+		signed = true
+	case "unsigned", "unsigneddelegation", "no", "inactive", "false":
+		signed = false
+	default:
+		return nil
+	}
+	return &signed
+}
+
 // containsNotFoundPattern checks for common "domain not found" patterns in WHOIS data
 func containsNotFoundPattern(lowerRaw string) bool {
 	notFoundPatterns := []string{
@@ -302,37 +429,38 @@ func parseWHOISDate(dateStr string) *time.Time {
 	return nil
 }
 
-// SearchDomainsConcurrent checks multiple domains concurrently
+// SearchDomainsConcurrent checks multiple domains concurrently, within the
+// default LookupEngine's limits
 func SearchDomainsConcurrent(domains []string) []DomainSearchResult {
-	results := make([]DomainSearchResult, len(domains))
-	var wg sync.WaitGroup
-
-	for i, domain := range domains {
-		wg.Add(1)
-		go func(index int, d string) {
-			defer wg.Done()
-			results[index] = SearchDomain(d)
-		}(i, domain)
-	}
-
-	wg.Wait()
-	return results
+	return defaultLookupEngine().Search(context.Background(), domains)
 }
 
-// ExtractBaseDomain removes the TLD from a domain if present
+// ExtractBaseDomain removes the public suffix (com, co.uk, ...) from a
+// domain if it has one. Suffixes come from the public suffix list; private
+// ones such as github.io count only for their TLD, and unlisted TLDs only
+// when they are in the bundled catalog. Internationalized names are
+// returned in U-label form.
 func ExtractBaseDomain(domain string) string {
-	parts := strings.Split(domain, ".")
-	if len(parts) > 1 {
-		// Check if the last part is a known TLD
-		lastPart := parts[len(parts)-1]
-		for _, tld := range PopularTLDs {
-			if lastPart == tld {
-				// Remove the TLD and return the base domain
-				return strings.Join(parts[:len(parts)-1], ".")
-			}
+	ascii, err := dns.ToASCII(domain)
+	if err != nil {
+		return domain
+	}
+	if dns.IsIDN(ascii) {
+		domain = dns.ToUnicode(ascii)
+	}
+
+	suffix, icann := publicsuffix.PublicSuffix(ascii)
+	if !icann {
+		suffix = ascii[strings.LastIndex(ascii, ".")+1:]
+		if _, ok := BundledTLDCatalog().Get(suffix); !ok {
+			return domain
 		}
 	}
-	return domain
+	if suffix == ascii {
+		return domain
+	}
+
+	return dns.ToUnicode(strings.TrimSuffix(ascii, "."+suffix))
 }
 
 // ParseTLDs parses TLD input (comma-separated or @filename)
@@ -350,7 +478,10 @@ func ParseTLDs(input string) ([]string, error) {
 		tld = strings.TrimSpace(tld)
 		if tld != "" {
 			// Remove leading dot if present
-			tld = strings.TrimPrefix(tld, ".")
+			tld, err := normalizeTLD(strings.TrimPrefix(tld, "."))
+			if err != nil {
+				return nil, err
+			}
 			result = append(result, tld)
 		}
 	}
@@ -362,6 +493,15 @@ func ParseTLDs(input string) ([]string, error) {
 	return result, nil
 }
 
+// normalizeTLD converts IDN TLDs such as "рф" to their A-label ("xn--p1ai")
+func normalizeTLD(tld string) (string, error) {
+	ascii, err := dns.ToASCII(tld)
+	if err != nil {
+		return "", fmt.Errorf("invalid TLD %q: %w", tld, err)
+	}
+	return ascii, nil
+}
+
 // readTLDsFromFile reads TLDs from a newline-delimited file
 func readTLDsFromFile(filename string) ([]string, error) {
 	file, err := os.Open(filename)
@@ -376,7 +516,10 @@ func readTLDsFromFile(filename string) ([]string, error) {
 		tld := strings.TrimSpace(scanner.Text())
 		if tld != "" && !strings.HasPrefix(tld, "#") {
 			// Remove leading dot if present
-			tld = strings.TrimPrefix(tld, ".")
+			tld, err := normalizeTLD(strings.TrimPrefix(tld, "."))
+			if err != nil {
+				return nil, err
+			}
 			tlds = append(tlds, tld)
 		}
 	}
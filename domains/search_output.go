@@ -2,6 +2,7 @@ package domains
 
 import (
 	"fmt"
+	"indietool/cli/dns"
 	"indietool/cli/output"
 	"io"
 	"sort"
@@ -13,9 +14,10 @@ import (
 var SearchTableConfig = output.TableConfig{
 	DefaultColumns: []output.Column{
 		{
-			Name:     "DOMAIN",
-			JSONPath: "domain",
-			Required: true,
+			Name:      "DOMAIN",
+			JSONPath:  "domain",
+			Formatter: DomainNameFormatter,
+			Required:  true,
 		},
 		{
 			Name:      "STATUS",
@@ -67,6 +69,36 @@ var SearchTableConfig = output.TableConfig{
 			Formatter: DateFormatter,
 			WideOnly:  true,
 		},
+		{
+			Name:      "NAMESERVERS",
+			JSONPath:  "nameservers",
+			Formatter: ListFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "DNSSEC",
+			JSONPath:  "dnssec",
+			Formatter: DNSSECFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "EPP_STATUS",
+			JSONPath:  "epp_status",
+			Formatter: EPPStatusFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "COUNTRY",
+			JSONPath:  "registrant_country",
+			Formatter: DashIfEmptyFormatter,
+			WideOnly:  true,
+		},
+		{
+			Name:      "SOURCE",
+			JSONPath:  "source",
+			Formatter: DashIfEmptyFormatter,
+			WideOnly:  true,
+		},
 		{
 			Name:      "ERROR",
 			JSONPath:  "error",
@@ -171,19 +203,26 @@ func ConvertSearchResultsToTableRows(results []DomainSearchResult) []map[string]
 	rows := make([]map[string]interface{}, 0, len(results))
 
 	for _, result := range results {
-		tld := extractTLD(result.Domain)
+		tld := dns.ToUnicode(extractTLD(result.Domain))
 
 		row := map[string]interface{}{
-			"domain":        result.Domain,
-			"status":        getSearchStatus(result),
-			"tld":           tld,
-			"registrar":     "",                  // Not available in DomainSearchResult
-			"cost":          0.0,                 // Not available in DomainSearchResult
-			"expiry_date":   result.ExpiryDate,   // Now available from RDAP/WHOIS
-			"creation_date": result.CreationDate, // Now available from RDAP/WHOIS
-			"last_updated":  result.LastUpdated,  // Now available from RDAP/WHOIS
-			"last_changed":  result.LastChanged,  // Now available from RDAP/WHOIS
-			"error":         result.Error,
+			"domain":             result.Domain,
+			"unicode_domain":     result.UnicodeName,
+			"status":             getSearchStatus(result),
+			"tld":                tld,
+			"registrar":          result.Registrar,
+			"registrar_iana_id":  result.RegistrarIANAID,
+			"cost":               0.0,                 // Not available in DomainSearchResult
+			"expiry_date":        result.ExpiryDate,   // Now available from RDAP/WHOIS
+			"creation_date":      result.CreationDate, // Now available from RDAP/WHOIS
+			"last_updated":       result.LastUpdated,  // Now available from RDAP/WHOIS
+			"last_changed":       result.LastChanged,  // Now available from RDAP/WHOIS
+			"nameservers":        result.Nameservers,
+			"dnssec":             result.DNSSEC,
+			"epp_status":         DescribeEPPStatuses(result.EPPStatus),
+			"registrant_country": result.RegistrantCountry,
+			"source":             result.Source,
+			"error":              result.Error,
 		}
 		rows = append(rows, row)
 	}
@@ -223,6 +262,15 @@ func SearchStatusFormatter(value interface{}) string {
 	}
 }
 
+// DomainNameFormatter shows internationalized domains as both U-label and
+// A-label
+func DomainNameFormatter(value interface{}) string {
+	if value == nil {
+		return "-"
+	}
+	return dns.DisplayName(fmt.Sprintf("%v", value))
+}
+
 // PlainSearchStatusFormatter formats domain status without colors
 func PlainSearchStatusFormatter(value interface{}) string {
 	if value == nil {
@@ -231,6 +279,41 @@ func PlainSearchStatusFormatter(value interface{}) string {
 	return fmt.Sprintf("%v", value)
 }
 
+// ListFormatter joins a list of values, such as nameservers
+func ListFormatter(value interface{}) string {
+	list, ok := value.([]string)
+	if !ok || len(list) == 0 {
+		return "-"
+	}
+	return strings.Join(list, ", ")
+}
+
+// DNSSECFormatter shows whether a delegation is signed, or a dash when the
+// registry didn't say
+func DNSSECFormatter(value interface{}) string {
+	signed, ok := value.(*bool)
+	if !ok || signed == nil {
+		return "-"
+	}
+	if *signed {
+		return "signed"
+	}
+	return "unsigned"
+}
+
+// EPPStatusFormatter lists the EPP status codes of a domain
+func EPPStatusFormatter(value interface{}) string {
+	statuses, ok := value.([]EPPStatus)
+	if !ok || len(statuses) == 0 {
+		return "-"
+	}
+	codes := make([]string, len(statuses))
+	for i, status := range statuses {
+		codes[i] = status.Code
+	}
+	return strings.Join(codes, ", ")
+}
+
 // DateFormatter formats date values for Creation, Last Updated, and Last Changed columns
 func DateFormatter(value interface{}) string {
 	if value == nil {
@@ -0,0 +1,138 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openrdap/rdap"
+)
+
+func TestParseTLDsIDN(t *testing.T) {
+	tlds, err := ParseTLDs("com, .рф,みんな,XN--P1AI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"com", "xn--p1ai", "xn--q9jyb4c", "xn--p1ai"}
+	if !reflect.DeepEqual(tlds, want) {
+		t.Errorf("ParseTLDs = %v, want %v", tlds, want)
+	}
+}
+
+func TestExtractBaseDomainIDN(t *testing.T) {
+	tests := map[string]string{
+		"kopitiam.dev":       "kopitiam",
+		"Bücher.com":         "bücher",
+		"xn--bcher-kva.io":   "bücher",
+		"bücher.example":     "bücher.example",
+		"kopitiam.co.uk":     "kopitiam",
+		"kopitiam.github.io": "kopitiam.github",
+		"kopitiam.rocks":     "kopitiam",
+	}
+	for input, want := range tests {
+		if got := ExtractBaseDomain(input); got != want {
+			t.Errorf("ExtractBaseDomain(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestGenerateNamesIDN(t *testing.T) {
+	got := GenerateNames("bücher", NameGeneratorOptions{Prefixes: []string{"get"}})
+	if want := []string{"bücher", "getbücher"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateNames = %v, want %v", got, want)
+	}
+	if score := ScoreDomain("xn--bcher-kva.com", []string{"com"}); score != 6 {
+		t.Errorf("ScoreDomain counts %d, want the 6 visible characters", score)
+	}
+}
+
+func TestRDAPDomainResultDetails(t *testing.T) {
+	object, err := rdap.NewDecoder([]byte(`{
+		"objectClassName": "domain",
+		"ldhName": "kopi.com",
+		"status": ["client transfer prohibited", "server hold"],
+		"secureDNS": {"delegationSigned": true},
+		"nameservers": [{"objectClassName": "nameserver", "ldhName": "NS1.Example.NET"}],
+		"entities": [
+			{
+				"objectClassName": "entity",
+				"roles": ["registrar"],
+				"publicIds": [{"type": "IANA Registrar ID", "identifier": "1068"}],
+				"vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "NameCheap, Inc."]]]
+			},
+			{
+				"objectClassName": "entity",
+				"roles": ["registrant"],
+				"vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["adr", {"cc": "sg"}, "text", ["", "", "", "", "", "", ""]]]]
+			}
+		]
+	}`)).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := rdapDomainResult("kopi.com", object.(*rdap.Domain))
+	if result.Registrar != "NameCheap, Inc." || result.RegistrarIANAID != "1068" {
+		t.Errorf("registrar = %q (%q), want NameCheap, Inc. (1068)", result.Registrar, result.RegistrarIANAID)
+	}
+	if result.DNSSEC == nil || !*result.DNSSEC {
+		t.Errorf("DNSSEC = %v, want signed", result.DNSSEC)
+	}
+	if want := []string{"clientTransferProhibited", "serverHold"}; !reflect.DeepEqual(result.EPPStatus, want) {
+		t.Errorf("EPPStatus = %v, want %v", result.EPPStatus, want)
+	}
+	if result.RegistrantCountry != "SG" {
+		t.Errorf("RegistrantCountry = %q, want SG", result.RegistrantCountry)
+	}
+	if want := []string{"ns1.example.net"}; !reflect.DeepEqual(result.Nameservers, want) {
+		t.Errorf("Nameservers = %v, want %v", result.Nameservers, want)
+	}
+	if result.Source != SourceRDAP {
+		t.Errorf("Source = %q, want %q", result.Source, SourceRDAP)
+	}
+}
+
+func TestAnalyzeRawWHOISDetails(t *testing.T) {
+	raw := `Domain Name: KOPI.COM
+Registrar WHOIS Server: whois.namecheap.com
+Updated Date: 2024-03-01T10:00:00Z
+Creation Date: 2015-06-12T08:30:00Z
+Registry Expiry Date: 2026-06-12T08:30:00Z
+Registrar: NameCheap, Inc.
+Registrar IANA ID: 1068
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Domain Status: redemptionPeriod https://icann.org/epp#redemptionPeriod
+Registrant Country: REDACTED FOR PRIVACY
+Name Server: DNS1.REGISTRAR-SERVERS.COM
+Name Server: DNS2.REGISTRAR-SERVERS.COM
+DNSSEC: unsigned
+`
+	result := analyzeRawWHOIS("kopi.com", raw)
+	if result.Available || result.Status != "registered (via whois)" {
+		t.Fatalf("result = %+v, want registered", result)
+	}
+	if result.Registrar != "NameCheap, Inc." || result.RegistrarIANAID != "1068" {
+		t.Errorf("registrar = %q (%q), want NameCheap, Inc. (1068)", result.Registrar, result.RegistrarIANAID)
+	}
+	if result.DNSSEC == nil || *result.DNSSEC {
+		t.Errorf("DNSSEC = %v, want unsigned", result.DNSSEC)
+	}
+	if want := []string{"clientTransferProhibited", "redemptionPeriod"}; !reflect.DeepEqual(result.EPPStatus, want) {
+		t.Errorf("EPPStatus = %v, want %v", result.EPPStatus, want)
+	}
+	if result.RegistrantCountry != "" {
+		t.Errorf("RegistrantCountry = %q, want redacted value dropped", result.RegistrantCountry)
+	}
+	if want := []string{"dns1.registrar-servers.com", "dns2.registrar-servers.com"}; !reflect.DeepEqual(result.Nameservers, want) {
+		t.Errorf("Nameservers = %v, want %v", result.Nameservers, want)
+	}
+	if result.CreationDate == nil || result.CreationDate.Year() != 2015 || result.ExpiryDate == nil || result.LastUpdated == nil {
+		t.Errorf("dates = %v, %v, %v, want all parsed", result.CreationDate, result.ExpiryDate, result.LastUpdated)
+	}
+	if result.Source != SourceWHOIS {
+		t.Errorf("Source = %q, want %q", result.Source, SourceWHOIS)
+	}
+
+	if available := analyzeRawWHOIS("kopi.dev", "No match for domain \"KOPI.DEV\".\n"); !available.Available || available.Registrar != "" {
+		t.Errorf("not-found result = %+v, want available with no details", available)
+	}
+}
@@ -0,0 +1,246 @@
+package domains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/output"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxSessionRuns is how many runs a session keeps; older ones are dropped
+const MaxSessionRuns = 20
+
+// Availability of a domain in an explore run
+const (
+	SessionAvailable = "available"
+	SessionTaken     = "taken"
+	SessionError     = "error"
+)
+
+// SessionResult is one domain checked in an explore run
+type SessionResult struct {
+	Domain   string  `json:"domain"`
+	Status   string  `json:"status"` // available, taken or error
+	Cost     float64 `json:"cost,omitempty"`
+	Renewal  float64 `json:"renewal_cost,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	Premium  bool    `json:"premium,omitempty"`
+}
+
+// ExploreRun is the outcome of one `domain explore` run in a session
+type ExploreRun struct {
+	At      time.Time       `json:"at"`
+	Base    string          `json:"base"`
+	Results []SessionResult `json:"results"`
+}
+
+// Result returns the result for domain, if the run checked it
+func (r *ExploreRun) Result(domain string) (SessionResult, bool) {
+	for _, result := range r.Results {
+		if result.Domain == domain {
+			return result, true
+		}
+	}
+	return SessionResult{}, false
+}
+
+// ExploreSession is a named series of explore runs, e.g. all the searches
+// for one product name
+type ExploreSession struct {
+	Name    string       `json:"name"`
+	Created time.Time    `json:"created"`
+	Runs    []ExploreRun `json:"runs"` // Oldest first
+}
+
+// Latest returns the most recent run, or nil when there is none
+func (s *ExploreSession) Latest() *ExploreRun {
+	if len(s.Runs) == 0 {
+		return nil
+	}
+	return &s.Runs[len(s.Runs)-1]
+}
+
+// Previous returns the run before the most recent one, or nil
+func (s *ExploreSession) Previous() *ExploreRun {
+	if len(s.Runs) < 2 {
+		return nil
+	}
+	return &s.Runs[len(s.Runs)-2]
+}
+
+// NewExploreRun records search results, with prices from quotes where
+// there are any
+func NewExploreRun(base string, results []DomainSearchResult, quotes map[string]*PriceQuote, now time.Time) ExploreRun {
+	run := ExploreRun{At: now, Base: base, Results: make([]SessionResult, 0, len(results))}
+	for _, result := range results {
+		res := SessionResult{Domain: result.Domain, Status: strings.ToLower(getExploreStatus(result))}
+		if quote, ok := quotes[result.Domain]; ok {
+			res.Cost = quote.Registration
+			res.Renewal = quote.Renewal
+			res.Currency = quote.Currency
+			res.Premium = quote.Premium
+		}
+		run.Results = append(run.Results, res)
+	}
+	return run
+}
+
+// SessionChange is a domain whose availability changed between two runs
+type SessionChange struct {
+	Domain string `json:"domain"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// String describes the change for the CHANGE column
+func (c SessionChange) String() string {
+	return "now " + c.To
+}
+
+// DiffExploreRuns returns the domains checked in both runs that went from
+// available to taken or back, keyed by domain. Failed lookups aren't
+// changes.
+func DiffExploreRuns(previous, current *ExploreRun) map[string]SessionChange {
+	changes := make(map[string]SessionChange)
+	if previous == nil || current == nil {
+		return changes
+	}
+	for _, result := range current.Results {
+		before, ok := previous.Result(result.Domain)
+		if !ok || before.Status == SessionError || result.Status == SessionError || before.Status == result.Status {
+			continue
+		}
+		changes[result.Domain] = SessionChange{Domain: result.Domain, From: before.Status, To: result.Status}
+	}
+	return changes
+}
+
+// WithChangeColumn adds the CHANGE column filled in by AddChangesToRows
+func WithChangeColumn(config output.TableConfig) output.TableConfig {
+	config.DefaultColumns = append(slices.Clone(config.DefaultColumns),
+		output.Column{Name: "CHANGE", JSONPath: "change", Formatter: DashIfEmptyFormatter, Required: true})
+	return config
+}
+
+// AddChangesToRows marks explore rows whose availability changed since the
+// previous run
+func AddChangesToRows(rows []map[string]interface{}, changes map[string]SessionChange) {
+	for _, row := range rows {
+		domain, _ := row["domain"].(string)
+		if change, ok := changes[domain]; ok {
+			row["change"] = change.String()
+		}
+	}
+}
+
+// ExploreSessions holds the saved explore sessions
+type ExploreSessions struct {
+	Sessions map[string]*ExploreSession `json:"sessions"`
+}
+
+// LoadExploreSessions reads the sessions at path. A missing file yields no
+// sessions.
+func LoadExploreSessions(path string) (*ExploreSessions, error) {
+	sessions := &ExploreSessions{Sessions: make(map[string]*ExploreSession)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read explore sessions: %w", err)
+	}
+
+	if err := json.Unmarshal(data, sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse explore sessions %s: %w", path, err)
+	}
+	if sessions.Sessions == nil {
+		sessions.Sessions = make(map[string]*ExploreSession)
+	}
+	return sessions, nil
+}
+
+// Save writes the sessions to path, creating parent directories as needed
+func (s *ExploreSessions) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode explore sessions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create explore sessions directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Record appends run to the named session, creating it if needed, and
+// returns the session
+func (s *ExploreSessions) Record(name string, run ExploreRun) *ExploreSession {
+	name = strings.TrimSpace(name)
+	session, ok := s.Sessions[name]
+	if !ok {
+		session = &ExploreSession{Name: name, Created: run.At}
+		s.Sessions[name] = session
+	}
+	session.Runs = append(session.Runs, run)
+	if len(session.Runs) > MaxSessionRuns {
+		session.Runs = slices.Clone(session.Runs[len(session.Runs)-MaxSessionRuns:])
+	}
+	return session
+}
+
+// Remove deletes the named session
+func (s *ExploreSessions) Remove(name string) bool {
+	_, ok := s.Sessions[name]
+	delete(s.Sessions, name)
+	return ok
+}
+
+// List returns the sessions, most recently run first
+func (s *ExploreSessions) List() []*ExploreSession {
+	sessions := make([]*ExploreSession, 0, len(s.Sessions))
+	for _, session := range s.Sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := sessions[i].Latest(), sessions[j].Latest()
+		switch {
+		case a == nil || b == nil:
+			return b == nil && a != nil
+		case !a.At.Equal(b.At):
+			return a.At.After(b.At)
+		default:
+			return sessions[i].Name < sessions[j].Name
+		}
+	})
+	return sessions
+}
+
+// LatestResult returns the most recent successful lookup of domain in any
+// session, with the name of the session and when it ran
+func (s *ExploreSessions) LatestResult(domain string) (SessionResult, string, time.Time, bool) {
+	var (
+		found   SessionResult
+		name    string
+		at      time.Time
+		matched bool
+	)
+	for _, session := range s.Sessions {
+		for i := len(session.Runs) - 1; i >= 0; i-- {
+			run := &session.Runs[i]
+			if matched && !run.At.After(at) {
+				break
+			}
+			if result, ok := run.Result(domain); ok && result.Status != SessionError {
+				found, name, at, matched = result, session.Name, run.At, true
+				break
+			}
+		}
+	}
+	return found, name, at, matched
+}
@@ -0,0 +1,85 @@
+package domains
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExploreSessionDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	sessions, err := LoadExploreSessions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	session := sessions.Record("kopi", NewExploreRun("kopi", []DomainSearchResult{
+		{Domain: "kopi.com", Status: "active"},
+		availableResult("kopi.io"),
+		availableResult("kopi.dev"),
+		{Domain: "kopi.ai", Error: "timeout"},
+	}, map[string]*PriceQuote{"kopi.io": {DomainPrice: DomainPrice{Registration: 35, Renewal: 45, Currency: "USD"}}}, start))
+	if session.Previous() != nil {
+		t.Fatal("first run has a previous run")
+	}
+	if result, _ := session.Latest().Result("kopi.io"); result.Status != SessionAvailable || result.Cost != 35 {
+		t.Errorf("kopi.io = %+v, want available at 35", result)
+	}
+
+	if err := sessions.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err = LoadExploreSessions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session = sessions.Record("kopi", NewExploreRun("kopi", []DomainSearchResult{
+		availableResult("kopi.com"),
+		{Domain: "kopi.io", Status: "active"},
+		{Domain: "kopi.dev", Error: "timeout"},
+		availableResult("kopi.ai"),
+		availableResult("kopi.app"),
+	}, nil, start.Add(24*time.Hour)))
+
+	changes := DiffExploreRuns(session.Previous(), session.Latest())
+	if len(changes) != 2 {
+		t.Fatalf("changes = %v, want kopi.com and kopi.io only", changes)
+	}
+	if change := changes["kopi.com"]; change.From != SessionTaken || change.To != SessionAvailable {
+		t.Errorf("kopi.com change = %+v", change)
+	}
+	if change := changes["kopi.io"]; change.String() != "now taken" {
+		t.Errorf("kopi.io change = %q", change)
+	}
+
+	rows := []map[string]interface{}{{"domain": "kopi.com"}, {"domain": "kopi.app"}}
+	AddChangesToRows(rows, changes)
+	if rows[0]["change"] != "now available" || rows[1]["change"] != nil {
+		t.Errorf("rows = %v", rows)
+	}
+
+	// The newest successful lookup wins; failed ones are skipped
+	if result, name, at, ok := sessions.LatestResult("kopi.dev"); !ok || result.Status != SessionAvailable || name != "kopi" || !at.Equal(start) {
+		t.Errorf("LatestResult(kopi.dev) = %+v, %q, %v, %v", result, name, at, ok)
+	}
+	if _, _, _, ok := sessions.LatestResult("kopi.xyz"); ok {
+		t.Error("kopi.xyz was never checked")
+	}
+}
+
+func TestExploreSessionKeepsRecentRuns(t *testing.T) {
+	sessions := &ExploreSessions{Sessions: make(map[string]*ExploreSession)}
+	start := time.Now()
+	for i := 0; i < MaxSessionRuns+5; i++ {
+		sessions.Record("kopi", ExploreRun{At: start.Add(time.Duration(i) * time.Hour), Base: "kopi"})
+	}
+	session := sessions.Sessions["kopi"]
+	if len(session.Runs) != MaxSessionRuns || !session.Runs[0].At.Equal(start.Add(5*time.Hour)) {
+		t.Errorf("kept %d runs starting %v, want the last %d", len(session.Runs), session.Runs[0].At, MaxSessionRuns)
+	}
+	if !session.Created.Equal(start) {
+		t.Errorf("created = %v, want the first run", session.Created)
+	}
+}
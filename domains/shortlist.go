@@ -0,0 +1,134 @@
+package domains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ShortlistEntry is a candidate domain starred while naming something
+type ShortlistEntry struct {
+	Domain    string     `json:"domain"`
+	AddedAt   time.Time  `json:"added_at"`
+	Note      string     `json:"note,omitempty"`
+	Session   string     `json:"session,omitempty"` // Explore session it was last seen in
+	Status    string     `json:"status,omitempty"`  // available or taken, as of CheckedAt
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	Cost      float64    `json:"cost,omitempty"`
+	Renewal   float64    `json:"renewal_cost,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	Premium   bool       `json:"premium,omitempty"`
+}
+
+// UpdateFromSession takes the availability and price from an explore run
+// in session at at, unless the entry was checked more recently
+func (e *ShortlistEntry) UpdateFromSession(result SessionResult, session string, at time.Time) {
+	if e.CheckedAt != nil && !at.After(*e.CheckedAt) {
+		return
+	}
+	e.Session = session
+	e.Status = result.Status
+	e.CheckedAt = &at
+	e.Cost, e.Renewal, e.Currency, e.Premium = result.Cost, result.Renewal, result.Currency, result.Premium
+}
+
+// UpdateFromSearch takes the availability from a lookup made at now. Failed
+// lookups change nothing, and a taken domain's price no longer applies.
+func (e *ShortlistEntry) UpdateFromSearch(result DomainSearchResult, now time.Time) {
+	if result.Error != "" {
+		return
+	}
+	e.Status = strings.ToLower(getExploreStatus(result))
+	e.CheckedAt = &now
+	if !result.Available {
+		e.Cost, e.Renewal, e.Currency, e.Premium = 0, 0, "", false
+	}
+}
+
+// Shortlist holds the starred candidate domains
+type Shortlist struct {
+	Domains map[string]*ShortlistEntry `json:"domains"`
+}
+
+// LoadShortlist reads the shortlist at path. A missing file yields an empty
+// shortlist.
+func LoadShortlist(path string) (*Shortlist, error) {
+	shortlist := &Shortlist{Domains: make(map[string]*ShortlistEntry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return shortlist, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shortlist: %w", err)
+	}
+
+	if err := json.Unmarshal(data, shortlist); err != nil {
+		return nil, fmt.Errorf("failed to parse shortlist %s: %w", path, err)
+	}
+	if shortlist.Domains == nil {
+		shortlist.Domains = make(map[string]*ShortlistEntry)
+	}
+	return shortlist, nil
+}
+
+// Save writes the shortlist to path, creating parent directories as needed
+func (s *Shortlist) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode shortlist: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create shortlist directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Add stars domain. It returns false when it is already on the shortlist.
+func (s *Shortlist) Add(domain string, now time.Time) (*ShortlistEntry, bool) {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if entry, ok := s.Domains[domain]; ok {
+		return entry, false
+	}
+	entry := &ShortlistEntry{Domain: domain, AddedAt: now}
+	s.Domains[domain] = entry
+	return entry, true
+}
+
+// Remove takes domain off the shortlist
+func (s *Shortlist) Remove(domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	_, ok := s.Domains[domain]
+	delete(s.Domains, domain)
+	return ok
+}
+
+// List returns the shortlisted domains in the order they were added
+func (s *Shortlist) List() []*ShortlistEntry {
+	entries := make([]*ShortlistEntry, 0, len(s.Domains))
+	for _, entry := range s.Domains {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].AddedAt.Equal(entries[j].AddedAt) {
+			return entries[i].AddedAt.Before(entries[j].AddedAt)
+		}
+		return entries[i].Domain < entries[j].Domain
+	})
+	return entries
+}
+
+// Names returns the shortlisted domain names in the order they were added
+func (s *Shortlist) Names() []string {
+	entries := s.List()
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Domain
+	}
+	return names
+}
@@ -0,0 +1,59 @@
+package domains
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShortlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shortlist.json")
+	shortlist, err := LoadShortlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	entry, added := shortlist.Add("Kopi.dev.", now)
+	if !added || entry.Domain != "kopi.dev" {
+		t.Fatalf("Add = %+v, %v", entry, added)
+	}
+	shortlist.Add("getkopi.com", now.Add(time.Minute))
+	if _, added := shortlist.Add("kopi.dev", now); added {
+		t.Error("kopi.dev added twice")
+	}
+
+	// Session results only apply when newer than the last check
+	explored := now.Add(-time.Hour)
+	entry.UpdateFromSession(SessionResult{Domain: "kopi.dev", Status: SessionAvailable, Cost: 12, Currency: "USD"}, "kopi", explored)
+	if entry.Status != SessionAvailable || entry.Cost != 12 || entry.Session != "kopi" {
+		t.Fatalf("entry = %+v", entry)
+	}
+	entry.UpdateFromSearch(DomainSearchResult{Domain: "kopi.dev", Error: "timeout"}, now)
+	if entry.Status != SessionAvailable || !entry.CheckedAt.Equal(explored) {
+		t.Errorf("a failed lookup changed the entry: %+v", entry)
+	}
+	entry.UpdateFromSearch(DomainSearchResult{Domain: "kopi.dev", Status: "active"}, now)
+	if entry.Status != SessionTaken || entry.Cost != 0 {
+		t.Errorf("entry = %+v, want taken without a price", entry)
+	}
+	entry.UpdateFromSession(SessionResult{Domain: "kopi.dev", Status: SessionAvailable}, "kopi", explored)
+	if entry.Status != SessionTaken {
+		t.Error("an older session result overwrote a newer lookup")
+	}
+
+	if err := shortlist.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	shortlist, err = LoadShortlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := shortlist.Names()
+	if len(names) != 2 || names[0] != "kopi.dev" || names[1] != "getkopi.com" {
+		t.Errorf("names = %v, want in the order added", names)
+	}
+	if !shortlist.Remove("KOPI.DEV") || shortlist.Remove("kopi.dev") {
+		t.Error("Remove should succeed once")
+	}
+}
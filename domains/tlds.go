@@ -0,0 +1,410 @@
+package domains
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TLDCategory groups TLDs. Every TLD has one of generic, country, sponsored,
+// infrastructure or brand, and may also be tagged tech.
+type TLDCategory string
+
+const (
+	CategoryGeneric        TLDCategory = "generic"
+	CategoryCountry        TLDCategory = "country"
+	CategorySponsored      TLDCategory = "sponsored"      // Run for a community, e.g. .edu, .aero
+	CategoryInfrastructure TLDCategory = "infrastructure" // .arpa
+	CategoryBrand          TLDCategory = "brand"          // A company's own TLD
+	CategoryTech           TLDCategory = "tech"           // Popular with developers and software products
+)
+
+// TLDCategories lists every category, for help text and validation
+var TLDCategories = []TLDCategory{
+	CategoryGeneric, CategoryCountry, CategorySponsored, CategoryInfrastructure, CategoryBrand, CategoryTech,
+}
+
+// TLDRestriction is a rule that limits who can register or how a domain
+// can be used
+type TLDRestriction string
+
+const (
+	RestrictionHSTSPreload   TLDRestriction = "hsts-preload"   // Browsers only connect over HTTPS
+	RestrictionLocalPresence TLDRestriction = "local-presence" // Registrants need a presence in the country or city
+	RestrictionEligibility   TLDRestriction = "eligibility"    // Registrants must qualify, e.g. verified banks
+	RestrictionClosed        TLDRestriction = "closed"         // Not sold to the public
+)
+
+// LimitsRegistration reports whether the restriction stops some people
+// from registering. HSTS preloading only affects how a domain is served.
+func (r TLDRestriction) LimitsRegistration() bool {
+	return r != RestrictionHSTSPreload
+}
+
+// PriceBand is a TLD's typical yearly price: budget under $10, standard
+// $10-30 and premium above
+type PriceBand string
+
+const (
+	PriceBandBudget   PriceBand = "budget"
+	PriceBandStandard PriceBand = "standard"
+	PriceBandPremium  PriceBand = "premium"
+	PriceBandUnknown  PriceBand = "unknown" // The catalog has no band for the TLD
+)
+
+// PriceBands lists every price band, for help text and validation
+var PriceBands = []PriceBand{PriceBandBudget, PriceBandStandard, PriceBandPremium, PriceBandUnknown}
+
+// TLDInfo describes one TLD
+type TLDInfo struct {
+	TLD          string           `json:"tld"` // A-label
+	Categories   []TLDCategory    `json:"categories"`
+	RDAP         bool             `json:"rdap,omitempty"` // Listed in the IANA RDAP bootstrap
+	Restrictions []TLDRestriction `json:"restrictions,omitempty"`
+	PriceBand    PriceBand        `json:"price_band,omitempty"`
+}
+
+// HasCategory reports whether the TLD is in category
+func (t TLDInfo) HasCategory(category TLDCategory) bool {
+	return slices.Contains(t.Categories, category)
+}
+
+// Band returns the TLD's price band, PriceBandUnknown when it has none
+func (t TLDInfo) Band() PriceBand {
+	if t.PriceBand == "" {
+		return PriceBandUnknown
+	}
+	return t.PriceBand
+}
+
+// Restricted reports whether anyone is kept from registering in the TLD
+func (t TLDInfo) Restricted() bool {
+	for _, restriction := range t.Restrictions {
+		if restriction.LimitsRegistration() {
+			return true
+		}
+	}
+	return false
+}
+
+// TLDCatalog is the list of delegated TLDs and what is known about them
+type TLDCatalog struct {
+	Updated time.Time `json:"updated"`
+	Source  string    `json:"source,omitempty"`
+	TLDs    []TLDInfo `json:"tlds"` // Sorted by TLD
+
+	index map[string]int
+}
+
+//go:embed tlds.json
+var bundledTLDs []byte
+
+var (
+	bundledCatalog     *TLDCatalog
+	bundledCatalogOnce sync.Once
+)
+
+// BundledTLDCatalog returns the catalog shipped with indietool
+func BundledTLDCatalog() *TLDCatalog {
+	bundledCatalogOnce.Do(func() {
+		catalog, err := parseTLDCatalog(bundledTLDs)
+		if err != nil {
+			panic(fmt.Sprintf("bundled TLD catalog is invalid: %v", err))
+		}
+		bundledCatalog = catalog
+	})
+	return bundledCatalog
+}
+
+// LoadTLDCatalog reads the catalog at path, as written by an update. A
+// missing file yields the bundled catalog.
+func LoadTLDCatalog(path string) (*TLDCatalog, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return BundledTLDCatalog(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLD catalog: %w", err)
+	}
+
+	catalog, err := parseTLDCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TLD catalog %s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+func parseTLDCatalog(data []byte) (*TLDCatalog, error) {
+	catalog := &TLDCatalog{}
+	if err := json.Unmarshal(data, catalog); err != nil {
+		return nil, err
+	}
+	catalog.reindex()
+	return catalog, nil
+}
+
+// Save writes the catalog to path, creating parent directories as needed
+func (c *TLDCatalog) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode TLD catalog: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create TLD catalog directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (c *TLDCatalog) reindex() {
+	sort.Slice(c.TLDs, func(i, j int) bool { return c.TLDs[i].TLD < c.TLDs[j].TLD })
+	c.index = make(map[string]int, len(c.TLDs))
+	for i, info := range c.TLDs {
+		c.index[info.TLD] = i
+	}
+}
+
+// Get returns what is known about tld, given with or without a leading dot
+func (c *TLDCatalog) Get(tld string) (TLDInfo, bool) {
+	i, ok := c.index[strings.ToLower(strings.TrimPrefix(tld, "."))]
+	if !ok {
+		return TLDInfo{}, false
+	}
+	return c.TLDs[i], true
+}
+
+// TLDFilter selects TLDs from a catalog
+type TLDFilter struct {
+	Categories        []TLDCategory // TLDs in any of these; all TLDs when empty
+	PriceBands        []PriceBand   // TLDs in any of these bands; all TLDs when empty
+	ExcludeRestricted bool          // Leave out TLDs not everyone can register in
+}
+
+// Matches reports whether info passes the filter
+func (f TLDFilter) Matches(info TLDInfo) bool {
+	if f.ExcludeRestricted && info.Restricted() {
+		return false
+	}
+	if len(f.PriceBands) > 0 && !slices.Contains(f.PriceBands, info.Band()) {
+		return false
+	}
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, category := range f.Categories {
+		if info.HasCategory(category) {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the TLDs matching filter, the popular ones first in
+// PopularTLDs order and the rest alphabetically
+func (c *TLDCatalog) Select(filter TLDFilter) []string {
+	var popular, rest []string
+	for _, info := range c.TLDs {
+		if !filter.Matches(info) {
+			continue
+		}
+		if slices.Contains(PopularTLDs, info.TLD) {
+			popular = append(popular, info.TLD)
+		} else {
+			rest = append(rest, info.TLD)
+		}
+	}
+	sort.Slice(popular, func(i, j int) bool {
+		return slices.Index(PopularTLDs, popular[i]) < slices.Index(PopularTLDs, popular[j])
+	})
+	return append(popular, rest...)
+}
+
+// Filter keeps the TLDs in tlds that match filter, in their order. TLDs
+// missing from the catalog only pass when no categories are asked for, and
+// count as PriceBandUnknown.
+func (c *TLDCatalog) Filter(tlds []string, filter TLDFilter) []string {
+	var kept []string
+	for _, tld := range tlds {
+		info, ok := c.Get(tld)
+		if !ok {
+			if len(filter.Categories) == 0 && (len(filter.PriceBands) == 0 || slices.Contains(filter.PriceBands, PriceBandUnknown)) {
+				kept = append(kept, tld)
+			}
+			continue
+		}
+		if filter.Matches(info) {
+			kept = append(kept, tld)
+		}
+	}
+	return kept
+}
+
+// ParseTLDCategories parses a list of category names
+func ParseTLDCategories(names []string) ([]TLDCategory, error) {
+	categories := make([]TLDCategory, 0, len(names))
+	for _, name := range names {
+		category := TLDCategory(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(TLDCategories, category) {
+			valid := make([]string, len(TLDCategories))
+			for i, c := range TLDCategories {
+				valid[i] = string(c)
+			}
+			return nil, fmt.Errorf("unknown TLD category %q (use %s)", name, strings.Join(valid, ", "))
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// ParsePriceBands parses a list of price band names
+func ParsePriceBands(names []string) ([]PriceBand, error) {
+	bands := make([]PriceBand, 0, len(names))
+	for _, name := range names {
+		band := PriceBand(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(PriceBands, band) {
+			valid := make([]string, len(PriceBands))
+			for i, b := range PriceBands {
+				valid[i] = string(b)
+			}
+			return nil, fmt.Errorf("unknown price band %q (use %s)", name, strings.Join(valid, ", "))
+		}
+		bands = append(bands, band)
+	}
+	return bands, nil
+}
+
+// IANA sources for catalog updates
+var (
+	IANATLDListURL       = "https://data.iana.org/TLD/tlds-alpha-by-domain.txt"
+	IANARDAPBootstrapURL = "https://data.iana.org/rdap/dns.json"
+)
+
+// TLDCatalogChanges summarises an update
+type TLDCatalogChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	RDAP    []string `json:"rdap"` // TLDs whose RDAP support changed
+}
+
+// UpdateTLDCatalog builds a new catalog from IANA's current TLD list and
+// RDAP bootstrap, keeping what base knows about TLDs that still exist. New
+// TLDs are classed from their name alone: two letters are a country, the
+// rest generic.
+func UpdateTLDCatalog(ctx context.Context, client *http.Client, base *TLDCatalog, now time.Time) (*TLDCatalog, TLDCatalogChanges, error) {
+	var changes TLDCatalogChanges
+
+	tlds, err := fetchTLDList(ctx, client)
+	if err != nil {
+		return nil, changes, err
+	}
+	rdapTLDs, err := fetchRDAPTLDs(ctx, client)
+	if err != nil {
+		return nil, changes, err
+	}
+
+	updated := &TLDCatalog{Updated: now, Source: "IANA TLD list and RDAP bootstrap"}
+	seen := make(map[string]bool, len(tlds))
+	for _, tld := range tlds {
+		seen[tld] = true
+		info, ok := base.Get(tld)
+		if !ok {
+			changes.Added = append(changes.Added, tld)
+			info = TLDInfo{TLD: tld, Categories: []TLDCategory{CategoryGeneric}}
+			if len(tld) == 2 {
+				info.Categories = []TLDCategory{CategoryCountry}
+			}
+		}
+		if ok && info.RDAP != rdapTLDs[tld] {
+			changes.RDAP = append(changes.RDAP, tld)
+		}
+		info.RDAP = rdapTLDs[tld]
+		updated.TLDs = append(updated.TLDs, info)
+	}
+	for _, info := range base.TLDs {
+		if !seen[info.TLD] {
+			changes.Removed = append(changes.Removed, info.TLD)
+		}
+	}
+
+	updated.reindex()
+	return updated, changes, nil
+}
+
+// fetchTLDList reads IANA's list of delegated TLDs
+func fetchTLDList(ctx context.Context, client *http.Client) ([]string, error) {
+	resp, err := ianaGet(ctx, client, IANATLDListURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the TLD list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tlds []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tlds = append(tlds, strings.ToLower(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the TLD list: %w", err)
+	}
+	if len(tlds) == 0 {
+		return nil, fmt.Errorf("the TLD list is empty")
+	}
+	return tlds, nil
+}
+
+// fetchRDAPTLDs reads the TLDs with an RDAP server from IANA's bootstrap
+func fetchRDAPTLDs(ctx context.Context, client *http.Client) (map[string]bool, error) {
+	resp, err := ianaGet(ctx, client, IANARDAPBootstrapURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the RDAP bootstrap: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var bootstrap struct {
+		Services [][][]string `json:"services"` // [[tlds...], [urls...]]
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&bootstrap); err != nil {
+		return nil, fmt.Errorf("failed to parse the RDAP bootstrap: %w", err)
+	}
+
+	tlds := make(map[string]bool)
+	for _, service := range bootstrap.Services {
+		if len(service) < 2 || len(service[1]) == 0 {
+			continue
+		}
+		for _, tld := range service[0] {
+			tlds[strings.ToLower(tld)] = true
+		}
+	}
+	return tlds, nil
+}
+
+func ianaGet(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return resp, nil
+}
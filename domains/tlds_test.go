@@ -0,0 +1,126 @@
+package domains
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBundledTLDCatalog(t *testing.T) {
+	catalog := BundledTLDCatalog()
+	if len(catalog.TLDs) < 1000 {
+		t.Fatalf("bundled catalog has %d TLDs", len(catalog.TLDs))
+	}
+	for _, tld := range PopularTLDs {
+		if _, ok := catalog.Get(tld); !ok {
+			t.Errorf("popular TLD %s is missing from the catalog", tld)
+		}
+	}
+
+	dev, _ := catalog.Get(".dev")
+	if !dev.HasCategory(CategoryTech) || dev.Restricted() || dev.Restrictions[0] != RestrictionHSTSPreload {
+		t.Errorf("dev = %+v, want tech, HSTS preloaded and open", dev)
+	}
+	if ca, _ := catalog.Get("ca"); !ca.HasCategory(CategoryCountry) || !ca.Restricted() {
+		t.Errorf("ca = %+v, want a restricted country TLD", ca)
+	}
+	if google, _ := catalog.Get("google"); !google.HasCategory(CategoryBrand) || !google.Restricted() {
+		t.Errorf("google = %+v, want a closed brand TLD", google)
+	}
+}
+
+func TestTLDCatalogFilter(t *testing.T) {
+	catalog := BundledTLDCatalog()
+
+	tech := catalog.Select(TLDFilter{Categories: []TLDCategory{CategoryTech}})
+	if len(tech) < 5 || tech[0] != "dev" || tech[1] != "app" {
+		t.Errorf("tech TLDs = %v, want the popular ones first", tech)
+	}
+
+	got := catalog.Filter([]string{"com", "ca", "google", "example", "io"}, TLDFilter{ExcludeRestricted: true})
+	if want := []string{"com", "example", "io"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+	got = catalog.Filter([]string{"com", "example", "io"}, TLDFilter{Categories: []TLDCategory{CategoryCountry}})
+	if want := []string{"io"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter by category = %v, want %v", got, want)
+	}
+
+	if _, err := ParseTLDCategories([]string{"tech", "cheap"}); err == nil {
+		t.Error("unknown category accepted")
+	}
+
+	// TLDs without a band count as unknown rather than dropping out
+	budget := TLDFilter{PriceBands: []PriceBand{PriceBandBudget}}
+	unknown := TLDFilter{PriceBands: []PriceBand{PriceBandBudget, PriceBandUnknown}}
+	banded, _ := catalog.Get("xyz")
+	unbanded := TLDInfo{TLD: "kopi", Categories: []TLDCategory{CategoryGeneric}}
+	if banded.Band() != PriceBandBudget || unbanded.Band() != PriceBandUnknown {
+		t.Errorf("bands = %s, %s; want budget, unknown", banded.Band(), unbanded.Band())
+	}
+	if !budget.Matches(banded) || budget.Matches(unbanded) || !unknown.Matches(unbanded) {
+		t.Error("price band filter misjudged a TLD")
+	}
+	if got := catalog.Filter([]string{"xyz", "notatld"}, unknown); !reflect.DeepEqual(got, []string{"xyz", "notatld"}) {
+		t.Errorf("Filter by unknown band = %v, want TLDs missing from the catalog kept", got)
+	}
+	if _, err := ParsePriceBands([]string{"budget", "free"}); err == nil {
+		t.Error("unknown price band accepted")
+	}
+}
+
+func TestUpdateTLDCatalog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tlds.txt":
+			io.WriteString(w, "# Version 2026101800\nCOM\nDEV\nZZ\nKOPI\n")
+		case "/dns.json":
+			io.WriteString(w, `{"services":[[["com","kopi"],["https://rdap.example/"]]]}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	oldList, oldRDAP := IANATLDListURL, IANARDAPBootstrapURL
+	IANATLDListURL, IANARDAPBootstrapURL = srv.URL+"/tlds.txt", srv.URL+"/dns.json"
+	t.Cleanup(func() { IANATLDListURL, IANARDAPBootstrapURL = oldList, oldRDAP })
+
+	base, err := parseTLDCatalog([]byte(`{"tlds":[
+		{"tld":"dev","categories":["generic","tech"],"rdap":true,"restrictions":["hsts-preload"]},
+		{"tld":"com","categories":["generic"],"rdap":true},
+		{"tld":"gone","categories":["generic"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, changes, err := UpdateTLDCatalog(context.Background(), srv.Client(), base, time.Now())
+	if err != nil {
+		t.Fatalf("UpdateTLDCatalog: %v", err)
+	}
+	if !reflect.DeepEqual(changes.Added, []string{"zz", "kopi"}) || !reflect.DeepEqual(changes.Removed, []string{"gone"}) || !reflect.DeepEqual(changes.RDAP, []string{"dev"}) {
+		t.Errorf("changes = %+v", changes)
+	}
+	if dev, _ := updated.Get("dev"); dev.RDAP || !dev.HasCategory(CategoryTech) {
+		t.Errorf("dev = %+v, want its metadata kept and RDAP off", dev)
+	}
+	if zz, _ := updated.Get("zz"); !zz.HasCategory(CategoryCountry) {
+		t.Errorf("zz = %+v, want a country TLD", zz)
+	}
+
+	// A saved catalog replaces the bundled one
+	path := filepath.Join(t.TempDir(), "tlds.json")
+	if err := updated.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTLDCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kopi, ok := loaded.Get("kopi"); !ok || !kopi.RDAP {
+		t.Errorf("kopi = %+v, %v after reload", kopi, ok)
+	}
+}
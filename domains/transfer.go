@@ -0,0 +1,329 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TransferLocker is implemented by registrars that expose a domain's
+// transfer lock. SetTransferLock wraps ErrNotSupported when the lock can
+// only be changed in the registrar's web UI.
+type TransferLocker interface {
+	GetTransferLock(ctx context.Context, name string) (bool, error)
+	SetTransferLock(ctx context.Context, name string, locked bool) error
+}
+
+// AuthCodeProvider is implemented by registrars that hand out the auth
+// (EPP) code needed to transfer a domain away
+type AuthCodeProvider interface {
+	GetAuthCode(ctx context.Context, name string) (string, error)
+}
+
+// TransferInitiator is implemented by registrars that can start an inbound
+// transfer. It returns the registrar's reference for the transfer order.
+type TransferInitiator interface {
+	InitiateTransfer(ctx context.Context, name, authCode string) (string, error)
+}
+
+// TransferStep is a stage of a registrar transfer
+type TransferStep string
+
+const (
+	TransferStepUnlock   TransferStep = "unlock"    // Remove the transfer lock at the losing registrar
+	TransferStepAuthCode TransferStep = "auth-code" // Obtain the auth code from the losing registrar
+	TransferStepInitiate TransferStep = "initiate"  // Request the transfer at the gaining registrar
+	TransferStepAwait    TransferStep = "await"     // Wait for the registry to complete it
+	TransferStepDone     TransferStep = "done"
+)
+
+// TransferEvent records something that happened during a transfer
+type TransferEvent struct {
+	At      time.Time    `json:"at"`
+	Step    TransferStep `json:"step"`
+	Message string       `json:"message"`
+}
+
+// Transfer tracks a domain moving between registrars so it can be resumed
+// across runs
+type Transfer struct {
+	Domain      string          `json:"domain"`
+	From        string          `json:"from"`
+	To          string          `json:"to"`
+	Step        TransferStep    `json:"step"`
+	Waiting     string          `json:"waiting,omitempty"` // What the transfer is waiting for, if anything
+	AuthCode    string          `json:"auth_code,omitempty"`
+	OrderID     string          `json:"order_id,omitempty"` // Gaining registrar's reference
+	StartedAt   time.Time       `json:"started_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	InitiatedAt time.Time       `json:"initiated_at,omitzero"`
+	CompletedAt time.Time       `json:"completed_at,omitzero"`
+	Events      []TransferEvent `json:"events"`
+}
+
+// Done reports whether the domain has arrived at the gaining registrar
+func (t *Transfer) Done() bool {
+	return t.Step == TransferStepDone
+}
+
+func (t *Transfer) advance(step TransferStep, message string, now time.Time) {
+	t.Events = append(t.Events, TransferEvent{At: now, Step: t.Step, Message: message})
+	t.Step = step
+	t.Waiting = ""
+	t.UpdatedAt = now
+}
+
+func (t *Transfer) wait(reason string, now time.Time) {
+	t.Waiting = reason
+	t.UpdatedAt = now
+}
+
+// TransferStore persists transfers in progress. Auth codes are stored too,
+// so the file is only readable by its owner.
+type TransferStore struct {
+	Transfers map[string]*Transfer `json:"transfers"`
+}
+
+// LoadTransfers reads the store at path. A missing file yields an empty
+// store.
+func LoadTransfers(path string) (*TransferStore, error) {
+	store := &TransferStore{Transfers: make(map[string]*Transfer)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transfers: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse transfers %s: %w", path, err)
+	}
+	if store.Transfers == nil {
+		store.Transfers = make(map[string]*Transfer)
+	}
+	return store, nil
+}
+
+// Save writes the store to path, creating parent directories as needed
+func (s *TransferStore) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transfers: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create transfers directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Get returns the transfer of domain, if one is tracked
+func (s *TransferStore) Get(domain string) (*Transfer, bool) {
+	t, ok := s.Transfers[strings.ToLower(domain)]
+	return t, ok
+}
+
+// Start begins tracking a transfer of domain from one registrar to another
+func (s *TransferStore) Start(domain, from, to string, now time.Time) *Transfer {
+	t := &Transfer{
+		Domain:    strings.ToLower(domain),
+		From:      from,
+		To:        to,
+		Step:      TransferStepUnlock,
+		StartedAt: now,
+		UpdatedAt: now,
+		Events:    []TransferEvent{},
+	}
+	s.Transfers[t.Domain] = t
+	return t
+}
+
+// Remove stops tracking the transfer of domain
+func (s *TransferStore) Remove(domain string) bool {
+	domain = strings.ToLower(domain)
+	_, ok := s.Transfers[domain]
+	delete(s.Transfers, domain)
+	return ok
+}
+
+// List returns the tracked transfers sorted by domain
+func (s *TransferStore) List() []*Transfer {
+	transfers := make([]*Transfer, 0, len(s.Transfers))
+	for _, t := range s.Transfers {
+		transfers = append(transfers, t)
+	}
+	sort.Slice(transfers, func(i, j int) bool { return transfers[i].Domain < transfers[j].Domain })
+	return transfers
+}
+
+// TransferOptions controls how far AdvanceTransfer may go on its own
+type TransferOptions struct {
+	// AuthCode supplies the auth code when the losing registrar can't
+	AuthCode string
+
+	// Approve is asked before anything is changed at a registrar. Returning
+	// false pauses the transfer. A nil Approve approves everything.
+	Approve func(action string) bool
+
+	// Confirm is asked whether the user completed a step that has to be done
+	// by hand. Returning false pauses the transfer until the next run.
+	Confirm func(instructions string) bool
+
+	Now time.Time
+}
+
+// AdvanceTransfer runs the steps of t that can be completed now, using the
+// registrars' APIs where they allow and the user otherwise. It stops when
+// the transfer is done or waiting, with t.Waiting describing what for.
+// Progress made before an error is kept in t.
+func (d *Manager) AdvanceTransfer(ctx context.Context, t *Transfer, options TransferOptions) error {
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	approve := func(action string) bool { return options.Approve == nil || options.Approve(action) }
+	confirm := func(instructions string) bool { return options.Confirm != nil && options.Confirm(instructions) }
+
+	from, ok := d.Registrar(t.From)
+	if !ok {
+		return fmt.Errorf("provider %s is not configured", t.From)
+	}
+	to, ok := d.Registrar(t.To)
+	if !ok {
+		return fmt.Errorf("provider %s is not configured", t.To)
+	}
+
+	for !t.Done() {
+		switch t.Step {
+		case TransferStepUnlock:
+			unlocked, err := unlockForTransfer(ctx, from, t, approve, confirm, now)
+			if err != nil {
+				return err
+			}
+			if !unlocked {
+				return nil
+			}
+
+		case TransferStepAuthCode:
+			switch {
+			case options.AuthCode != "":
+				t.AuthCode = strings.TrimSpace(options.AuthCode)
+				t.advance(TransferStepInitiate, "Auth code provided", now)
+				continue
+			case t.AuthCode != "":
+				t.advance(TransferStepInitiate, "Auth code already known", now)
+				continue
+			}
+
+			if provider, ok := from.(AuthCodeProvider); ok {
+				code, err := provider.GetAuthCode(ctx, t.Domain)
+				if err == nil && code != "" {
+					t.AuthCode = code
+					t.advance(TransferStepInitiate, fmt.Sprintf("Retrieved auth code from %s", t.From), now)
+					continue
+				}
+				if err != nil && !errors.Is(err, ErrNotSupported) {
+					return fmt.Errorf("failed to get auth code from %s: %w", t.From, err)
+				}
+			}
+			t.wait(fmt.Sprintf("Request the auth (EPP) code for %s from %s; it is usually emailed to the registrant. Then resume with --auth-code.", t.Domain, t.From), now)
+			return nil
+
+		case TransferStepInitiate:
+			initiator, ok := to.(TransferInitiator)
+			if !ok {
+				manual := fmt.Sprintf("Start the transfer of %s at %s using the auth code.", t.Domain, t.To)
+				if !confirm(manual) {
+					t.wait(manual, now)
+					return nil
+				}
+				t.InitiatedAt = now
+				t.advance(TransferStepAwait, fmt.Sprintf("Transfer started at %s by hand", t.To), now)
+				continue
+			}
+
+			if !approve(fmt.Sprintf("Request the transfer of %s at %s (this usually charges a year's renewal)", t.Domain, t.To)) {
+				t.wait(fmt.Sprintf("Approve starting the transfer at %s", t.To), now)
+				return nil
+			}
+			orderID, err := initiator.InitiateTransfer(ctx, t.Domain, t.AuthCode)
+			if err != nil {
+				return fmt.Errorf("failed to start transfer at %s: %w", t.To, err)
+			}
+			t.OrderID = orderID
+			t.InitiatedAt = now
+			message := fmt.Sprintf("Transfer requested at %s", t.To)
+			if orderID != "" {
+				message += fmt.Sprintf(" (order %s)", orderID)
+			}
+			t.advance(TransferStepAwait, message, now)
+
+		case TransferStepAwait:
+			if _, err := to.GetDomain(ctx, t.Domain); err != nil {
+				t.wait(fmt.Sprintf("Waiting for the registry; transfers usually complete within 5-7 days, or sooner if you approve the outgoing transfer at %s.", t.From), now)
+				return nil
+			}
+			t.CompletedAt = now
+			t.AuthCode = ""
+			t.advance(TransferStepDone, fmt.Sprintf("%s is now at %s", t.Domain, t.To), now)
+
+		default:
+			return fmt.Errorf("unknown transfer step %q", t.Step)
+		}
+	}
+
+	return nil
+}
+
+// unlockForTransfer removes the transfer lock at the losing registrar,
+// falling back to the user where its API can't. It reports whether the step
+// is complete.
+func unlockForTransfer(ctx context.Context, from Registrar, t *Transfer, approve, confirm func(string) bool, now time.Time) (bool, error) {
+	manual := fmt.Sprintf("Remove the transfer lock of %s in the %s dashboard.", t.Domain, t.From)
+
+	locker, ok := from.(TransferLocker)
+	if !ok {
+		if !confirm(manual) {
+			t.wait(manual, now)
+			return false, nil
+		}
+		t.advance(TransferStepAuthCode, "Transfer lock removed by hand", now)
+		return true, nil
+	}
+
+	locked, err := locker.GetTransferLock(ctx, t.Domain)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transfer lock from %s: %w", t.From, err)
+	}
+	if !locked {
+		t.advance(TransferStepAuthCode, "Transfer lock is off", now)
+		return true, nil
+	}
+
+	if !approve(fmt.Sprintf("Remove the transfer lock of %s at %s", t.Domain, t.From)) {
+		t.wait(fmt.Sprintf("Approve removing the transfer lock at %s", t.From), now)
+		return false, nil
+	}
+
+	err = locker.SetTransferLock(ctx, t.Domain, false)
+	switch {
+	case errors.Is(err, ErrNotSupported):
+		if !confirm(manual) {
+			t.wait(manual, now)
+			return false, nil
+		}
+		t.advance(TransferStepAuthCode, "Transfer lock removed by hand", now)
+	case err != nil:
+		return false, fmt.Errorf("failed to remove transfer lock at %s: %w", t.From, err)
+	default:
+		t.advance(TransferStepAuthCode, fmt.Sprintf("Removed transfer lock at %s", t.From), now)
+	}
+	return true, nil
+}
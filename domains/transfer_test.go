@@ -0,0 +1,138 @@
+package domains
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// transferFake is a registrar with every transfer capability
+type transferFake struct {
+	fakeRegistrar
+	locked      map[string]bool
+	lockErr     error
+	authCode    string
+	transferred []string
+	arrived     bool
+}
+
+func (f *transferFake) GetTransferLock(ctx context.Context, name string) (bool, error) {
+	return f.locked[name], nil
+}
+
+func (f *transferFake) SetTransferLock(ctx context.Context, name string, locked bool) error {
+	if f.lockErr != nil {
+		return f.lockErr
+	}
+	f.locked[name] = locked
+	return nil
+}
+
+func (f *transferFake) GetAuthCode(ctx context.Context, name string) (string, error) {
+	return f.authCode, nil
+}
+
+func (f *transferFake) InitiateTransfer(ctx context.Context, name, authCode string) (string, error) {
+	f.transferred = append(f.transferred, name+":"+authCode)
+	return "order-1", nil
+}
+
+func (f *transferFake) GetDomain(ctx context.Context, name string) (*ManagedDomain, error) {
+	if !f.arrived {
+		return nil, fmt.Errorf("domain %s not found", name)
+	}
+	return &ManagedDomain{Name: name, Provider: f.name}, nil
+}
+
+func TestAdvanceTransferAutomated(t *testing.T) {
+	from := &transferFake{fakeRegistrar: fakeRegistrar{name: "old"}, locked: map[string]bool{"example.com": true}, authCode: "EPP-123"}
+	to := &transferFake{fakeRegistrar: fakeRegistrar{name: "new"}, locked: map[string]bool{}}
+	manager := NewManager([]Registrar{from, to})
+
+	path := filepath.Join(t.TempDir(), "transfers.json")
+	store, _ := LoadTransfers(path)
+	transfer := store.Start("Example.com", "old", "new", testNow)
+
+	var approved []string
+	options := TransferOptions{Approve: func(action string) bool {
+		approved = append(approved, action)
+		return true
+	}, Now: testNow}
+
+	if err := manager.AdvanceTransfer(context.Background(), transfer, options); err != nil {
+		t.Fatalf("AdvanceTransfer: %v", err)
+	}
+	if from.locked["example.com"] {
+		t.Error("transfer lock not removed at the losing registrar")
+	}
+	if len(to.transferred) != 1 || to.transferred[0] != "example.com:EPP-123" {
+		t.Errorf("transfer requests = %v", to.transferred)
+	}
+	if len(approved) != 2 {
+		t.Errorf("approvals asked = %v, want unlock and initiate", approved)
+	}
+	if transfer.Step != TransferStepAwait || transfer.Waiting == "" || transfer.OrderID != "order-1" {
+		t.Fatalf("transfer = %+v, want waiting for the registry", transfer)
+	}
+
+	// Progress survives a restart and completes once the domain arrives
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	store, _ = LoadTransfers(path)
+	transfer, ok := store.Get("example.com")
+	if !ok {
+		t.Fatal("transfer not persisted")
+	}
+
+	to.arrived = true
+	if err := manager.AdvanceTransfer(context.Background(), transfer, TransferOptions{Now: testNow}); err != nil {
+		t.Fatalf("AdvanceTransfer: %v", err)
+	}
+	if !transfer.Done() || transfer.AuthCode != "" || transfer.CompletedAt.IsZero() {
+		t.Errorf("transfer = %+v, want done with the auth code cleared", transfer)
+	}
+	if len(transfer.Events) != 4 {
+		t.Errorf("got %d events, want one per step", len(transfer.Events))
+	}
+}
+
+func TestAdvanceTransferManualSteps(t *testing.T) {
+	// The losing registrar can't unlock or hand out auth codes, the gaining
+	// one can't start transfers
+	from := &transferFake{fakeRegistrar: fakeRegistrar{name: "old"}, locked: map[string]bool{"example.com": true}, lockErr: fmt.Errorf("unlock: %w", ErrNotSupported)}
+	to := &fakeRegistrar{name: "new"}
+	manager := NewManager([]Registrar{from, to})
+
+	store := &TransferStore{Transfers: map[string]*Transfer{}}
+	transfer := store.Start("example.com", "old", "new", testNow)
+
+	// Without confirmation the transfer pauses at the unlock step
+	if err := manager.AdvanceTransfer(context.Background(), transfer, TransferOptions{Now: testNow}); err != nil {
+		t.Fatalf("AdvanceTransfer: %v", err)
+	}
+	if transfer.Step != TransferStepUnlock || transfer.Waiting == "" {
+		t.Fatalf("transfer = %+v, want waiting for a manual unlock", transfer)
+	}
+
+	// Confirming the unlock moves on; the auth code is still missing
+	confirmAll := func(string) bool { return true }
+	if err := manager.AdvanceTransfer(context.Background(), transfer, TransferOptions{Confirm: confirmAll, Now: testNow}); err != nil {
+		t.Fatalf("AdvanceTransfer: %v", err)
+	}
+	if transfer.Step != TransferStepAuthCode {
+		t.Fatalf("step = %s, want auth-code", transfer.Step)
+	}
+
+	err := manager.AdvanceTransfer(context.Background(), transfer, TransferOptions{AuthCode: " EPP-9 ", Confirm: confirmAll, Now: testNow})
+	if err != nil {
+		t.Fatalf("AdvanceTransfer: %v", err)
+	}
+	if transfer.AuthCode != "EPP-9" || transfer.Step != TransferStepAwait || transfer.InitiatedAt.IsZero() {
+		t.Errorf("transfer = %+v, want a manually started transfer awaiting completion", transfer)
+	}
+}
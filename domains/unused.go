@@ -0,0 +1,512 @@
+package domains
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"indietool/cli/dns"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UsageSignal is a sign that a domain serves nothing
+type UsageSignal string
+
+const (
+	SignalNoRecords     UsageSignal = "no-records"     // Nothing in DNS beyond NS, SOA, provider defaults and parking
+	SignalParked        UsageSignal = "parked"         // Only parking records, or a parking page
+	SignalNoHTTP        UsageSignal = "no-http"        // No web server answers
+	SignalNoMX          UsageSignal = "no-mx"          // Receives no mail
+	SignalNoCertificate UsageSignal = "no-certificate" // Nothing on 443 presents a certificate for it
+)
+
+// signalWeights is how much each signal adds to the unused score. The
+// weights of the signals a domain can show at once add up to 100.
+var signalWeights = map[UsageSignal]int{
+	SignalNoRecords:     40,
+	SignalParked:        40,
+	SignalNoHTTP:        30,
+	SignalNoMX:          15,
+	SignalNoCertificate: 15,
+}
+
+// DefaultUnusedMinScore is the score from which a domain is a candidate to
+// let expire
+const DefaultUnusedMinScore = 50
+
+// parkingHosts are nameserver, CNAME and redirect targets of well-known
+// parking services and registrars' default parking pages
+var parkingHosts = []string{
+	"parkingpage.namecheap.com",
+	"pixie.porkbun.com",
+	"uixie.porkbun.com",
+	"sedoparking.com",
+	"bodis.com",
+	"parkingcrew.net",
+	"above.com",
+	"afternic.com",
+	"dan.com",
+	"parklogic.com",
+	"parked.com",
+}
+
+// redirectTypes are the record types DNS hosts use for web redirects
+var redirectTypes = map[string]bool{"URL": true, "URL301": true, "URL302": true, "FRAME": true, "REDIRECT": true}
+
+// parkingAddresses are the addresses of well-known registrar parking pages
+var parkingAddresses = []string{
+	"34.102.136.180", // GoDaddy
+	"34.98.99.30",    // GoDaddy
+}
+
+// parkingPhrases mark a web page as a parking or for-sale page
+var parkingPhrases = []string{
+	"domain is for sale",
+	"domain may be for sale",
+	"buy this domain",
+	"this domain is parked",
+	"parked free",
+	"domain parking",
+}
+
+// UsageEvidence is one signal found for a domain, with what was seen
+type UsageEvidence struct {
+	Signal UsageSignal `json:"signal"`
+	Detail string      `json:"detail"`
+}
+
+// UnusedCandidate is a managed domain scored on how unused it looks
+type UnusedCandidate struct {
+	Domain       string          `json:"domain"`
+	Provider     string          `json:"provider"`
+	Score        int             `json:"score"` // 0 (in use) to 100 (serves nothing)
+	Evidence     []UsageEvidence `json:"evidence"`
+	RenewalDate  time.Time       `json:"renewal_date"`
+	DaysToRenew  int             `json:"days_to_renewal"`
+	AutoRenewal  bool            `json:"auto_renewal"`
+	RenewalPrice float64         `json:"renewal_price,omitempty"`
+	Currency     string          `json:"currency,omitempty"`
+	Project      string          `json:"project,omitempty"`
+	Action       PlannedAction   `json:"action,omitempty"`
+	Error        string          `json:"error,omitempty"` // Why the domain couldn't be checked
+}
+
+// Signals lists the signals found, in the order they were checked
+func (c UnusedCandidate) Signals() []string {
+	signals := make([]string, len(c.Evidence))
+	for i, evidence := range c.Evidence {
+		signals[i] = string(evidence.Signal)
+	}
+	return signals
+}
+
+// HTTPProbe is what a web request to a domain got back
+type HTTPProbe struct {
+	URL    string // Final URL after redirects
+	Status int
+	Parked bool // The page or redirect target is a parking page
+}
+
+// UsageResolver answers the public DNS lookups behind the unused checks.
+// *net.Resolver implements it.
+type UsageResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// UnusedOptions configures FindUnusedDomains
+type UnusedOptions struct {
+	// ZoneRecords lists a domain's records at its DNS host when indietool
+	// manages it. When nil or failing, public DNS is used instead.
+	ZoneRecords func(ctx context.Context, domain string) ([]dns.Record, error)
+
+	// Concurrency bounds how many domains are checked at once (default 8)
+	Concurrency int
+
+	// Now is when renewals are counted from (default time.Now)
+	Now time.Time
+
+	// Resolver, ProbeHTTP and ProbeTLS default to public DNS and real
+	// connections; tests replace them
+	Resolver  UsageResolver
+	ProbeHTTP func(ctx context.Context, url string) (*HTTPProbe, error)
+	ProbeTLS  func(ctx context.Context, host string) error
+}
+
+// FindUnusedDomains checks every domain for signs that it serves nothing and
+// returns them scored, the most likely candidates to let expire first. Ties
+// are broken by renewal price, then by how soon the domain renews.
+func FindUnusedDomains(ctx context.Context, domainList []ManagedDomain, options UnusedOptions) []UnusedCandidate {
+	if options.Resolver == nil {
+		options.Resolver = net.DefaultResolver
+	}
+	if options.ProbeHTTP == nil {
+		options.ProbeHTTP = probeHTTP
+	}
+	if options.ProbeTLS == nil {
+		options.ProbeTLS = probeTLS
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	candidates := make([]UnusedCandidate, len(domainList))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, options.Concurrency)
+	for i, domain := range domainList {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			candidates[i] = assessUsage(ctx, domain, options)
+		}()
+	}
+	wg.Wait()
+
+	RankUnusedCandidates(candidates)
+	return candidates
+}
+
+// RankUnusedCandidates sorts candidates by score, then renewal price, then
+// renewal date. Domains that couldn't be checked go last.
+func RankUnusedCandidates(candidates []UnusedCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch {
+		case (a.Error == "") != (b.Error == ""):
+			return a.Error == ""
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		case a.RenewalPrice != b.RenewalPrice:
+			return a.RenewalPrice > b.RenewalPrice
+		case !a.RenewalDate.Equal(b.RenewalDate):
+			return a.RenewalDate.Before(b.RenewalDate)
+		default:
+			return a.Domain < b.Domain
+		}
+	})
+}
+
+func assessUsage(ctx context.Context, domain ManagedDomain, options UnusedOptions) UnusedCandidate {
+	candidate := UnusedCandidate{
+		Domain:      domain.Name,
+		Provider:    domain.Provider,
+		Evidence:    []UsageEvidence{},
+		RenewalDate: domain.ExpiryDate,
+		DaysToRenew: int(domain.ExpiryDate.Sub(options.Now).Hours() / 24),
+		AutoRenewal: domain.AutoRenewal,
+		Project:     domain.Project,
+		Action:      domain.Action,
+	}
+	if domain.Cost != nil {
+		candidate.RenewalPrice = domain.Cost.RenewalPrice
+		candidate.Currency = domain.Cost.Currency
+	}
+
+	add := func(signal UsageSignal, format string, args ...interface{}) {
+		for _, evidence := range candidate.Evidence {
+			if evidence.Signal == signal {
+				return
+			}
+		}
+		candidate.Evidence = append(candidate.Evidence, UsageEvidence{Signal: signal, Detail: fmt.Sprintf(format, args...)})
+		candidate.Score += signalWeights[signal]
+	}
+
+	records, source, err := usageRecords(ctx, domain.Name, options)
+	if err != nil {
+		candidate.Error = err.Error()
+		return candidate
+	}
+
+	var meaningful, parking []dns.Record
+	hasMX := false
+	for _, record := range records {
+		if isProviderDefault(record, domain.Name) {
+			continue
+		}
+		switch strings.ToUpper(record.Type) {
+		case "NS", "SOA":
+			continue
+		case "MX":
+			if content := strings.TrimSuffix(record.Content, "."); content != "" {
+				hasMX = true
+			}
+		}
+		if isParkingRecord(record) {
+			parking = append(parking, record)
+		} else {
+			meaningful = append(meaningful, record)
+		}
+	}
+
+	switch {
+	case len(meaningful) == 0 && len(parking) > 0:
+		add(SignalParked, "only parking records in %s: %s", source, describeRecords(parking))
+	case len(meaningful) == 0 && source == publicDNS:
+		add(SignalNoRecords, "no address, CNAME, MX or TXT records for %s or www in public DNS", domain.Name)
+	case len(meaningful) == 0:
+		add(SignalNoRecords, "no records beyond NS, SOA and the DNS host's defaults in %s", source)
+	}
+	if !hasMX {
+		add(SignalNoMX, "no MX records in %s, so it receives no mail", source)
+	}
+
+	var httpErr error
+	responded := false
+	for _, url := range []string{"https://" + domain.Name, "http://" + domain.Name, "http://www." + domain.Name} {
+		probe, err := options.ProbeHTTP(ctx, url)
+		if err != nil {
+			httpErr = err
+			continue
+		}
+		responded = true
+		if probe.Parked {
+			add(SignalParked, "%s serves a parking page", probe.URL)
+		}
+		break
+	}
+	if !responded {
+		add(SignalNoHTTP, "no web server answered on http or https (%v)", httpErr)
+	}
+
+	if err := options.ProbeTLS(ctx, domain.Name); err != nil {
+		add(SignalNoCertificate, "no certificate for %s on port 443 (%v)", domain.Name, err)
+	}
+
+	if candidate.Score > 100 {
+		candidate.Score = 100
+	}
+	return candidate
+}
+
+// Where the records behind the unused checks came from
+const (
+	dnsZone   = "the DNS zone"
+	publicDNS = "public DNS"
+)
+
+// usageRecords returns the domain's DNS records from its DNS host when
+// indietool manages it, otherwise from public DNS, with where they came from
+func usageRecords(ctx context.Context, domain string, options UnusedOptions) ([]dns.Record, string, error) {
+	if options.ZoneRecords != nil {
+		if records, err := options.ZoneRecords(ctx, domain); err == nil {
+			return records, dnsZone, nil
+		}
+	}
+	records, err := publicRecords(ctx, options.Resolver, domain)
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't check DNS: %w", err)
+	}
+	return records, publicDNS, nil
+}
+
+// publicRecords looks up the records that show a domain is in use: the
+// addresses and CNAMEs of the apex and www, MX and TXT. Names that don't
+// exist just yield no records; other lookup failures are returned.
+func publicRecords(ctx context.Context, resolver UsageResolver, domain string) ([]dns.Record, error) {
+	var records []dns.Record
+	for _, name := range []string{domain, "www." + domain} {
+		label := "@"
+		if name != domain {
+			label = "www"
+		}
+
+		cname, err := resolver.LookupCNAME(ctx, name)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		if target := strings.TrimSuffix(cname, "."); err == nil && target != "" && !strings.EqualFold(target, name) {
+			// The addresses behind a CNAME are its target's, not the domain's
+			records = append(records, dns.Record{Type: "CNAME", Name: label, Content: target})
+			continue
+		}
+
+		addresses, err := resolver.LookupHost(ctx, name)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		for _, address := range addresses {
+			recordType := "A"
+			if strings.Contains(address, ":") {
+				recordType = "AAAA"
+			}
+			records = append(records, dns.Record{Type: recordType, Name: label, Content: address})
+		}
+	}
+
+	mxs, err := resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	for _, mx := range mxs {
+		records = append(records, dns.Record{Type: "MX", Name: "@", Content: mx.Host})
+	}
+
+	txts, err := resolver.LookupTXT(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	for _, txt := range txts {
+		records = append(records, dns.Record{Type: "TXT", Name: "@", Content: txt})
+	}
+	return records, nil
+}
+
+// isNotFound reports whether a lookup failed because the name or record
+// doesn't exist, as opposed to DNS being unreachable
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isParkingRecord reports whether record points at a parking service
+func isParkingRecord(record dns.Record) bool {
+	content := strings.ToLower(strings.TrimSuffix(record.Content, "."))
+	switch strings.ToUpper(record.Type) {
+	case "A", "AAAA":
+		for _, address := range parkingAddresses {
+			if content == address {
+				return true
+			}
+		}
+	case "CNAME", "ALIAS", "NS":
+		return isParkingHost(content)
+	}
+	if redirectTypes[strings.ToUpper(record.Type)] {
+		return isParkingHost(redirectHost(record.Content))
+	}
+	return false
+}
+
+// isProviderDefault reports whether record is one a DNS host adds to new
+// zones, such as Namecheap's redirect from the apex to www.<domain>
+func isProviderDefault(record dns.Record, domain string) bool {
+	if !redirectTypes[strings.ToUpper(record.Type)] {
+		return false
+	}
+	target, err := parseRedirect(record.Content)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(strings.TrimSuffix(target.Hostname(), "."))
+	return host == "www."+strings.ToLower(domain) && (target.Path == "" || target.Path == "/") && target.RawQuery == ""
+}
+
+// redirectHost returns the host a redirect record points at
+func redirectHost(content string) string {
+	target, err := parseRedirect(content)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSuffix(target.Hostname(), "."))
+}
+
+// parseRedirect parses a redirect record's target, which DNS hosts store
+// with or without a scheme
+func parseRedirect(content string) (*url.URL, error) {
+	content = strings.TrimSpace(content)
+	if !strings.Contains(content, "://") {
+		content = "http://" + content
+	}
+	return url.Parse(content)
+}
+
+// isParkingHost reports whether host belongs to a parking service
+func isParkingHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, parking := range parkingHosts {
+		if host == parking || strings.HasSuffix(host, "."+parking) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeRecords(records []dns.Record) string {
+	parts := make([]string, len(records))
+	for i, record := range records {
+		parts[i] = fmt.Sprintf("%s %s → %s", record.Type, record.Name, record.Content)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// probeHTTP requests url, following redirects, and checks whether it ends
+// on a parking page
+func probeHTTP(ctx context.Context, url string) (*HTTPProbe, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "indietool")
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	probe := &HTTPProbe{URL: resp.Request.URL.String(), Status: resp.StatusCode}
+	probe.Parked = isParkingHost(resp.Request.URL.Hostname()) || looksParked(string(body))
+	return probe, nil
+}
+
+// looksParked reports whether a web page reads like a parking page
+func looksParked(body string) bool {
+	body = strings.ToLower(body)
+	for _, phrase := range parkingPhrases {
+		if strings.Contains(body, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// probeTLS checks that host:443 presents a certificate valid for host. Its
+// expiry isn't checked: an expired certificate still shows the domain was
+// in use.
+func probeTLS(ctx context.Context, host string) error {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		// The certificate is inspected below rather than verified, so that
+		// a hostname mismatch is reported as such
+		Config: &tls.Config{ServerName: host, InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, "443"))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("no certificate presented")
+	}
+	return certs[0].VerifyHostname(host)
+}
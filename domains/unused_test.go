@@ -0,0 +1,207 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"indietool/cli/dns"
+	"net"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers public DNS lookups from fixed records. Names listed
+// in failing fail as if DNS were unreachable.
+type fakeResolver struct {
+	hosts   map[string][]string
+	cnames  map[string]string
+	mx      map[string][]*net.MX
+	failing map[string]bool
+}
+
+func (r *fakeResolver) lookup(name string) error {
+	if r.failing[name] {
+		return &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	}
+	return nil
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if err := r.lookup(host); err != nil {
+		return nil, err
+	}
+	if addresses, ok := r.hosts[host]; ok {
+		return addresses, nil
+	}
+	return nil, notFound(host)
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if err := r.lookup(host); err != nil {
+		return "", err
+	}
+	if target, ok := r.cnames[host]; ok {
+		return target + ".", nil
+	}
+	if _, ok := r.hosts[host]; ok {
+		return host + ".", nil
+	}
+	return "", notFound(host)
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if err := r.lookup(name); err != nil {
+		return nil, err
+	}
+	if mxs, ok := r.mx[name]; ok {
+		return mxs, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if err := r.lookup(name); err != nil {
+		return nil, err
+	}
+	return nil, notFound(name)
+}
+
+func TestFindUnusedDomains(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	domainList := []ManagedDomain{
+		{Name: "inuse.com", Provider: "alpha", ExpiryDate: now.AddDate(0, 2, 0), Cost: &DomainCost{RenewalPrice: 10, Currency: "USD"}},
+		{Name: "parked.com", Provider: "alpha", ExpiryDate: now.AddDate(0, 6, 0), Cost: &DomainCost{RenewalPrice: 12, Currency: "USD"}},
+		{Name: "empty.dev", Provider: "beta", ExpiryDate: now.AddDate(0, 1, 0), Cost: &DomainCost{RenewalPrice: 15, Currency: "USD"}},
+		{Name: "cheap.dev", Provider: "beta", ExpiryDate: now.AddDate(0, 1, 0), Cost: &DomainCost{RenewalPrice: 8, Currency: "USD"}},
+		{Name: "offline.io", Provider: "beta", ExpiryDate: now.AddDate(0, 3, 0)},
+		{Name: "default.net", Provider: "alpha", ExpiryDate: now.AddDate(0, 4, 0)},
+		{Name: "redirected.net", Provider: "alpha", ExpiryDate: now.AddDate(0, 4, 0)},
+	}
+
+	resolver := &fakeResolver{
+		hosts: map[string][]string{
+			"parked.com":    {"34.102.136.180"},
+			"inuse.com":     {"203.0.113.10"},
+			"www.inuse.com": {"203.0.113.10"},
+		},
+		cnames:  map[string]string{"www.parked.com": "parkingpage.namecheap.com"},
+		mx:      map[string][]*net.MX{"inuse.com": {{Host: "mx.inuse.com.", Pref: 10}}},
+		failing: map[string]bool{"offline.io": true},
+	}
+	zones := map[string][]dns.Record{
+		"empty.dev": {{Type: "NS", Name: "@", Content: "ns1.example.net"}, {Type: "SOA", Name: "@", Content: "ns1.example.net"}},
+		"cheap.dev": {{Type: "NS", Name: "@", Content: "ns1.example.net"}},
+		// Namecheap's default zone: the apex redirects to www, which is parked
+		"default.net": {
+			{Type: "URL", Name: "@", Content: "http://www.default.net/"},
+			{Type: "CNAME", Name: "www", Content: "parkingpage.namecheap.com."},
+		},
+		// A redirect somewhere else is a real use of the domain
+		"redirected.net": {{Type: "URL301", Name: "@", Content: "https://kopi.dev/launch"}},
+	}
+
+	candidates := FindUnusedDomains(context.Background(), domainList, UnusedOptions{
+		Now:      now,
+		Resolver: resolver,
+		ZoneRecords: func(ctx context.Context, domain string) ([]dns.Record, error) {
+			if records, ok := zones[domain]; ok {
+				return records, nil
+			}
+			return nil, errors.New("not hosted at a configured DNS provider")
+		},
+		ProbeHTTP: func(ctx context.Context, url string) (*HTTPProbe, error) {
+			if url == "https://inuse.com" {
+				return &HTTPProbe{URL: url, Status: 200}, nil
+			}
+			return nil, errors.New("connection refused")
+		},
+		ProbeTLS: func(ctx context.Context, host string) error {
+			if host == "inuse.com" {
+				return nil
+			}
+			return errors.New("connection refused")
+		},
+	})
+
+	var order []string
+	for _, candidate := range candidates {
+		order = append(order, candidate.Domain)
+	}
+	// Equal scores rank the pricier renewal first; unchecked domains go last
+	if want := []string{"empty.dev", "parked.com", "cheap.dev", "default.net", "redirected.net", "inuse.com", "offline.io"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("ranking = %v, want %v", order, want)
+	}
+
+	byDomain := make(map[string]UnusedCandidate)
+	for _, candidate := range candidates {
+		byDomain[candidate.Domain] = candidate
+	}
+
+	parked := byDomain["parked.com"]
+	if want := []string{"parked", "no-mx", "no-http", "no-certificate"}; !reflect.DeepEqual(parked.Signals(), want) || parked.Score != 100 {
+		t.Errorf("parked.com = %d %v, want 100 %v", parked.Score, parked.Signals(), want)
+	}
+
+	empty := byDomain["empty.dev"]
+	if want := []string{"no-records", "no-mx", "no-http", "no-certificate"}; !reflect.DeepEqual(empty.Signals(), want) || empty.Score != 100 {
+		t.Errorf("empty.dev = %d %v, want 100 %v", empty.Score, empty.Signals(), want)
+	}
+	if empty.DaysToRenew != 31 || empty.RenewalPrice != 15 {
+		t.Errorf("empty.dev renews in %d days for %.2f, want 31 days for 15.00", empty.DaysToRenew, empty.RenewalPrice)
+	}
+
+	defaultZone := byDomain["default.net"]
+	if want := []string{"parked", "no-mx", "no-http", "no-certificate"}; !reflect.DeepEqual(defaultZone.Signals(), want) {
+		t.Errorf("default.net = %d %v, want %v", defaultZone.Score, defaultZone.Signals(), want)
+	}
+	if redirected := byDomain["redirected.net"]; slices.Contains(redirected.Signals(), "no-records") || slices.Contains(redirected.Signals(), "parked") {
+		t.Errorf("redirected.net = %v, want its redirect counted as a record", redirected.Signals())
+	}
+
+	if inuse := byDomain["inuse.com"]; inuse.Score != 0 || len(inuse.Evidence) != 0 {
+		t.Errorf("inuse.com = %d %+v, want no evidence", inuse.Score, inuse.Evidence)
+	}
+	if offline := byDomain["offline.io"]; offline.Error == "" || offline.Score != 0 {
+		t.Errorf("offline.io = %+v, want it unchecked", offline)
+	}
+}
+
+func TestParkingDetection(t *testing.T) {
+	if !isParkingRecord(dns.Record{Type: "CNAME", Content: "Pixie.Porkbun.com."}) {
+		t.Error("Porkbun's parking CNAME not detected")
+	}
+	if !isParkingRecord(dns.Record{Type: "NS", Content: "ns1.sedoparking.com"}) {
+		t.Error("Sedo parking nameserver not detected")
+	}
+	if isParkingRecord(dns.Record{Type: "CNAME", Content: "notdan.com"}) {
+		t.Error("notdan.com taken for dan.com")
+	}
+	if !isParkingRecord(dns.Record{Type: "URL", Content: "https://www.sedoparking.com/search?q=kopi"}) {
+		t.Error("redirect to a parking service not detected")
+	}
+
+	defaults := []struct {
+		record dns.Record
+		want   bool
+	}{
+		{dns.Record{Type: "URL", Content: "http://www.kopi.com/"}, true},
+		{dns.Record{Type: "URL301", Content: "www.kopi.com"}, true},
+		{dns.Record{Type: "URL", Content: "https://WWW.Kopi.com"}, true},
+		{dns.Record{Type: "URL", Content: "http://www.kopi.com/shop"}, false},
+		{dns.Record{Type: "URL", Content: "http://kopi.dev/"}, false},
+		{dns.Record{Type: "CNAME", Content: "www.kopi.com"}, false},
+	}
+	for _, tt := range defaults {
+		if got := isProviderDefault(tt.record, "kopi.com"); got != tt.want {
+			t.Errorf("isProviderDefault(%s %s) = %v, want %v", tt.record.Type, tt.record.Content, got, tt.want)
+		}
+	}
+	if !looksParked("<h1>This Domain Is For Sale</h1>") || looksParked("<h1>Welcome to Kopi</h1>") {
+		t.Error("looksParked misjudged a page")
+	}
+}
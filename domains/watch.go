@@ -0,0 +1,296 @@
+package domains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"indietool/cli/dns"
+	"indietool/cli/indietool/notify"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WatchPhase is where a watched domain is in the registration lifecycle
+type WatchPhase string
+
+const (
+	WatchRegistered       WatchPhase = "registered"
+	WatchAutoRenewPeriod  WatchPhase = "autoRenewPeriod"  // Expired, the registrar may still renew it
+	WatchRedemptionPeriod WatchPhase = "redemptionPeriod" // Deleted, only the owner can restore it
+	WatchPendingDelete    WatchPhase = "pendingDelete"    // About to be released by the registry
+	WatchAvailable        WatchPhase = "available"
+	WatchUnknown          WatchPhase = "unknown" // The lookup failed or was inconclusive
+)
+
+// Typical gTLD lifecycle lengths used to predict when a domain drops. The
+// auto-renew grace period is the longest a registrar may keep it.
+const (
+	AutoRenewGracePeriod = 45 * 24 * time.Hour
+	RedemptionPeriod     = 30 * 24 * time.Hour
+	PendingDeletePeriod  = 5 * 24 * time.Hour
+)
+
+// ClassifyWatchStatus maps a search result to a lifecycle phase using the
+// RDAP statuses in result.Status
+func ClassifyWatchStatus(result DomainSearchResult) WatchPhase {
+	if result.Available {
+		return WatchAvailable
+	}
+	if result.Error != "" {
+		return WatchUnknown
+	}
+
+	// RDAP spells statuses with spaces ("redemption period"); registries
+	// often show "pending delete" throughout the redemption period too, so
+	// redemption is checked first
+	status := strings.ReplaceAll(strings.ToLower(result.Status), " ", "")
+	switch {
+	case strings.Contains(status, "redemptionperiod"):
+		return WatchRedemptionPeriod
+	case strings.Contains(status, "pendingdelete"):
+		return WatchPendingDelete
+	case strings.Contains(status, "autorenewperiod"):
+		return WatchAutoRenewPeriod
+	case status != "" && !strings.HasPrefix(status, "unknown"):
+		// "registered", WHOIS's "registered via ..." or plain RDAP statuses
+		return WatchRegistered
+	default:
+		return WatchUnknown
+	}
+}
+
+// WatchTransition records a watched domain moving between phases
+type WatchTransition struct {
+	From WatchPhase `json:"from"`
+	To   WatchPhase `json:"to"`
+	At   time.Time  `json:"at"`
+}
+
+// String renders the transition as "from → to"
+func (t WatchTransition) String() string {
+	return fmt.Sprintf("%s → %s", t.From, t.To)
+}
+
+// WatchEntry is a domain we'd like to own, with what the last checks found
+type WatchEntry struct {
+	Domain        string            `json:"domain"`
+	AddedAt       time.Time         `json:"added_at"`
+	Phase         WatchPhase        `json:"phase,omitempty"`
+	Status        string            `json:"status,omitempty"`      // Raw status from the last successful check
+	PhaseSince    *time.Time        `json:"phase_since,omitempty"` // When the current phase was first seen
+	ExpiryDate    *time.Time        `json:"expiry_date,omitempty"`
+	PredictedDrop *time.Time        `json:"predicted_drop,omitempty"`
+	CheckedAt     *time.Time        `json:"checked_at,omitempty"`
+	LastError     string            `json:"last_error,omitempty"`
+	AlertedAt     *time.Time        `json:"alerted_at,omitempty"` // When the availability alert went out
+	Transitions   []WatchTransition `json:"transitions"`
+}
+
+// Update applies a search result checked at now. It returns the transition
+// when the domain changed phase. Failed lookups keep the previous phase.
+func (e *WatchEntry) Update(result DomainSearchResult, now time.Time) *WatchTransition {
+	e.CheckedAt = &now
+
+	phase := ClassifyWatchStatus(result)
+	if phase == WatchUnknown {
+		e.LastError = result.Error
+		if e.LastError == "" {
+			e.LastError = result.Status
+		}
+		return nil
+	}
+
+	e.LastError = ""
+	e.Status = result.Status
+	if result.ExpiryDate != nil {
+		e.ExpiryDate = result.ExpiryDate
+	}
+
+	var transition *WatchTransition
+	switch {
+	case e.Phase == "" || e.Phase == WatchUnknown:
+		// First sighting; the registry's last change is the best guess at
+		// when the phase began
+		since := now
+		if result.LastChanged != nil && result.LastChanged.Before(now) && phase != WatchRegistered {
+			since = *result.LastChanged
+		}
+		e.PhaseSince = &since
+	case phase != e.Phase:
+		transition = &WatchTransition{From: e.Phase, To: phase, At: now}
+		e.Transitions = append(e.Transitions, *transition)
+		e.PhaseSince = &now
+		// Someone registered it again; alert the next time it frees up
+		if e.Phase == WatchAvailable {
+			e.AlertedAt = nil
+		}
+	}
+	e.Phase = phase
+	e.PredictedDrop = e.predictDrop()
+
+	return transition
+}
+
+// predictDrop estimates when the domain becomes available, assuming it is
+// not renewed or restored
+func (e *WatchEntry) predictDrop() *time.Time {
+	var drop time.Time
+	switch {
+	case e.Phase == WatchPendingDelete && e.PhaseSince != nil:
+		drop = e.PhaseSince.Add(PendingDeletePeriod)
+	case e.Phase == WatchRedemptionPeriod && e.PhaseSince != nil:
+		drop = e.PhaseSince.Add(RedemptionPeriod + PendingDeletePeriod)
+	case (e.Phase == WatchRegistered || e.Phase == WatchAutoRenewPeriod) && e.ExpiryDate != nil:
+		drop = e.ExpiryDate.Add(AutoRenewGracePeriod + RedemptionPeriod + PendingDeletePeriod)
+	default:
+		return nil
+	}
+	return &drop
+}
+
+// AlertDue reports whether the domain is available and nobody was told yet
+func (e *WatchEntry) AlertDue() bool {
+	return e.Phase == WatchAvailable && e.AlertedAt == nil
+}
+
+// MarkAlerted records that the availability alert was delivered
+func (e *WatchEntry) MarkAlerted(now time.Time) {
+	e.AlertedAt = &now
+}
+
+// Notification renders the availability alert for delivery through notify
+// sinks
+func (e *WatchEntry) Notification() notify.Notification {
+	body := fmt.Sprintf("%s is available to register.", e.Domain)
+	if len(e.Transitions) > 0 {
+		last := e.Transitions[len(e.Transitions)-1]
+		body = fmt.Sprintf("%s dropped (%s) and is available to register.", e.Domain, last)
+	}
+
+	return notify.Notification{
+		Event:    "domain.watch.available",
+		Title:    fmt.Sprintf("%s is available", e.Domain),
+		Body:     body,
+		Severity: notify.SeverityCritical,
+		Data: map[string]any{
+			"domain":   e.Domain,
+			"phase":    e.Phase,
+			"added_at": e.AddedAt,
+		},
+	}
+}
+
+// Watchlist holds the domains being watched
+type Watchlist struct {
+	Domains map[string]*WatchEntry `json:"domains"`
+}
+
+// LoadWatchlist reads the watchlist at path. A missing file yields an empty
+// watchlist.
+func LoadWatchlist(path string) (*Watchlist, error) {
+	watchlist := &Watchlist{Domains: make(map[string]*WatchEntry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return watchlist, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	if err := json.Unmarshal(data, watchlist); err != nil {
+		return nil, fmt.Errorf("failed to parse watchlist %s: %w", path, err)
+	}
+
+	// Older watchlists may key internationalized domains by their Unicode
+	// name, while lookups report the A-label
+	normalized := make(map[string]*WatchEntry, len(watchlist.Domains))
+	for name, entry := range watchlist.Domains {
+		if entry == nil {
+			continue
+		}
+		key := watchKey(name)
+		entry.Domain = key
+		if existing, ok := normalized[key]; ok && checkedLater(existing, entry) {
+			continue
+		}
+		normalized[key] = entry
+	}
+	watchlist.Domains = normalized
+	return watchlist, nil
+}
+
+// watchKey returns the A-label a domain is watched under
+func watchKey(domain string) string {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if ascii, err := dns.ToASCII(domain); err == nil {
+		return ascii
+	}
+	return domain
+}
+
+// checkedLater reports whether a was checked more recently than b
+func checkedLater(a, b *WatchEntry) bool {
+	if a.CheckedAt == nil || b.CheckedAt == nil {
+		return a.CheckedAt != nil
+	}
+	return a.CheckedAt.After(*b.CheckedAt)
+}
+
+// Save writes the watchlist to path, creating parent directories as needed
+func (w *Watchlist) Save(path string) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode watchlist: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create watchlist directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Add starts watching domain. It returns false when it is already watched.
+func (w *Watchlist) Add(domain string, now time.Time) (*WatchEntry, bool) {
+	domain = watchKey(domain)
+	if entry, ok := w.Domains[domain]; ok {
+		return entry, false
+	}
+	entry := &WatchEntry{
+		Domain:      domain,
+		AddedAt:     now,
+		Transitions: []WatchTransition{},
+	}
+	w.Domains[domain] = entry
+	return entry, true
+}
+
+// Remove stops watching domain
+func (w *Watchlist) Remove(domain string) bool {
+	domain = watchKey(domain)
+	_, ok := w.Domains[domain]
+	delete(w.Domains, domain)
+	return ok
+}
+
+// List returns the watched domains sorted by name
+func (w *Watchlist) List() []*WatchEntry {
+	entries := make([]*WatchEntry, 0, len(w.Domains))
+	for _, entry := range w.Domains {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Domain < entries[j].Domain })
+	return entries
+}
+
+// Names returns the watched domain names sorted
+func (w *Watchlist) Names() []string {
+	names := make([]string, 0, len(w.Domains))
+	for name := range w.Domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,162 @@
+package domains
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClassifyWatchStatus(t *testing.T) {
+	tests := []struct {
+		result DomainSearchResult
+		want   WatchPhase
+	}{
+		{DomainSearchResult{Available: true, Status: "available"}, WatchAvailable},
+		{DomainSearchResult{Status: "registered"}, WatchRegistered},
+		{DomainSearchResult{Status: "client transfer prohibited, server delete prohibited"}, WatchRegistered},
+		{DomainSearchResult{Status: "registered via Example Registrar (expires: 2026-01-01)"}, WatchRegistered},
+		{DomainSearchResult{Status: "auto renew period, client transfer prohibited"}, WatchAutoRenewPeriod},
+		{DomainSearchResult{Status: "pending delete, redemption period"}, WatchRedemptionPeriod},
+		{DomainSearchResult{Status: "pending delete"}, WatchPendingDelete},
+		{DomainSearchResult{Status: "unknown (whois inconclusive)"}, WatchUnknown},
+		{DomainSearchResult{Error: "timeout"}, WatchUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyWatchStatus(tt.result); got != tt.want {
+			t.Errorf("ClassifyWatchStatus(%+v) = %s, want %s", tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestWatchEntryLifecycle(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiry := start.Add(-50 * day)
+
+	watchlist := &Watchlist{Domains: make(map[string]*WatchEntry)}
+	entry, added := watchlist.Add("CoolName.com.", start)
+	if !added || entry.Domain != "coolname.com" {
+		t.Fatalf("Add = %+v, %v", entry, added)
+	}
+	if _, added := watchlist.Add("coolname.com", start); added {
+		t.Error("adding a watched domain again should be a no-op")
+	}
+
+	// First check only records the phase
+	if transition := entry.Update(DomainSearchResult{Status: "registered", ExpiryDate: &expiry}, start); transition != nil {
+		t.Errorf("first check reported transition %v", transition)
+	}
+	wantDrop := expiry.Add(80 * day)
+	if entry.PredictedDrop == nil || !entry.PredictedDrop.Equal(wantDrop) {
+		t.Errorf("registered drop = %v, want %v", entry.PredictedDrop, wantDrop)
+	}
+
+	// Failed lookups keep the phase
+	if transition := entry.Update(DomainSearchResult{Error: "timeout"}, start.Add(day)); transition != nil || entry.Phase != WatchRegistered || entry.LastError != "timeout" {
+		t.Errorf("after failed lookup: transition %v, entry %+v", transition, entry)
+	}
+
+	redemption := start.Add(2 * day)
+	transition := entry.Update(DomainSearchResult{Status: "redemption period"}, redemption)
+	if transition == nil || transition.String() != "registered → redemptionPeriod" {
+		t.Fatalf("transition = %v", transition)
+	}
+	if want := redemption.Add(35 * day); !entry.PredictedDrop.Equal(want) {
+		t.Errorf("redemption drop = %v, want %v", entry.PredictedDrop, want)
+	}
+	if entry.LastError != "" {
+		t.Errorf("LastError = %q after a successful lookup", entry.LastError)
+	}
+
+	pending := redemption.Add(30 * day)
+	entry.Update(DomainSearchResult{Status: "pending delete"}, pending)
+	if want := pending.Add(5 * day); !entry.PredictedDrop.Equal(want) {
+		t.Errorf("pending delete drop = %v, want %v", entry.PredictedDrop, want)
+	}
+	if entry.AlertDue() {
+		t.Error("alert due before the domain is available")
+	}
+
+	dropped := pending.Add(5 * day)
+	entry.Update(DomainSearchResult{Available: true, Status: "available"}, dropped)
+	if !entry.AlertDue() || entry.PredictedDrop != nil {
+		t.Fatalf("available entry = %+v, want alert due and no predicted drop", entry)
+	}
+	if n := entry.Notification(); n.Event != "domain.watch.available" || n.Title != "coolname.com is available" {
+		t.Errorf("notification = %+v", n)
+	}
+	entry.MarkAlerted(dropped)
+
+	// Still available: no second alert
+	entry.Update(DomainSearchResult{Available: true, Status: "available"}, dropped.Add(day))
+	if entry.AlertDue() {
+		t.Error("alert due twice for the same drop")
+	}
+
+	// Registered again by someone else re-arms the alert
+	entry.Update(DomainSearchResult{Status: "registered"}, dropped.Add(2*day))
+	if entry.AlertedAt != nil {
+		t.Error("re-registration should reset the alert")
+	}
+
+	if len(entry.Transitions) != 4 {
+		t.Errorf("transitions = %v, want 4", entry.Transitions)
+	}
+
+	// Round trip through disk
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+	if err := watchlist.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadWatchlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Domains["coolname.com"]; got == nil || got.Phase != WatchRegistered || len(got.Transitions) != 4 {
+		t.Errorf("loaded entry = %+v", got)
+	}
+	if !loaded.Remove("COOLNAME.com") || len(loaded.Names()) != 0 {
+		t.Error("Remove should be case-insensitive")
+	}
+}
+
+func TestWatchEntryFirstSightingUsesLastChanged(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changed := now.Add(-3 * 24 * time.Hour)
+
+	entry := &WatchEntry{Domain: "late.com"}
+	entry.Update(DomainSearchResult{Status: "pending delete", LastChanged: &changed}, now)
+	if want := changed.Add(PendingDeletePeriod); entry.PredictedDrop == nil || !entry.PredictedDrop.Equal(want) {
+		t.Errorf("drop = %v, want %v", entry.PredictedDrop, want)
+	}
+}
+
+func TestLoadWatchlistNormalizesUnicodeKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+	data := `{"domains": {
+  "bücher.de": {"domain": "bücher.de", "added_at": "2024-01-01T00:00:00Z", "checked_at": "2024-03-01T00:00:00Z", "phase": "registered", "transitions": []},
+  "xn--bcher-kva.de": {"domain": "xn--bcher-kva.de", "added_at": "2024-01-01T00:00:00Z", "checked_at": "2024-02-01T00:00:00Z", "transitions": []},
+  "Example.COM": {"domain": "Example.COM", "added_at": "2024-01-01T00:00:00Z", "transitions": []}
+}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	watchlist, err := LoadWatchlist(path)
+	if err != nil {
+		t.Fatalf("LoadWatchlist: %v", err)
+	}
+	names := watchlist.Names()
+	if len(names) != 2 || names[0] != "example.com" || names[1] != "xn--bcher-kva.de" {
+		t.Fatalf("names = %v, want [example.com xn--bcher-kva.de]", names)
+	}
+	entry := watchlist.Domains["xn--bcher-kva.de"]
+	if entry.Domain != "xn--bcher-kva.de" || entry.Phase != WatchRegistered {
+		t.Errorf("entry = %+v, want the most recently checked entry under its A-label", entry)
+	}
+	if !watchlist.Remove("bücher.de") {
+		t.Error("Remove by Unicode name didn't find the entry")
+	}
+}
@@ -2,10 +2,13 @@ package indietool
 
 import (
 	"fmt"
+	"indietool/cli/domains"
+	"indietool/cli/indietool/notify"
 	"indietool/cli/indietool/secrets"
 	"indietool/cli/providers"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 )
@@ -22,21 +25,42 @@ var (
 	DefaultSecretLocation     = fmt.Sprintf("%s/secrets", DefaultBaseDir)
 	DefaultSecretClipboardTTL = 30 // seconds
 
+	// Domains
+	DefaultDomainInventoryFile   = "inventory.json"
+	DefaultDomainNotifyStateFile = "notify-state.json"
+	DefaultDomainCostHistoryFile = "costs.json"
+	DefaultDomainTransfersFile   = "transfers.json"
+	DefaultDomainWatchlistFile   = "watchlist.json"
+	DefaultDomainCacheDir        = "cache"
+	DefaultDomainPriceTableFile  = "prices.json"
+	DefaultDomainTLDCatalogFile  = "tlds.json"
+	DefaultDomainLookalikesFile  = "lookalikes.json"
+	DefaultDomainSessionsFile    = "sessions.json"
+	DefaultDomainShortlistFile   = "shortlist.json"
+	DefaultDomainMetadataFile    = "metadata.yaml"
 )
 
 // Config represents the entire configuration structure for the indietool CLI
 type Config struct {
-	Domains   DomainsConfig   `yaml:"domains"`
-	Providers ProvidersConfig `yaml:"providers"`
-	Secrets   secrets.Config  `yaml:"secrets"`
-	Path      string          `yaml:"-"` // Path where config was successfully loaded from
-	Version   string          `yaml:"-"` // Version set during app initialization
+	Domains       DomainsConfig   `yaml:"domains"`
+	Providers     ProvidersConfig `yaml:"providers"`
+	Secrets       secrets.Config  `yaml:"secrets"`
+	Notifications notify.Config   `yaml:"notifications,omitempty"`
+	Path          string          `yaml:"-"` // Path where config was successfully loaded from
+	Version       string          `yaml:"-"` // Version set during app initialization
 }
 
 // DomainsConfig holds all domain-related configuration
 type DomainsConfig struct {
 	Providers  []string         `yaml:"providers"` // List of provider names to use for domain management
 	Management ManagementConfig `yaml:"management"`
+
+	// Contacts are named registrant profiles used when registering domains
+	Contacts map[string]domains.Contact `yaml:"contacts,omitempty"`
+
+	// MetadataFile holds domain tags, projects and notes. Point it into a
+	// team repo to share them; relative paths are from the config file.
+	MetadataFile string `yaml:"metadata_file,omitempty"`
 }
 
 // ProvidersConfig holds configuration for all supported providers
@@ -45,7 +69,8 @@ type ProvidersConfig struct {
 	Namecheap     *providers.NamecheapConfig     `yaml:"namecheap,omitempty,omitzero"`
 	Porkbun       *providers.PorkbunConfig       `yaml:"porkbun,omitempty,omitzero"`
 	GoDaddy       *providers.GoDaddyConfig       `yaml:"godaddy,omitempty,omitzero"`
-	TheLittleHost *providers.TheLittleHostConfig  `yaml:"thelittlehost,omitempty,omitzero"`
+	TheLittleHost *providers.TheLittleHostConfig `yaml:"thelittlehost,omitempty,omitzero"`
+	Mock          *providers.MockConfig          `yaml:"mock,omitempty,omitzero"`
 }
 
 // ManagementConfig holds domain management settings
@@ -156,6 +181,20 @@ func (c *Config) ValidateConfig() []string {
 		}
 	}
 
+	// Validate mock config if present
+	if mock := c.Providers.Mock; mock != nil {
+		if mock.StatePath == "" {
+			errors = append(errors, "Mock: state is required")
+		}
+	}
+
+	// Validate notification sinks
+	for _, sink := range c.Notifications.Sinks {
+		if _, err := notify.NewSink(sink); err != nil {
+			errors = append(errors, fmt.Sprintf("Notifications: %v", err))
+		}
+	}
+
 	return errors
 }
 
@@ -178,6 +217,9 @@ func (c *Config) GetEnabledProviders() []string {
 	if c.Providers.TheLittleHost != nil && c.Providers.TheLittleHost.Enabled {
 		enabled = append(enabled, "thelittlehost")
 	}
+	if c.Providers.Mock != nil && c.Providers.Mock.Enabled {
+		enabled = append(enabled, "mock")
+	}
 
 	return enabled
 }
@@ -211,6 +253,98 @@ func (c *Config) GetSecretsConfig() *secrets.Config {
 	return &c.Secrets
 }
 
+// GetDomainInventoryPath returns where the local domain inventory is cached,
+// next to the config file. The path may still contain a leading ~.
+func (c *Config) GetDomainInventoryPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainInventoryFile)
+}
+
+// GetDomainNotifyStatePath returns where `domains notify` remembers which
+// expiry alerts were already sent. The path may still contain a leading ~.
+func (c *Config) GetDomainNotifyStatePath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainNotifyStateFile)
+}
+
+// GetDomainCostHistoryPath returns where `domains costs` remembers previous
+// renewal prices. The path may still contain a leading ~.
+func (c *Config) GetDomainCostHistoryPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainCostHistoryFile)
+}
+
+// GetDomainTransfersPath returns where `domains transfer` keeps the progress
+// of transfers between registrars. The path may still contain a leading ~.
+func (c *Config) GetDomainTransfersPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainTransfersFile)
+}
+
+// GetDomainWatchlistPath returns where `domain watch` keeps the domains being
+// watched. The path may still contain a leading ~.
+func (c *Config) GetDomainWatchlistPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainWatchlistFile)
+}
+
+// GetDomainLookalikesPath returns where `domain lookalikes --monitor` keeps
+// the lookalikes it has seen registered. The path may still contain a
+// leading ~.
+func (c *Config) GetDomainLookalikesPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainLookalikesFile)
+}
+
+// GetDomainSessionsPath returns where `domain explore --session` keeps its
+// named sessions. The path may still contain a leading ~.
+func (c *Config) GetDomainSessionsPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainSessionsFile)
+}
+
+// GetDomainShortlistPath returns where `domain shortlist` keeps the starred
+// candidate domains. The path may still contain a leading ~.
+func (c *Config) GetDomainShortlistPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainShortlistFile)
+}
+
+// GetDomainMetadataPath returns the domain metadata file: domains.metadata_file
+// when set, otherwise next to the config file. The path may still contain a
+// leading ~.
+func (c *Config) GetDomainMetadataPath() string {
+	path := c.Domains.MetadataFile
+	switch {
+	case path == "":
+		return filepath.Join(c.getDataDir(), "domains", DefaultDomainMetadataFile)
+	case filepath.IsAbs(path) || strings.HasPrefix(path, "~"):
+		return path
+	default:
+		return filepath.Join(c.getDataDir(), path)
+	}
+}
+
+// GetDomainCacheDir returns the directory caching domain lookups and the RDAP
+// bootstrap registry. The path may still contain a leading ~.
+func (c *Config) GetDomainCacheDir() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainCacheDir)
+}
+
+// GetDomainPriceTablePath returns where registrar price lists are cached.
+// The path may still contain a leading ~.
+func (c *Config) GetDomainPriceTablePath() string {
+	return filepath.Join(c.GetDomainCacheDir(), DefaultDomainPriceTableFile)
+}
+
+// GetDomainTLDCatalogPath returns where `domain tlds update` saves the TLD
+// catalog; the bundled one is used until then. The path may still contain a
+// leading ~.
+func (c *Config) GetDomainTLDCatalogPath() string {
+	return filepath.Join(c.getDataDir(), "domains", DefaultDomainTLDCatalogFile)
+}
+
+// getDataDir returns the directory holding the config file, which also holds
+// indietool's local data
+func (c *Config) getDataDir() string {
+	if c.Path == "" {
+		return DefaultBaseDir
+	}
+	return filepath.Dir(c.Path)
+}
+
 // getSecretsDir calculates the secrets directory relative to the config directory
 func (c *Config) getSecretsDir() string {
 	if c.Path == "" {
@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CommandSink runs a local command for each notification, which makes it a
+// hook for desktop notifications (notify-send, osascript, terminal-notifier)
+// or any custom script.
+//
+// The placeholders {{title}}, {{body}}, {{severity}} and {{event}} are
+// replaced in every argument, and the same values are exported as
+// INDIETOOL_TITLE, INDIETOOL_BODY, INDIETOOL_SEVERITY and INDIETOOL_EVENT.
+type CommandSink struct {
+	name    string
+	command []string
+}
+
+// NewCommandSink creates a command hook sink
+func NewCommandSink(name string, command []string) *CommandSink {
+	return &CommandSink{name: name, command: command}
+}
+
+// Name returns the sink name
+func (s *CommandSink) Name() string {
+	return s.name
+}
+
+// Send runs the command for n
+func (s *CommandSink) Send(ctx context.Context, n Notification) error {
+	replacer := strings.NewReplacer(
+		"{{title}}", n.Title,
+		"{{body}}", n.Body,
+		"{{severity}}", string(n.Severity),
+		"{{event}}", n.Event,
+	)
+
+	args := make([]string, len(s.command))
+	for i, arg := range s.command {
+		args[i] = replacer.Replace(arg)
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"INDIETOOL_TITLE="+n.Title,
+		"INDIETOOL_BODY="+n.Body,
+		"INDIETOOL_SEVERITY="+string(n.Severity),
+		"INDIETOOL_EVENT="+n.Event,
+	)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("command %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
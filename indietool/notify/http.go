@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultNtfyServer = "https://ntfy.sh"
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// WebhookSink posts the notification as JSON to an arbitrary URL
+type WebhookSink struct {
+	name    string
+	url     string
+	headers map[string]string
+}
+
+// NewWebhookSink creates a generic JSON webhook sink
+func NewWebhookSink(name, url string, headers map[string]string) *WebhookSink {
+	return &WebhookSink{name: name, url: url, headers: headers}
+}
+
+// Name returns the sink name
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// Send posts n as JSON
+func (s *WebhookSink) Send(ctx context.Context, n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	for key, value := range s.headers {
+		headers[key] = value
+	}
+	return post(ctx, s.url, bytes.NewReader(payload), headers)
+}
+
+// SlackSink posts to a Slack-compatible incoming webhook (Slack, Mattermost,
+// Rocket.Chat, Discord's /slack endpoint)
+type SlackSink struct {
+	name string
+	url  string
+}
+
+// NewSlackSink creates a Slack-compatible webhook sink
+func NewSlackSink(name, url string) *SlackSink {
+	return &SlackSink{name: name, url: url}
+}
+
+// Name returns the sink name
+func (s *SlackSink) Name() string {
+	return s.name
+}
+
+// Send posts n as a Slack message
+func (s *SlackSink) Send(ctx context.Context, n Notification) error {
+	payload, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("%s *%s*\n%s", severityEmoji(n.Severity), n.Title, n.Body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return post(ctx, s.url, bytes.NewReader(payload), map[string]string{"Content-Type": "application/json"})
+}
+
+// NtfySink publishes to an ntfy topic
+type NtfySink struct {
+	name   string
+	server string
+	topic  string
+	token  string
+}
+
+// NewNtfySink creates an ntfy sink. An empty server uses ntfy.sh.
+func NewNtfySink(name, server, topic, token string) *NtfySink {
+	if server == "" {
+		server = defaultNtfyServer
+	}
+	return &NtfySink{name: name, server: strings.TrimRight(server, "/"), topic: topic, token: token}
+}
+
+// Name returns the sink name
+func (s *NtfySink) Name() string {
+	return s.name
+}
+
+// Send publishes n to the topic, mapping severity to ntfy priorities
+func (s *NtfySink) Send(ctx context.Context, n Notification) error {
+	headers := map[string]string{
+		"Title":    n.Title,
+		"Priority": ntfyPriority(n.Severity),
+		"Tags":     ntfyTag(n.Severity),
+	}
+	if s.token != "" {
+		headers["Authorization"] = "Bearer " + s.token
+	}
+	return post(ctx, s.server+"/"+s.topic, strings.NewReader(n.Body), headers)
+}
+
+// post sends body to url and treats any non-2xx response as an error
+func post(ctx context.Context, url string, body io.Reader, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func severityEmoji(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "🚨"
+	case SeverityWarning:
+		return "⚠️"
+	default:
+		return "ℹ️"
+	}
+}
+
+func ntfyPriority(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "urgent"
+	case SeverityWarning:
+		return "high"
+	default:
+		return "default"
+	}
+}
+
+func ntfyTag(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "rotating_light"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "information_source"
+	}
+}
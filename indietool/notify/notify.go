@@ -0,0 +1,141 @@
+// Package notify delivers alerts raised by indietool (expiring domains and
+// the like) through pluggable sinks such as webhooks, ntfy, email or a local
+// command.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity describes how urgent a notification is
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Notification is a single alert delivered to every configured sink
+type Notification struct {
+	Event    string         `json:"event"`          // Machine-readable event name, e.g. "domain.expiry"
+	Title    string         `json:"title"`          // Short summary line
+	Body     string         `json:"body"`           // Human-readable details
+	Severity Severity       `json:"severity"`       // info, warning or critical
+	Data     map[string]any `json:"data,omitempty"` // Structured event details for webhooks
+	SentAt   time.Time      `json:"sent_at"`
+}
+
+// Sink delivers notifications to one destination
+type Sink interface {
+	// Name identifies the sink in output and errors
+	Name() string
+
+	// Send delivers a single notification
+	Send(ctx context.Context, n Notification) error
+}
+
+// SinkConfig configures one sink. Type selects the sink; only the fields
+// relevant to that type are read.
+type SinkConfig struct {
+	Name string `yaml:"name,omitempty"` // Optional display name, defaults to the type
+	Type string `yaml:"type"`           // webhook, slack, ntfy, smtp or command
+
+	// webhook, slack and ntfy
+	URL     string            `yaml:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// ntfy
+	Topic string `yaml:"topic,omitempty"`
+	Token string `yaml:"token,omitempty"`
+
+	// smtp
+	Host     string   `yaml:"host,omitempty"`
+	Port     int      `yaml:"port,omitempty"`
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from,omitempty"`
+	To       []string `yaml:"to,omitempty"`
+
+	// command
+	Command []string `yaml:"command,omitempty"`
+}
+
+// Config holds the notification sinks
+type Config struct {
+	Sinks []SinkConfig `yaml:"sinks,omitempty"`
+}
+
+// NewSink creates the sink described by cfg
+func NewSink(cfg SinkConfig) (Sink, error) {
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+
+	switch strings.ToLower(cfg.Type) {
+	case "webhook":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook sink %s requires url", name)
+		}
+		return NewWebhookSink(name, cfg.URL, cfg.Headers), nil
+	case "slack":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("slack sink %s requires url", name)
+		}
+		return NewSlackSink(name, cfg.URL), nil
+	case "ntfy":
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("ntfy sink %s requires topic", name)
+		}
+		return NewNtfySink(name, cfg.URL, cfg.Topic, cfg.Token), nil
+	case "smtp":
+		if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+			return nil, fmt.Errorf("smtp sink %s requires host, from and to", name)
+		}
+		return NewSMTPSink(name, cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From, cfg.To), nil
+	case "command", "desktop":
+		if len(cfg.Command) == 0 {
+			return nil, fmt.Errorf("command sink %s requires command", name)
+		}
+		return NewCommandSink(name, cfg.Command), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q (supported: webhook, slack, ntfy, smtp, command)", cfg.Type)
+	}
+}
+
+// NewSinks creates all sinks in cfg, failing on the first invalid one
+func NewSinks(cfg Config) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sinkConfig := range cfg.Sinks {
+		sink, err := NewSink(sinkConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// Dispatch sends n to every sink. It returns the names of the sinks that
+// accepted the notification along with the errors of those that failed.
+func Dispatch(ctx context.Context, sinks []Sink, n Notification) ([]string, error) {
+	if n.SentAt.IsZero() {
+		n.SentAt = time.Now()
+	}
+
+	var delivered []string
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Send(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		delivered = append(delivered, sink.Name())
+	}
+	return delivered, errors.Join(errs...)
+}
@@ -0,0 +1,257 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var testNotification = Notification{
+	Event:    "domain.expiry",
+	Title:    "example.com expires in 7 days",
+	Body:     "example.com (mock) expires on 2026-01-08.",
+	Severity: SeverityWarning,
+	Data:     map[string]any{"domain": "example.com"},
+}
+
+type capturedRequest struct {
+	path    string
+	headers http.Header
+	body    []byte
+}
+
+func newCaptureServer(t *testing.T, status int) (*httptest.Server, chan capturedRequest) {
+	t.Helper()
+	requests := make(chan capturedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- capturedRequest{path: r.URL.Path, headers: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestWebhookSink(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	sink, err := NewSink(SinkConfig{Type: "webhook", URL: server.URL, Headers: map[string]string{"X-Token": "abc"}})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.Send(context.Background(), testNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	req := <-requests
+	if got := req.headers.Get("X-Token"); got != "abc" {
+		t.Errorf("X-Token = %q, want abc", got)
+	}
+	var payload Notification
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("invalid JSON payload: %v", err)
+	}
+	if payload.Title != testNotification.Title || payload.Severity != SeverityWarning || payload.Data["domain"] != "example.com" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhookSinkErrorStatus(t *testing.T) {
+	server, _ := newCaptureServer(t, http.StatusInternalServerError)
+
+	sink := NewWebhookSink("webhook", server.URL, nil)
+	if err := sink.Send(context.Background(), testNotification); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+}
+
+func TestSlackSink(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	sink := NewSlackSink("slack", server.URL)
+	if err := sink.Send(context.Background(), testNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal((<-requests).body, &payload); err != nil {
+		t.Fatalf("invalid JSON payload: %v", err)
+	}
+	if !strings.Contains(payload["text"], "*"+testNotification.Title+"*") || !strings.Contains(payload["text"], testNotification.Body) {
+		t.Errorf("unexpected text: %q", payload["text"])
+	}
+}
+
+func TestNtfySink(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	sink := NewNtfySink("ntfy", server.URL+"/", "domains", "tk_secret")
+	if err := sink.Send(context.Background(), testNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	req := <-requests
+	if req.path != "/domains" {
+		t.Errorf("path = %q, want /domains", req.path)
+	}
+	if got := req.headers.Get("Title"); got != testNotification.Title {
+		t.Errorf("Title = %q", got)
+	}
+	if got := req.headers.Get("Priority"); got != "high" {
+		t.Errorf("Priority = %q, want high", got)
+	}
+	if got := req.headers.Get("Authorization"); got != "Bearer tk_secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if string(req.body) != testNotification.Body {
+		t.Errorf("body = %q", req.body)
+	}
+}
+
+// serveSMTP runs a minimal SMTP server for a single session and returns the
+// received envelope and message
+func serveSMTP(t *testing.T) (string, chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var transcript strings.Builder
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost ESMTP test")
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				received <- transcript.String()
+				return
+			}
+			transcript.WriteString(line)
+
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					reply("250 OK")
+				}
+				continue
+			}
+
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "DATA"):
+				inData = true
+				reply("354 go ahead")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 bye")
+				received <- transcript.String()
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSMTPSink(t *testing.T) {
+	addr, received := serveSMTP(t)
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portStr)
+
+	sink, err := NewSink(SinkConfig{Type: "smtp", Host: host, Port: port, From: "alerts@example.com", To: []string{"me@example.com"}})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.Send(context.Background(), testNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	transcript := <-received
+	for _, want := range []string{
+		"MAIL FROM:<alerts@example.com>",
+		"RCPT TO:<me@example.com>",
+		"Subject: [indietool] " + testNotification.Title,
+		"X-Indietool-Severity: warning",
+		testNotification.Body,
+	} {
+		if !strings.Contains(transcript, want) {
+			t.Errorf("transcript missing %q:\n%s", want, transcript)
+		}
+	}
+}
+
+func TestCommandSink(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.txt")
+
+	sink, err := NewSink(SinkConfig{
+		Type:    "desktop",
+		Command: []string{"sh", "-c", `printf '%s|%s' "$1" "$INDIETOOL_SEVERITY" > "$2"`, "sh", "{{title}}", out},
+	})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.Send(context.Background(), testNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := testNotification.Title + "|warning"; string(data) != want {
+		t.Errorf("output = %q, want %q", data, want)
+	}
+}
+
+func TestNewSinkValidation(t *testing.T) {
+	for _, cfg := range []SinkConfig{
+		{Type: "webhook"},
+		{Type: "slack"},
+		{Type: "ntfy"},
+		{Type: "smtp", Host: "smtp.example.com"},
+		{Type: "command"},
+		{Type: "carrier-pigeon"},
+	} {
+		if _, err := NewSink(cfg); err == nil {
+			t.Errorf("NewSink(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestDispatchPartialFailure(t *testing.T) {
+	ok, _ := newCaptureServer(t, http.StatusOK)
+	broken, _ := newCaptureServer(t, http.StatusBadGateway)
+
+	sinks := []Sink{NewWebhookSink("broken", broken.URL, nil), NewWebhookSink("ok", ok.URL, nil)}
+	delivered, err := Dispatch(context.Background(), sinks, testNotification)
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected error mentioning broken sink, got %v", err)
+	}
+	if len(delivered) != 1 || delivered[0] != "ok" {
+		t.Errorf("delivered = %v, want [ok]", delivered)
+	}
+}
@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPSink emails notifications. STARTTLS is used whenever the server offers
+// it; credentials are only sent when a username is configured.
+type SMTPSink struct {
+	name     string
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// NewSMTPSink creates an email sink. Port defaults to 587.
+func NewSMTPSink(name, host string, port int, username, password, from string, to []string) *SMTPSink {
+	if port == 0 {
+		port = 587
+	}
+	return &SMTPSink{
+		name:     name,
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+// Name returns the sink name
+func (s *SMTPSink) Name() string {
+	return s.name
+}
+
+// Send emails n to every recipient
+func (s *SMTPSink) Send(ctx context.Context, n Notification) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+
+	dialer := &net.Dialer{Timeout: 15 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, recipient := range s.to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(s.message(n)); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// message renders n as a plain-text email
+func (s *SMTPSink) message(n Notification) []byte {
+	sentAt := n.SentAt
+	if sentAt.IsZero() {
+		sentAt = time.Now()
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "[indietool] "+n.Title))
+	fmt.Fprintf(&buf, "Date: %s\r\n", sentAt.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&buf, "X-Indietool-Severity: %s\r\n", n.Severity)
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
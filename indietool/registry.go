@@ -33,6 +33,7 @@ type Providers struct {
 	Namecheap     *providers.NamecheapProvider
 	GoDaddy       *providers.GoDaddyProvider
 	TheLittleHost *providers.TheLittleHostProvider
+	Mock          *providers.MockProvider
 }
 
 func GetProviders[T any](registry *Registry) []T {
@@ -82,6 +83,10 @@ func NewRegistry(cfg *Config) (*Registry, error) {
 		registry.providers.TheLittleHost = providers.NewTheLittleHost(*cfg.Providers.TheLittleHost)
 	}
 
+	if cfg.Providers.Mock != nil {
+		registry.providers.Mock = providers.NewMock(*cfg.Providers.Mock)
+	}
+
 	return registry, nil
 }
 
@@ -104,6 +109,9 @@ func (r *Registry) List() []string {
 	if r.providers.TheLittleHost != nil {
 		names = append(names, "thelittlehost")
 	}
+	if r.providers.Mock != nil {
+		names = append(names, "mock")
+	}
 
 	return names
 }
@@ -131,6 +139,10 @@ func (r *Registry) Get(name string) (Provider, bool) {
 		if r.providers.TheLittleHost != nil {
 			return r.providers.TheLittleHost, true
 		}
+	case "mock":
+		if r.providers.Mock != nil {
+			return r.providers.Mock, true
+		}
 	}
 	return nil, false
 }
@@ -154,6 +166,9 @@ func (r *Registry) GetEnabledProviders() []Provider {
 	if r.providers.TheLittleHost != nil && r.providers.TheLittleHost.IsEnabled() {
 		enabled = append(enabled, r.providers.TheLittleHost)
 	}
+	if r.providers.Mock != nil && r.providers.Mock.IsEnabled() {
+		enabled = append(enabled, r.providers.Mock)
+	}
 
 	return enabled
 }
@@ -0,0 +1,226 @@
+package secrets
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Formats accepted by secrets export and import
+const (
+	FormatJSON   = "json"
+	FormatDotenv = "dotenv"
+)
+
+// dotenvBarePattern matches values that can be written without quotes
+var dotenvBarePattern = regexp.MustCompile(`^[A-Za-z0-9_./:@+,%=-]*$`)
+
+// ParseDotenvTarget splits a file.env@database import argument. The suffix
+// is only split off when it is a valid database name and the whole argument
+// isn't an existing file, so paths containing @ are left alone.
+func ParseDotenvTarget(arg string) (path, database string) {
+	at := strings.LastIndex(arg, "@")
+	if at <= 0 || !ValidDatabaseName(arg[at+1:]) {
+		return arg, ""
+	}
+	if _, err := os.Stat(arg); err == nil {
+		return arg, ""
+	}
+	return arg[:at], arg[at+1:]
+}
+
+// ParseDotenv reads KEY=value lines from a .env file and returns them as
+// secrets named after their keys. Values may be unquoted, single-quoted
+// (literal) or double-quoted (with \n, \t, \", \\ and \$ escapes), and quoted
+// values may span lines. A block of comment lines directly above a key
+// becomes the secret's note. When a key repeats, the last value wins and
+// keeps the earlier note unless it has its own.
+func ParseDotenv(r io.Reader) ([]*Secret, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dotenv file: %w", err)
+	}
+
+	var parsed []*Secret
+	index := make(map[string]int)
+	var notes []string
+	for i := 0; i < len(lines); i++ {
+		lineNum := i + 1
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			notes = nil
+			continue
+		case strings.HasPrefix(line, "#"):
+			notes = append(notes, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			continue
+		}
+
+		key, rest, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || !ValidEnvVar(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=value", lineNum)
+		}
+
+		value, consumed, err := parseDotenvValue(strings.TrimSpace(rest), lines[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", lineNum, key, err)
+		}
+		i += consumed
+
+		secret := &Secret{Name: key, Value: value, Note: strings.Join(notes, "\n")}
+		notes = nil
+		if at, seen := index[key]; seen {
+			if secret.Note == "" {
+				secret.Note = parsed[at].Note
+			}
+			parsed[at] = secret
+			continue
+		}
+		index[key] = len(parsed)
+		parsed = append(parsed, secret)
+	}
+	return parsed, nil
+}
+
+// parseDotenvValue decodes the value after the = sign. Quoted values that
+// don't close on the same line continue into next; consumed is how many of
+// those lines were used.
+func parseDotenvValue(value string, next []string) (decoded string, consumed int, err error) {
+	if value == "" || (value[0] != '"' && value[0] != '\'') {
+		if at := strings.Index(value, " #"); at >= 0 {
+			value = value[:at]
+		}
+		if at := strings.Index(value, "\t#"); at >= 0 {
+			value = value[:at]
+		}
+		return strings.TrimSpace(value), 0, nil
+	}
+
+	quote := value[0]
+	text := value[1:]
+	for {
+		if end := closingQuote(text, quote); end >= 0 {
+			trailing := strings.TrimSpace(text[end+1:])
+			if trailing != "" && !strings.HasPrefix(trailing, "#") {
+				return "", 0, fmt.Errorf("unexpected text after closing quote")
+			}
+			text = text[:end]
+			break
+		}
+		if consumed == len(next) {
+			return "", 0, fmt.Errorf("missing closing %c", quote)
+		}
+		text += "\n" + next[consumed]
+		consumed++
+	}
+
+	if quote == '\'' {
+		return text, consumed, nil
+	}
+	return unescapeDotenv(text), consumed, nil
+}
+
+// closingQuote returns the index of the first unescaped quote in text, or -1
+func closingQuote(text string, quote byte) int {
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && quote == '"':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeDotenv expands the escapes allowed in double-quoted values
+func unescapeDotenv(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i+1 == len(text) {
+			b.WriteByte(text[i])
+			continue
+		}
+		i++
+		switch text[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\', '$', '`':
+			b.WriteByte(text[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(text[i])
+		}
+	}
+	return b.String()
+}
+
+// DotenvKey turns a secret name into an environment variable name, e.g.
+// openai-key becomes OPENAI_KEY
+func DotenvKey(name string) string {
+	key := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		key = "_" + key
+	}
+	return key
+}
+
+// QuoteDotenv formats a value for a .env file, quoting it when it holds
+// anything other than plain characters
+func QuoteDotenv(value string) string {
+	if dotenvBarePattern.MatchString(value) {
+		return value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`", "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// WriteDotenv writes secrets as KEY=value lines, with each note as comment
+// lines above its key. Keys come from DotenvKey; two secrets that map to
+// the same key are an error.
+func WriteDotenv(w io.Writer, list []*Secret) error {
+	owners := make(map[string]string, len(list))
+	var b strings.Builder
+	for i, secret := range list {
+		key := DotenvKey(secret.Name)
+		if owner, taken := owners[key]; taken {
+			return fmt.Errorf("secrets %q and %q both map to %s", owner, secret.Name, key)
+		}
+		owners[key] = secret.Name
+
+		if secret.Note != "" {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			for _, line := range strings.Split(secret.Note, "\n") {
+				b.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+			}
+		}
+		b.WriteString(key + "=" + QuoteDotenv(secret.Value) + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
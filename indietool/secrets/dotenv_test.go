@@ -0,0 +1,239 @@
+package secrets
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Secret // Name, Value and Note only
+	}{
+		{
+			name:  "unquoted",
+			input: "KEY=value\nURL=https://example.com/a?b=c\n",
+			want:  []Secret{{Name: "KEY", Value: "value"}, {Name: "URL", Value: "https://example.com/a?b=c"}},
+		},
+		{
+			name:  "unquoted with inline comment and spaces",
+			input: "KEY =  some value   # not part of it\nHASH=a#b\n",
+			want:  []Secret{{Name: "KEY", Value: "some value"}, {Name: "HASH", Value: "a#b"}},
+		},
+		{
+			name:  "empty value",
+			input: "EMPTY=\nQUOTED=\"\"\n",
+			want:  []Secret{{Name: "EMPTY"}, {Name: "QUOTED"}},
+		},
+		{
+			name:  "export prefix",
+			input: "export TOKEN=abc\n",
+			want:  []Secret{{Name: "TOKEN", Value: "abc"}},
+		},
+		{
+			name:  "single quotes are literal",
+			input: `PASS='p@ss "w\n" $HOME # kept'` + "\n",
+			want:  []Secret{{Name: "PASS", Value: `p@ss "w\n" $HOME # kept`}},
+		},
+		{
+			name:  "double quote escapes",
+			input: `ESC="a\nb\tc \"q\" \\ \$HOME \x"` + "\n",
+			want:  []Secret{{Name: "ESC", Value: "a\nb\tc \"q\" \\ $HOME \\x"}},
+		},
+		{
+			name:  "quoted value with trailing comment",
+			input: `KEY="value" # comment` + "\n",
+			want:  []Secret{{Name: "KEY", Value: "value"}},
+		},
+		{
+			name:  "multiline double quoted",
+			input: "PEM=\"-----BEGIN KEY-----\nline \\\"two\\\"\n-----END KEY-----\"\nNEXT=1\n",
+			want:  []Secret{{Name: "PEM", Value: "-----BEGIN KEY-----\nline \"two\"\n-----END KEY-----"}, {Name: "NEXT", Value: "1"}},
+		},
+		{
+			name:  "multiline single quoted",
+			input: "CERT='a\n  b\n'\n",
+			want:  []Secret{{Name: "CERT", Value: "a\n  b\n"}},
+		},
+		{
+			name:  "comments directly above become notes",
+			input: "# Project header\n\n# OpenAI key for the bot\n#   rotated monthly\nOPENAI_KEY=sk\nPLAIN=x\n",
+			want:  []Secret{{Name: "OPENAI_KEY", Value: "sk", Note: "OpenAI key for the bot\nrotated monthly"}, {Name: "PLAIN", Value: "x"}},
+		},
+		{
+			name:  "repeated key keeps the last value and the earlier note",
+			input: "# first\nKEY=one\nKEY=two\n",
+			want:  []Secret{{Name: "KEY", Value: "two", Note: "first"}},
+		},
+		{
+			name:  "CRLF line endings",
+			input: "A=1\r\nB=\"x\r\ny\"\r\n",
+			want:  []Secret{{Name: "A", Value: "1"}, {Name: "B", Value: "x\ny"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDotenv(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseDotenv: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d secrets, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if got[i].Name != want.Name || got[i].Value != want.Value || got[i].Note != want.Note {
+					t.Errorf("secret %d = {%q %q %q}, want {%q %q %q}", i, got[i].Name, got[i].Value, got[i].Note, want.Name, want.Value, want.Note)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  string
+	}{
+		{name: "no equals sign", input: "A=1\nJUSTTEXT\n", line: "line 2"},
+		{name: "invalid key", input: "MY-KEY=1\n", line: "line 1"},
+		{name: "unterminated quote", input: "A=1\nB=\"open\nstill open\n", line: "line 2"},
+		{name: "text after closing quote", input: "A='x' y\n", line: "line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDotenv(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("error = %v, want one naming %s", err, tt.line)
+			}
+		})
+	}
+}
+
+func TestQuoteDotenv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"sk-abc_123", "sk-abc_123"},
+		{"postgres://u:p@h:5432/db", "postgres://u:p@h:5432/db"},
+		{"has space", `"has space"`},
+		{"a#b", `"a#b"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`back\slash`, `"back\\slash"`},
+		{"$HOME and `cmd`", "\"\\$HOME and \\`cmd\\`\""},
+		{"line1\nline2", `"line1\nline2"`},
+		{"it's", `"it's"`},
+	}
+
+	for _, tt := range tests {
+		if got := QuoteDotenv(tt.value); got != tt.want {
+			t.Errorf("QuoteDotenv(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDotenvKey(t *testing.T) {
+	tests := map[string]string{
+		"openai-key":   "OPENAI_KEY",
+		"OPENAI_KEY":   "OPENAI_KEY",
+		"stripe.live":  "STRIPE_LIVE",
+		"2fa-secret":   "_2FA_SECRET",
+		"clé-api":      "CL__API",
+		"already_fine": "ALREADY_FINE",
+	}
+	for name, want := range tests {
+		if got := DotenvKey(name); got != want {
+			t.Errorf("DotenvKey(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestWriteDotenv(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteDotenv(&buf, []*Secret{
+		{Name: "db-url", Value: "postgres://h/db"},
+		{Name: "openai-key", Value: "sk abc", Note: "Bot key\nrotated monthly"},
+		{Name: "empty", Value: ""},
+	})
+	if err != nil {
+		t.Fatalf("WriteDotenv: %v", err)
+	}
+	want := "DB_URL=postgres://h/db\n\n# Bot key\n# rotated monthly\nOPENAI_KEY=\"sk abc\"\nEMPTY=\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	err = WriteDotenv(&bytes.Buffer{}, []*Secret{{Name: "api-key", Value: "a"}, {Name: "API_KEY", Value: "b"}})
+	if err == nil || !strings.Contains(err.Error(), "API_KEY") {
+		t.Errorf("error = %v, want a collision on API_KEY", err)
+	}
+}
+
+func TestDotenvRoundTrip(t *testing.T) {
+	original := []*Secret{
+		{Name: "PLAIN", Value: "value"},
+		{Name: "EMPTY", Value: ""},
+		{Name: "SPACES", Value: "  padded  "},
+		{Name: "QUOTES", Value: `it's "quoted"`},
+		{Name: "SHELL", Value: "$HOME `whoami` \\n"},
+		{Name: "HASH", Value: "a #b"},
+		{Name: "PEM", Value: "-----BEGIN KEY-----\nabc\r\ndef\n-----END KEY-----\n", Note: "TLS key\nfrom the CA"},
+		{Name: "UNICODE", Value: "héllo ✓", Note: "café"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDotenv(&buf, original); err != nil {
+		t.Fatalf("WriteDotenv: %v", err)
+	}
+	parsed, err := ParseDotenv(&buf)
+	if err != nil {
+		t.Fatalf("ParseDotenv: %v\n%s", err, buf.String())
+	}
+
+	if len(parsed) != len(original) {
+		t.Fatalf("got %d secrets back, want %d", len(parsed), len(original))
+	}
+	for i, want := range original {
+		got := parsed[i]
+		if got.Name != want.Name || got.Value != want.Value || got.Note != want.Note {
+			t.Errorf("round trip of %s = {%q %q %q}, want {%q %q %q}", want.Name, got.Name, got.Value, got.Note, want.Name, want.Value, want.Note)
+		}
+	}
+}
+
+func TestParseDotenvTarget(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "work@home")
+	if err := os.WriteFile(existing, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		arg      string
+		path     string
+		database string
+	}{
+		{".env@proj", ".env", "proj"},
+		{".env", ".env", ""},
+		{"configs/.env@my-db.v2", "configs/.env", "my-db.v2"},
+		{"~/work@home/.env", "~/work@home/.env", ""}, // suffix isn't a database name
+		{existing, existing, ""},                     // the whole argument is a file
+		{"@proj", "@proj", ""},
+		{".env@", ".env@", ""},
+	}
+
+	for _, tt := range tests {
+		path, database := ParseDotenvTarget(tt.arg)
+		if path != tt.path || database != tt.database {
+			t.Errorf("ParseDotenvTarget(%q) = %q, %q; want %q, %q", tt.arg, path, database, tt.path, tt.database)
+		}
+	}
+}
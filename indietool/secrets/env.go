@@ -0,0 +1,155 @@
+package secrets
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultEnvFile is the mapping file `secrets run` reads from the working
+// directory when no other is given
+const DefaultEnvFile = ".indietool-env"
+
+// ErrSecretExpired is returned when a referenced secret has expired
+var ErrSecretExpired = errors.New("secret has expired")
+
+// envVarPattern matches portable environment variable names
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvMapping maps an environment variable to the secret that fills it
+type EnvMapping struct {
+	Var      string
+	Secret   string
+	Database string // Empty for the default database
+}
+
+// String renders the mapping as VAR=secret[@database]
+func (m EnvMapping) String() string {
+	if m.Database == "" {
+		return m.Var + "=" + m.Secret
+	}
+	return m.Var + "=" + m.Secret + "@" + m.Database
+}
+
+// ParseEnvMapping parses a VAR=secret[@database] mapping
+func ParseEnvMapping(s string) (EnvMapping, error) {
+	name, ref, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "export "))
+	ref = strings.Trim(strings.TrimSpace(ref), `"'`)
+	if !ok || name == "" || ref == "" {
+		return EnvMapping{}, fmt.Errorf("invalid mapping %q (use VAR=secret[@database])", s)
+	}
+	if !ValidEnvVar(name) {
+		return EnvMapping{}, fmt.Errorf("invalid environment variable name %q", name)
+	}
+
+	secret, database := ParseSecretIdentifier(ref)
+	if secret == "" {
+		return EnvMapping{}, fmt.Errorf("invalid mapping %q: missing secret name", s)
+	}
+	return EnvMapping{Var: name, Secret: secret, Database: database}, nil
+}
+
+// ValidEnvVar reports whether name can be used as an environment variable
+func ValidEnvVar(name string) bool {
+	return envVarPattern.MatchString(name)
+}
+
+// LoadEnvMappings reads a mapping file with one VAR=secret[@database] per
+// line. Blank lines and lines starting with # are skipped.
+func LoadEnvMappings(path string) ([]EnvMapping, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var mappings []EnvMapping
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		mapping, err := ParseEnvMapping(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return mappings, nil
+}
+
+// ResolveEnv decrypts the secrets the mappings refer to and returns them as
+// VAR=value pairs, in mapping order. Mappings without a database use
+// defaultDatabase. Expired secrets are refused unless allowExpired is set.
+func (m *Manager) ResolveEnv(mappings []EnvMapping, defaultDatabase string, allowExpired bool) ([]string, error) {
+	env := make([]string, 0, len(mappings))
+	var expired []string
+	for _, mapping := range mappings {
+		database := mapping.Database
+		if database == "" {
+			database = defaultDatabase
+		}
+
+		secret, err := m.GetSecret(mapping.Secret, database)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s@%s for %s: %w", mapping.Secret, database, mapping.Var, err)
+		}
+		if secret.IsExpired() && !allowExpired {
+			expired = append(expired, fmt.Sprintf("%s@%s (expired %s)", mapping.Secret, database, secret.ExpiresAt.Format("2006-01-02")))
+			continue
+		}
+		env = append(env, mapping.Var+"="+secret.Value)
+	}
+
+	if len(expired) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSecretExpired, strings.Join(expired, ", "))
+	}
+	return env, nil
+}
+
+// MergeEnvMappings adds mapping, replacing any earlier one for the same
+// variable
+func MergeEnvMappings(mappings []EnvMapping, mapping EnvMapping) []EnvMapping {
+	for i := range mappings {
+		if mappings[i].Var == mapping.Var {
+			mappings[i] = mapping
+			return mappings
+		}
+	}
+	return append(mappings, mapping)
+}
+
+// MergeEnv returns base with the VAR=value pairs in overrides set. Variables
+// in overrides replace those in base, and later overrides win over earlier
+// ones.
+func MergeEnv(base, overrides []string) []string {
+	values := make(map[string]string, len(overrides))
+	var order []string
+	for _, pair := range overrides {
+		name, _, _ := strings.Cut(pair, "=")
+		if _, seen := values[name]; !seen {
+			order = append(order, name)
+		}
+		values[name] = pair
+	}
+
+	merged := make([]string, 0, len(base)+len(order))
+	for _, pair := range base {
+		name, _, _ := strings.Cut(pair, "=")
+		if _, replaced := values[name]; !replaced {
+			merged = append(merged, pair)
+		}
+	}
+	for _, name := range order {
+		merged = append(merged, values[name])
+	}
+	return merged
+}
@@ -0,0 +1,189 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+// newTestManager returns a manager storing secrets under a temp dir, with
+// database keys held in an in-memory keyring
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	keyring.MockInit()
+	manager, err := NewManager(&Config{DefaultDatabase: "default", StorageDir: t.TempDir(), KeyBackend: "keyring"})
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return manager
+}
+
+func TestParseEnvMapping(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    EnvMapping
+		wantErr bool
+	}{
+		{input: "OPENAI_KEY=openai-key", want: EnvMapping{Var: "OPENAI_KEY", Secret: "openai-key"}},
+		{input: "OPENAI_KEY=openai-key@proj", want: EnvMapping{Var: "OPENAI_KEY", Secret: "openai-key", Database: "proj"}},
+		{input: "export _TOKEN = 'token@ci'", want: EnvMapping{Var: "_TOKEN", Secret: "token", Database: "ci"}},
+		{input: `KEY="key"`, want: EnvMapping{Var: "KEY", Secret: "key"}},
+		{input: "OPENAI_KEY", wantErr: true},
+		{input: "OPENAI_KEY=", wantErr: true},
+		{input: "=openai-key", wantErr: true},
+		{input: "1KEY=openai-key", wantErr: true},
+		{input: "MY-KEY=openai-key", wantErr: true},
+		{input: "KEY=@proj", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEnvMapping(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseEnvMapping(%q) = %+v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseEnvMapping(%q) = %+v, %v; want %+v", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestLoadEnvMappings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultEnvFile)
+	content := "# Secrets for the app\n\nOPENAI_KEY=openai-key@proj\n  export STRIPE_KEY=stripe-key  \n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	mappings, err := LoadEnvMappings(path)
+	if err != nil {
+		t.Fatalf("LoadEnvMappings: %v", err)
+	}
+	want := []EnvMapping{
+		{Var: "OPENAI_KEY", Secret: "openai-key", Database: "proj"},
+		{Var: "STRIPE_KEY", Secret: "stripe-key"},
+	}
+	if !reflect.DeepEqual(mappings, want) {
+		t.Errorf("mappings = %+v, want %+v", mappings, want)
+	}
+
+	if err := os.WriteFile(path, []byte("OK=ok\nnot a mapping\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEnvMappings(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("error = %v, want one naming line 2", err)
+	}
+
+	if _, err := LoadEnvMappings(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	manager := newTestManager(t)
+	expired := time.Now().Add(-time.Hour)
+	for _, s := range []struct{ name, value, db string }{
+		{"openai-key", "sk-default", "default"},
+		{"openai-key", "sk-proj", "proj"},
+		{"stripe-key", "rk-proj", "proj"},
+	} {
+		if err := manager.SetSecret(s.name, s.value, s.db, "", nil); err != nil {
+			t.Fatalf("SetSecret: %v", err)
+		}
+	}
+	if err := manager.SetSecret("old-key", "stale", "proj", "", &expired); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+
+	mappings := []EnvMapping{
+		{Var: "OPENAI_KEY", Secret: "openai-key", Database: "default"},
+		{Var: "STRIPE_KEY", Secret: "stripe-key"},
+		{Var: "PROJ_OPENAI_KEY", Secret: "openai-key"},
+	}
+	env, err := manager.ResolveEnv(mappings, "proj", false)
+	if err != nil {
+		t.Fatalf("ResolveEnv: %v", err)
+	}
+	want := []string{"OPENAI_KEY=sk-default", "STRIPE_KEY=rk-proj", "PROJ_OPENAI_KEY=sk-proj"}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("env = %v, want %v", env, want)
+	}
+
+	withExpired := append(mappings, EnvMapping{Var: "OLD_KEY", Secret: "old-key"})
+	env, err = manager.ResolveEnv(withExpired, "proj", false)
+	if !errors.Is(err, ErrSecretExpired) || env != nil {
+		t.Errorf("ResolveEnv with an expired secret = %v, %v; want ErrSecretExpired and no env", env, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "old-key@proj") {
+		t.Errorf("error = %v, want it to name old-key@proj", err)
+	}
+
+	env, err = manager.ResolveEnv(withExpired, "proj", true)
+	if err != nil || len(env) != 4 || env[3] != "OLD_KEY=stale" {
+		t.Errorf("ResolveEnv allowing expired = %v, %v", env, err)
+	}
+
+	if _, err := manager.ResolveEnv([]EnvMapping{{Var: "X", Secret: "missing"}}, "proj", false); err == nil {
+		t.Error("ResolveEnv with a missing secret succeeded")
+	}
+}
+
+func TestMergeEnvMappings(t *testing.T) {
+	mappings := []EnvMapping{{Var: "A", Secret: "a"}, {Var: "B", Secret: "b"}}
+	mappings = MergeEnvMappings(mappings, EnvMapping{Var: "A", Secret: "override", Database: "proj"})
+	mappings = MergeEnvMappings(mappings, EnvMapping{Var: "C", Secret: "c"})
+
+	want := []EnvMapping{{Var: "A", Secret: "override", Database: "proj"}, {Var: "B", Secret: "b"}, {Var: "C", Secret: "c"}}
+	if !reflect.DeepEqual(mappings, want) {
+		t.Errorf("mappings = %+v, want %+v", mappings, want)
+	}
+}
+
+func TestMergeEnv(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      []string
+		overrides []string
+		want      []string
+	}{
+		{
+			name:      "secrets replace inherited variables",
+			base:      []string{"PATH=/usr/bin", "OPENAI_KEY=from-shell", "HOME=/home/ana"},
+			overrides: []string{"OPENAI_KEY=sk-secret"},
+			want:      []string{"PATH=/usr/bin", "HOME=/home/ana", "OPENAI_KEY=sk-secret"},
+		},
+		{
+			name:      "later overrides win",
+			base:      []string{"PATH=/usr/bin"},
+			overrides: []string{"KEY=first", "OTHER=x", "KEY=second"},
+			want:      []string{"PATH=/usr/bin", "KEY=second", "OTHER=x"},
+		},
+		{
+			name:      "names match exactly",
+			base:      []string{"KEY_ID=1", "KEY=old"},
+			overrides: []string{"KEY=new=with=equals"},
+			want:      []string{"KEY_ID=1", "KEY=new=with=equals"},
+		},
+		{
+			name: "nothing to override",
+			base: []string{"PATH=/usr/bin"},
+			want: []string{"PATH=/usr/bin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeEnv(tt.base, tt.overrides); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeEnv = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
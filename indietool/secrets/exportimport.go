@@ -20,17 +20,22 @@ type ImportConflict struct {
 
 const exportFormatVersion = 1
 
+// NewExportData returns an empty export in the current format version.
+func NewExportData() *ExportData {
+	return &ExportData{
+		Version:    exportFormatVersion,
+		ExportedAt: time.Now().UTC(),
+		Databases:  make(map[string][]*Secret),
+	}
+}
+
 // ExportSecrets collects plaintext secrets according to spec.
 //
 // spec maps a database name to the set of secret names to export from it.
 // A nil slice means "export all secrets from that database".
 // Every key in spec must appear in the output, even if it has zero secrets.
 func (m *Manager) ExportSecrets(spec map[string][]string) (*ExportData, error) {
-	data := &ExportData{
-		Version:    exportFormatVersion,
-		ExportedAt: time.Now().UTC(),
-		Databases:  make(map[string][]*Secret, len(spec)),
-	}
+	data := NewExportData()
 
 	for db, names := range spec {
 		if names == nil {
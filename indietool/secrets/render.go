@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// RenderTemplate executes a text/template and writes the result to w.
+// Templates look secrets up with {{ secret "name[@database]" }}, where
+// names without a database use defaultDatabase, and can quote values for
+// a .env file with {{ secret "name" | dotenv }}. Nothing is written unless
+// every secret resolves; expired secrets are refused unless allowExpired
+// is set.
+func (m *Manager) RenderTemplate(w io.Writer, name, text, defaultDatabase string, allowExpired bool) error {
+	funcs := template.FuncMap{
+		"secret": func(identifier string) (string, error) {
+			secretName, database := ParseSecretIdentifier(identifier)
+			if secretName == "" {
+				return "", fmt.Errorf("missing secret name in %q", identifier)
+			}
+			if database == "" {
+				database = defaultDatabase
+			}
+
+			secret, err := m.GetSecret(secretName, database)
+			if err != nil {
+				return "", err
+			}
+			if secret.IsExpired() && !allowExpired {
+				return "", fmt.Errorf("%w: %s@%s (expired %s)", ErrSecretExpired, secretName, database, secret.ExpiresAt.Format("2006-01-02"))
+			}
+			return secret.Value, nil
+		},
+		"dotenv": QuoteDotenv,
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
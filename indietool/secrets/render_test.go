@@ -0,0 +1,108 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	manager := newTestManager(t)
+	expired := time.Now().Add(-time.Hour)
+	for _, s := range []struct{ name, value, db string }{
+		{"openai-key", "sk-proj", "proj"},
+		{"openai-key", "sk-default", "default"},
+		{"tls-key", "-----BEGIN KEY-----\nabc\n-----END KEY-----", "proj"},
+	} {
+		if err := manager.SetSecret(s.name, s.value, s.db, "", nil); err != nil {
+			t.Fatalf("SetSecret: %v", err)
+		}
+	}
+	if err := manager.SetSecret("old-key", "stale", "proj", "", &expired); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		template     string
+		allowExpired bool
+		want         string
+		wantErr      string
+	}{
+		{
+			name:     "default database",
+			template: `OPENAI_KEY={{ secret "openai-key" }}`,
+			want:     "OPENAI_KEY=sk-proj",
+		},
+		{
+			name:     "explicit database",
+			template: `OPENAI_KEY={{ secret "openai-key@default" }}`,
+			want:     "OPENAI_KEY=sk-default",
+		},
+		{
+			name:     "dotenv quoting",
+			template: `TLS_KEY={{ secret "tls-key" | dotenv }}` + "\n",
+			want:     `TLS_KEY="-----BEGIN KEY-----\nabc\n-----END KEY-----"` + "\n",
+		},
+		{
+			name:     "missing secret",
+			template: `A={{ secret "openai-key" }}` + "\n" + `B={{ secret "missing" }}`,
+			wantErr:  "missing",
+		},
+		{
+			name:     "missing database",
+			template: `A={{ secret "openai-key@nowhere" }}`,
+			wantErr:  "nowhere",
+		},
+		{
+			name:     "empty name",
+			template: `A={{ secret "@proj" }}`,
+			wantErr:  "missing secret name",
+		},
+		{
+			name:     "expired secret refused",
+			template: `OLD={{ secret "old-key" }}`,
+			wantErr:  ErrSecretExpired.Error(),
+		},
+		{
+			name:         "expired secret allowed",
+			template:     `OLD={{ secret "old-key" }}`,
+			allowExpired: true,
+			want:         "OLD=stale",
+		},
+		{
+			name:     "template syntax error",
+			template: `A={{ secret "openai-key" `,
+			wantErr:  "failed to parse template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := manager.RenderTemplate(&out, "test.tmpl", tt.template, "proj", tt.allowExpired)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				if out.Len() != 0 {
+					t.Errorf("wrote %q despite the error", out.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderTemplate: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+
+	err := manager.RenderTemplate(&bytes.Buffer{}, "test.tmpl", `{{ secret "old-key" }}`, "proj", false)
+	if !errors.Is(err, ErrSecretExpired) {
+		t.Errorf("error = %v, want it to wrap ErrSecretExpired", err)
+	}
+}
@@ -1,10 +1,14 @@
 package secrets
 
 import (
+	"regexp"
 	"strings"
 	"time"
 )
 
+// databaseNamePattern matches names that are safe as a storage directory
+var databaseNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
 // Secret represents a stored secret with metadata
 type Secret struct {
 	Name      string     `json:"name"`
@@ -48,6 +52,12 @@ func ParseSecretIdentifier(identifier string) (name, database string) {
 	return
 }
 
+// ValidDatabaseName reports whether name can be used as a database name:
+// letters, digits, '.', '_' and '-', starting with a letter or digit
+func ValidDatabaseName(name string) bool {
+	return databaseNamePattern.MatchString(name)
+}
+
 // IsExpired checks if a secret has expired
 func (s *Secret) IsExpired() bool {
 	return s.ExpiresAt != nil && time.Now().After(*s.ExpiresAt)
@@ -9,11 +9,13 @@ import (
 type OutputFormat string
 
 const (
-	FormatTable  OutputFormat = "table"
-	FormatWide   OutputFormat = "wide"
-	FormatJSON   OutputFormat = "json"
-	FormatYAML   OutputFormat = "yaml"
-	FormatCustom OutputFormat = "custom"
+	FormatTable    OutputFormat = "table"
+	FormatWide     OutputFormat = "wide"
+	FormatJSON     OutputFormat = "json"
+	FormatYAML     OutputFormat = "yaml"
+	FormatCustom   OutputFormat = "custom"
+	FormatCSV      OutputFormat = "csv"
+	FormatMarkdown OutputFormat = "markdown"
 )
 
 // Note: Column alignment is handled automatically by text/tabwriter
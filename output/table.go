@@ -1,6 +1,7 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,8 +23,9 @@ func NewTable(config TableConfig, options ...TableOptions) *Table {
 	columns := make([]Column, len(config.DefaultColumns))
 	copy(columns, config.DefaultColumns)
 
-	// Add wide columns if wide format is requested
-	if opts.Wide && opts.Format == FormatWide {
+	// Add wide columns if wide format is requested. CSV and Markdown are
+	// meant for other tools and people, so they always get every column.
+	if (opts.Wide && opts.Format == FormatWide) || opts.Format == FormatCSV || opts.Format == FormatMarkdown {
 		columns = append(columns, config.WideColumns...)
 	}
 
@@ -133,6 +135,10 @@ func (t *Table) Render() error {
 		return t.renderJSON()
 	case FormatYAML:
 		return t.renderYAML()
+	case FormatCSV:
+		return t.renderCSV()
+	case FormatMarkdown:
+		return t.renderMarkdown()
 	default:
 		return fmt.Errorf("unsupported format: %s", t.format)
 	}
@@ -260,6 +266,72 @@ func (t *Table) renderYAML() error {
 	return encoder.Encode(t.rows)
 }
 
+// CSV rendering
+
+// renderCSV writes one record per row using the formatted cell values, with
+// colors stripped and no truncation
+func (t *Table) renderCSV() error {
+	w := csv.NewWriter(t.writer)
+
+	if t.showHeaders {
+		headers := make([]string, len(t.columns))
+		for i, col := range t.columns {
+			headers[i] = col.Name
+		}
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range t.rows {
+		record := make([]string, len(t.columns))
+		for i, col := range t.columns {
+			record[i] = removeANSIColors(t.formatCellValue(row, col))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// Markdown rendering
+
+// renderMarkdown writes a Markdown table using the formatted cell values,
+// with colors stripped and pipes escaped. Markdown tables need a header
+// row, so it is always written.
+func (t *Table) renderMarkdown() error {
+	cells := func(values []string) string {
+		for i, value := range values {
+			values[i] = strings.ReplaceAll(value, "|", "\\|")
+		}
+		return "| " + strings.Join(values, " | ") + " |\n"
+	}
+
+	headers := make([]string, len(t.columns))
+	separators := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		headers[i] = col.Name
+		separators[i] = "---"
+	}
+	var b strings.Builder
+	b.WriteString(cells(headers))
+	b.WriteString(cells(separators))
+
+	for _, row := range t.rows {
+		record := make([]string, len(t.columns))
+		for i, col := range t.columns {
+			record[i] = removeANSIColors(t.formatCellValue(row, col))
+		}
+		b.WriteString(cells(record))
+	}
+
+	_, err := io.WriteString(t.writer, b.String())
+	return err
+}
+
 // Utility functions
 
 // convertToMap converts a struct to map[string]interface{} using reflection
@@ -145,38 +145,148 @@ func parseDomain(rd registrar.Domain) domains.ManagedDomain {
 
 // GetDomain retrieves a specific domain from Cloudflare
 func (c *CloudflareProvider) GetDomain(ctx context.Context, name string) (*domains.ManagedDomain, error) {
-	// TODO: Implement get domain from Cloudflare API
-	return nil, fmt.Errorf("not implemented")
+	domainList, err := c.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, domain := range domainList {
+		if strings.EqualFold(domain.Name, name) {
+			return &domain, nil
+		}
+	}
+	return nil, fmt.Errorf("provider/cloudflare: domain %s not found", name)
 }
 
 // UpdateAutoRenewal updates the auto-renewal setting for a domain
 func (c *CloudflareProvider) UpdateAutoRenewal(ctx context.Context, name string, enabled bool) error {
-	// TODO: Implement auto-renewal update via Cloudflare API
-	return fmt.Errorf("not implemented")
+	_, err := c.client.Registrar.Domains.Update(
+		ctx,
+		name,
+		registrar.DomainUpdateParams{
+			AccountID: cloudflare.F(c.config.AccountId),
+			AutoRenew: cloudflare.F(enabled),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("provider/cloudflare: failed to update auto-renewal for %s: %w", name, err)
+	}
+	return nil
 }
 
 // GetRenewalInfo retrieves renewal pricing information
 func (c *CloudflareProvider) GetRenewalInfo(ctx context.Context, name string) (*domains.DomainCost, error) {
-	// TODO: Implement renewal info retrieval from Cloudflare API
-	return nil, fmt.Errorf("not implemented")
+	data, err := c.registrarDomain(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cloudflare Registrar charges at cost in US dollars
+	fees := data.Get("fees")
+	if !fees.Get("renewal_fee").Exists() {
+		return nil, fmt.Errorf("provider/cloudflare: no renewal fee reported for %s", name)
+	}
+	return &domains.DomainCost{
+		Currency:      "USD",
+		RenewalPrice:  fees.Get("renewal_fee").Float(),
+		TransferPrice: fees.Get("transfer_fee").Float(),
+	}, nil
+}
+
+// GetTransferLock reports whether a domain is locked against transfers
+func (c *CloudflareProvider) GetTransferLock(ctx context.Context, name string) (bool, error) {
+	data, err := c.registrarDomain(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return data.Get("locked").Bool(), nil
+}
+
+// SetTransferLock locks or unlocks a domain for transfers
+func (c *CloudflareProvider) SetTransferLock(ctx context.Context, name string, locked bool) error {
+	_, err := c.client.Registrar.Domains.Update(
+		ctx,
+		name,
+		registrar.DomainUpdateParams{
+			AccountID: cloudflare.F(c.config.AccountId),
+			Locked:    cloudflare.F(locked),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("provider/cloudflare: failed to update transfer lock for %s: %w", name, err)
+	}
+	return nil
+}
+
+// registrarDomain returns the raw registrar record of a domain. The SDK
+// doesn't model fees or the lock, so they are read from the raw response.
+func (c *CloudflareProvider) registrarDomain(ctx context.Context, name string) (gjson.Result, error) {
+	cfdomains, err := c.client.Registrar.Domains.List(
+		ctx,
+		registrar.DomainListParams{
+			AccountID: cloudflare.F(c.config.AccountId),
+		},
+	)
+	if err != nil {
+		return gjson.Result{}, fmt.Errorf("provider/cloudflare: failed to list domains: %w", err)
+	}
+
+	for _, d := range cfdomains.Result {
+		data := gjson.Parse(d.JSON.RawJSON())
+		if strings.EqualFold(data.Get("name").Str, name) {
+			return data, nil
+		}
+	}
+	return gjson.Result{}, fmt.Errorf("provider/cloudflare: domain %s not found", name)
 }
 
 // GetNameservers retrieves nameservers for a domain
 func (c *CloudflareProvider) GetNameservers(ctx context.Context, name string) ([]string, error) {
-	// TODO: Implement nameserver retrieval from Cloudflare API
-	return nil, fmt.Errorf("not implemented")
+	domain, err := c.GetDomain(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Nameservers, nil
 }
 
-// UpdateNameservers updates nameservers for a domain
+// UpdateNameservers updates nameservers for a domain. Cloudflare Registrar
+// only serves domains from Cloudflare's own nameservers, so this always fails.
 func (c *CloudflareProvider) UpdateNameservers(ctx context.Context, name string, nameservers []string) error {
-	// TODO: Implement nameserver update via Cloudflare API
-	return fmt.Errorf("not implemented")
+	return fmt.Errorf("provider/cloudflare: domains registered with Cloudflare must use Cloudflare nameservers: %w", domains.ErrNotSupported)
+}
+
+// AssignedNameservers returns the nameservers Cloudflare assigned to the zone
+// of domain
+func (c *CloudflareProvider) AssignedNameservers(ctx context.Context, domain string) ([]string, error) {
+	resp, err := c.client.Zones.List(ctx, zones.ZoneListParams{
+		Name: cloudflare.F(domain),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	if len(resp.Result) == 0 {
+		return nil, fmt.Errorf("no Cloudflare zone for %s; add the site in Cloudflare first", domain)
+	}
+	return resp.Result[0].NameServers, nil
 }
 
 // ============================================================================
 // DNS Provider Methods
 // ============================================================================
 
+// ListZones returns the names of all zones in the Cloudflare account
+func (c *CloudflareProvider) ListZones(ctx context.Context) ([]string, error) {
+	var names []string
+	iter := c.client.Zones.ListAutoPaging(ctx, zones.ZoneListParams{})
+	for iter.Next() {
+		names = append(names, iter.Current().Name)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	return names, nil
+}
+
 // ListRecords retrieves all DNS records for a domain
 func (c *CloudflareProvider) ListRecords(ctx context.Context, domain string) ([]dns.Record, error) {
 	zoneID, err := c.getZoneID(ctx, domain)
@@ -210,8 +320,14 @@ func (c *CloudflareProvider) SetRecord(ctx context.Context, domain string, recor
 		return fmt.Errorf("failed to get zone ID for domain %s: %w", domain, err)
 	}
 
+	// Update a specific record when the caller picked one by ID
+	if record.ID != "" {
+		log.Debugf("Updating DNS record %s: %s %s %s", record.ID, record.Name, record.Type, record.Content)
+		return c.updateRecord(ctx, zoneID, record.ID, record)
+	}
+
 	// Check if record already exists
-	existingRecord, err := c.findExistingRecord(ctx, zoneID, record.Name, record.Type)
+	existingRecord, err := c.findExistingRecord(ctx, zoneID, domain, record.Name, record.Type)
 	if err != nil {
 		return fmt.Errorf("failed to check for existing record: %w", err)
 	}
@@ -252,7 +368,7 @@ func (c *CloudflareProvider) GetRecord(ctx context.Context, domain, name, record
 		return nil, fmt.Errorf("failed to get zone ID for domain %s: %w", domain, err)
 	}
 
-	existingRecord, err := c.findExistingRecord(ctx, zoneID, name, recordType)
+	existingRecord, err := c.findExistingRecord(ctx, zoneID, domain, name, recordType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find DNS record: %w", err)
 	}
@@ -288,8 +404,10 @@ func (c *CloudflareProvider) getZoneID(ctx context.Context, domain string) (stri
 	return zoneID, nil
 }
 
-// findExistingRecord searches for an existing DNS record by name and type
-func (c *CloudflareProvider) findExistingRecord(ctx context.Context, zoneID, name, recordType string) (*cfDNS.RecordResponse, error) {
+// findExistingRecord searches for an existing DNS record by name and type.
+// Cloudflare returns fully qualified names, so they are compared in the
+// relative form used by the rest of indietool.
+func (c *CloudflareProvider) findExistingRecord(ctx context.Context, zoneID, domain, name, recordType string) (*cfDNS.RecordResponse, error) {
 	resp, err := c.client.DNS.Records.List(ctx, cfDNS.RecordListParams{
 		ZoneID: cloudflare.F(zoneID),
 		Type:   cloudflare.F(cfDNS.RecordListParamsType(recordType)),
@@ -299,8 +417,9 @@ func (c *CloudflareProvider) findExistingRecord(ctx context.Context, zoneID, nam
 	}
 
 	// Filter by name manually since the Name parameter seems to have type issues
+	name = dns.NormalizeName(name, domain)
 	for _, record := range resp.Result {
-		if record.Name == name {
+		if strings.EqualFold(c.convertFromCloudflareRecord(record, domain).Name, name) {
 			return &record, nil
 		}
 	}
@@ -409,12 +528,10 @@ func (c *CloudflareProvider) convertFromCloudflareRecord(cfRecord cfDNS.RecordRe
 		record.Name = cfRecord.Name
 	}
 
-	// Handle MX priority - we'll need to parse it from the content for now
-	// The Cloudflare SDK v4 has a different structure, so this is simplified for MVP
+	// Handle MX priority - the SDK leaves Priority empty for MX records, so
+	// read it from the raw response instead
 	if cfRecord.Type == cfDNS.RecordResponseTypeMX {
-		// For MX records, priority might be in the record data
-		// This is a simplified approach for the MVP
-		priority := 10 // Default priority
+		priority := int(gjson.Get(cfRecord.JSON.RawJSON(), "priority").Int())
 		record.Priority = &priority
 	}
 
@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"context"
+	"indietool/cli/dns"
+	"indietool/cli/dns/dnstest"
+	"indietool/cli/domains"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	"github.com/tuzzmaniandevil/porkbun-go"
+)
+
+const conformanceDomain = "example.com"
+
+func TestMockConformance(t *testing.T) {
+	dnstest.Run(t, conformanceDomain, func(t *testing.T) dns.Provider {
+		mock := NewMock(MockConfig{
+			StatePath: filepath.Join(t.TempDir(), "state.yaml"),
+			Enabled:   true,
+		})
+		if err := mock.AddDomain(domains.ManagedDomain{Name: conformanceDomain}); err != nil {
+			t.Fatalf("AddDomain: %v", err)
+		}
+		return mock
+	}, dnstest.Options{})
+}
+
+func TestCloudflareConformance(t *testing.T) {
+	dnstest.Run(t, conformanceDomain, func(t *testing.T) dns.Provider {
+		srv := newFakeCloudflare(t, newFakeZone(conformanceDomain))
+		return &CloudflareProvider{
+			client: cloudflare.NewClient(
+				option.WithAPIToken("test-token"),
+				option.WithBaseURL(srv.URL+"/"),
+				option.WithMaxRetries(0),
+			),
+			config: CloudflareConfig{APIToken: "test-token", Enabled: true},
+		}
+	}, dnstest.Options{})
+}
+
+func TestPorkbunConformance(t *testing.T) {
+	dnstest.Run(t, conformanceDomain, func(t *testing.T) dns.Provider {
+		srv := newFakePorkbun(t, newFakeZone(conformanceDomain))
+		var httpClient porkbun.HTTPClient = newRedirectClient(srv)
+		return &PorkbunProvider{
+			client: porkbun.NewClient(&porkbun.Options{
+				HttpClient:   &httpClient,
+				ApiKey:       "pk1_test",
+				SecretApiKey: "sk1_test",
+			}),
+			config: PorkbunConfig{APIKey: "pk1_test", APISecret: "sk1_test", Enabled: true},
+		}
+	}, dnstest.Options{})
+}
+
+func TestNamecheapConformance(t *testing.T) {
+	dnstest.Run(t, conformanceDomain, func(t *testing.T) dns.Provider {
+		srv := newFakeNamecheap(t, newFakeZone(conformanceDomain))
+		nc := NewNamecheap(NamecheapConfig{
+			APIKey:   "test-key",
+			Username: "test-user",
+			ClientIP: "192.0.2.100",
+			Enabled:  true,
+		})
+		nc.client.BaseURL = srv.URL
+		return nc
+	}, dnstest.Options{})
+}
+
+func TestTheLittleHostConformance(t *testing.T) {
+	dnstest.Run(t, conformanceDomain, func(t *testing.T) dns.Provider {
+		srv := newFakeTheLittleHost(t, newFakeZone(conformanceDomain))
+		return NewTheLittleHost(TheLittleHostConfig{
+			APIKey:  "tlh_test",
+			BaseURL: srv.URL,
+			Enabled: true,
+		})
+	}, dnstest.Options{})
+}
+
+func TestMockStatePersists(t *testing.T) {
+	ctx := context.Background()
+
+	for _, name := range []string{"state.yaml", "state.json"} {
+		t.Run(name, func(t *testing.T) {
+			config := MockConfig{StatePath: filepath.Join(t.TempDir(), name), Enabled: true}
+
+			first := NewMock(config)
+			expiry := time.Now().AddDate(0, 2, 0).Truncate(time.Second)
+			err := first.AddDomain(domains.ManagedDomain{
+				Name:        conformanceDomain,
+				ExpiryDate:  expiry,
+				Nameservers: []string{"ns1.example.net"},
+				Cost:        &domains.DomainCost{Currency: "USD", RenewalPrice: 10.5},
+			})
+			if err != nil {
+				t.Fatalf("AddDomain: %v", err)
+			}
+			if err := first.SetRecord(ctx, conformanceDomain, dns.Record{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 300}); err != nil {
+				t.Fatalf("SetRecord: %v", err)
+			}
+			if err := first.UpdateAutoRenewal(ctx, conformanceDomain, true); err != nil {
+				t.Fatalf("UpdateAutoRenewal: %v", err)
+			}
+
+			// A second instance must see everything written by the first
+			second := NewMock(config)
+
+			domain, err := second.GetDomain(ctx, conformanceDomain)
+			if err != nil {
+				t.Fatalf("GetDomain: %v", err)
+			}
+			if !domain.AutoRenewal || !domain.ExpiryDate.Equal(expiry) || domain.Provider != "mock" {
+				t.Errorf("GetDomain = %+v, want auto-renewal on, expiry %v and provider mock", domain, expiry)
+			}
+
+			cost, err := second.GetRenewalInfo(ctx, conformanceDomain)
+			if err != nil || cost.RenewalPrice != 10.5 {
+				t.Errorf("GetRenewalInfo = %+v, %v; want renewal price 10.5", cost, err)
+			}
+
+			record, err := second.GetRecord(ctx, conformanceDomain, "www", "A")
+			if err != nil || record.Content != "192.0.2.1" {
+				t.Errorf("GetRecord = %+v, %v; want www A 192.0.2.1", record, err)
+			}
+
+			if _, err := second.ListRecords(ctx, "unknown.example"); err == nil {
+				t.Error("ListRecords for an unknown zone succeeded, want an error")
+			}
+		})
+	}
+}
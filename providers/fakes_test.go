@@ -0,0 +1,553 @@
+package providers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRecord is a DNS record held by one of the fake provider APIs. Names
+// are stored relative to the zone with "@" for the apex; each fake renders
+// them the way its real API does.
+type fakeRecord struct {
+	ID       int
+	Name     string
+	Type     string
+	Content  string
+	TTL      int
+	Priority int
+}
+
+// fakeZone is the in-memory zone shared by the fake APIs below
+type fakeZone struct {
+	mu      sync.Mutex
+	domain  string
+	nextID  int
+	records []fakeRecord
+}
+
+func newFakeZone(domain string) *fakeZone {
+	return &fakeZone{domain: domain, nextID: 100}
+}
+
+// relative converts a name as sent by a client into the zone-relative form
+func (z *fakeZone) relative(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == "" || name == "@" || name == z.domain {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+z.domain)
+}
+
+// fqdn renders a zone-relative name as a fully qualified name
+func (z *fakeZone) fqdn(name string) string {
+	if name == "@" {
+		return z.domain
+	}
+	return name + "." + z.domain
+}
+
+func (z *fakeZone) list() []fakeRecord {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	return append([]fakeRecord(nil), z.records...)
+}
+
+func (z *fakeZone) add(r fakeRecord) fakeRecord {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.nextID++
+	r.ID = z.nextID
+	r.Name = z.relative(r.Name)
+	z.records = append(z.records, r)
+	return r
+}
+
+func (z *fakeZone) update(id int, r fakeRecord) (fakeRecord, bool) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	for i := range z.records {
+		if z.records[i].ID == id {
+			r.ID = id
+			r.Name = z.relative(r.Name)
+			z.records[i] = r
+			return r, true
+		}
+	}
+	return fakeRecord{}, false
+}
+
+func (z *fakeZone) remove(id int) bool {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	for i := range z.records {
+		if z.records[i].ID == id {
+			z.records = append(z.records[:i], z.records[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// replace swaps the whole zone, assigning fresh IDs like Namecheap's setHosts
+func (z *fakeZone) replace(records []fakeRecord) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.records = nil
+	for _, r := range records {
+		z.nextID++
+		r.ID = z.nextID
+		r.Name = z.relative(r.Name)
+		z.records = append(z.records, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// ============================================================================
+// Cloudflare
+// ============================================================================
+
+// newFakeCloudflare serves the subset of the Cloudflare v4 API used by
+// CloudflareProvider. Like the real API, record names are returned fully
+// qualified.
+func newFakeCloudflare(t *testing.T, zone *fakeZone) *httptest.Server {
+	const zoneID = "zone-1"
+
+	render := func(r fakeRecord) map[string]any {
+		out := map[string]any{
+			"id":        strconv.Itoa(r.ID),
+			"name":      zone.fqdn(r.Name),
+			"type":      r.Type,
+			"content":   r.Content,
+			"ttl":       r.TTL,
+			"proxied":   false,
+			"proxiable": r.Type == "A" || r.Type == "AAAA" || r.Type == "CNAME",
+		}
+		if r.Type == "MX" {
+			out["priority"] = r.Priority
+		}
+		return out
+	}
+	success := func(w http.ResponseWriter, result any) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"success": true, "errors": []any{}, "messages": []any{}, "result": result,
+		})
+	}
+	page := func(w http.ResponseWriter, result []any) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"success": true, "errors": []any{}, "messages": []any{}, "result": result,
+			"result_info": map[string]any{"page": 1, "per_page": 100, "count": len(result), "total_count": len(result)},
+		})
+	}
+	notFound := func(w http.ResponseWriter) {
+		writeJSON(w, http.StatusNotFound, map[string]any{
+			"success": false, "messages": []any{}, "result": nil,
+			"errors": []any{map[string]any{"code": 81044, "message": "Record does not exist."}},
+		})
+	}
+	decode := func(r *http.Request) (fakeRecord, error) {
+		var body struct {
+			Name     string  `json:"name"`
+			Type     string  `json:"type"`
+			Content  string  `json:"content"`
+			TTL      float64 `json:"ttl"`
+			Priority float64 `json:"priority"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		return fakeRecord{Name: body.Name, Type: body.Type, Content: body.Content, TTL: int(body.TTL), Priority: int(body.Priority)}, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /zones", func(w http.ResponseWriter, r *http.Request) {
+		var result []any
+		if name := r.URL.Query().Get("name"); name == "" || name == zone.domain {
+			result = append(result, map[string]any{"id": zoneID, "name": zone.domain})
+		}
+		page(w, result)
+	})
+	mux.HandleFunc("GET /zones/{zone}/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		result := []any{}
+		for _, rec := range zone.list() {
+			if typ := r.URL.Query().Get("type"); typ != "" && typ != rec.Type {
+				continue
+			}
+			result = append(result, render(rec))
+		}
+		page(w, result)
+	})
+	mux.HandleFunc("POST /zones/{zone}/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		rec, err := decode(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		success(w, render(zone.add(rec)))
+	})
+	mux.HandleFunc("PUT /zones/{zone}/dns_records/{id}", func(w http.ResponseWriter, r *http.Request) {
+		rec, err := decode(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id, _ := strconv.Atoi(r.PathValue("id"))
+		updated, ok := zone.update(id, rec)
+		if !ok {
+			notFound(w)
+			return
+		}
+		success(w, render(updated))
+	})
+	mux.HandleFunc("DELETE /zones/{zone}/dns_records/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(r.PathValue("id"))
+		if !zone.remove(id) {
+			notFound(w)
+			return
+		}
+		success(w, map[string]any{"id": r.PathValue("id")})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// ============================================================================
+// Porkbun
+// ============================================================================
+
+// newFakePorkbun serves the DNS endpoints of the Porkbun v3 JSON API. Like
+// the real API, retrieve returns fully qualified names and string fields.
+func newFakePorkbun(t *testing.T, zone *fakeZone) *httptest.Server {
+	render := func(r fakeRecord) map[string]any {
+		return map[string]any{
+			"id":      strconv.Itoa(r.ID),
+			"name":    zone.fqdn(r.Name),
+			"type":    r.Type,
+			"content": r.Content,
+			"ttl":     strconv.Itoa(r.TTL),
+			"prio":    strconv.Itoa(r.Priority),
+			"notes":   "",
+		}
+	}
+	fail := func(w http.ResponseWriter, message string) {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"status": "ERROR", "message": message})
+	}
+	decode := func(r *http.Request) (fakeRecord, error) {
+		var body struct {
+			Name    string `json:"name"`
+			Type    string `json:"type"`
+			Content string `json:"content"`
+			TTL     string `json:"ttl"`
+			Prio    string `json:"prio"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		ttl, _ := strconv.Atoi(body.TTL)
+		prio, _ := strconv.Atoi(body.Prio)
+		return fakeRecord{Name: body.Name, Type: body.Type, Content: body.Content, TTL: ttl, Priority: prio}, err
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// /api/json/v3/dns/<action>/<domain>[/<segments>...]
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/json/v3/dns/"), "/")
+		if len(parts) < 2 || parts[1] != zone.domain {
+			fail(w, "Invalid domain.")
+			return
+		}
+		action, args := parts[0], parts[2:]
+
+		switch action {
+		case "retrieve", "retrieveByNameType":
+			records := []any{}
+			for _, rec := range zone.list() {
+				if action == "retrieve" && len(args) > 0 && strconv.Itoa(rec.ID) != args[0] {
+					continue
+				}
+				if action == "retrieveByNameType" {
+					subdomain := ""
+					if len(args) > 1 {
+						subdomain = args[1]
+					}
+					if len(args) == 0 || rec.Type != args[0] || rec.Name != zone.relative(subdomain) {
+						continue
+					}
+				}
+				records = append(records, render(rec))
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"status": "SUCCESS", "records": records})
+		case "create":
+			rec, err := decode(r)
+			if err != nil {
+				fail(w, err.Error())
+				return
+			}
+			created := zone.add(rec)
+			writeJSON(w, http.StatusOK, map[string]any{"status": "SUCCESS", "id": created.ID})
+		case "edit":
+			rec, err := decode(r)
+			if err != nil || len(args) != 1 {
+				fail(w, "Invalid request.")
+				return
+			}
+			id, _ := strconv.Atoi(args[0])
+			if _, ok := zone.update(id, rec); !ok {
+				fail(w, "Edit error: We were unable to edit the DNS record.")
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"status": "SUCCESS"})
+		case "delete":
+			if len(args) != 1 {
+				fail(w, "Invalid request.")
+				return
+			}
+			id, _ := strconv.Atoi(args[0])
+			if !zone.remove(id) {
+				fail(w, "Invalid record ID.")
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"status": "SUCCESS"})
+		default:
+			fail(w, "Unknown action.")
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(handler))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// redirectClient sends requests to a test server regardless of the host in
+// the request URL. It lets SDKs with a hard-coded base URL talk to a fake.
+type redirectClient struct {
+	target *url.URL
+	client *http.Client
+}
+
+func newRedirectClient(srv *httptest.Server) *redirectClient {
+	target, _ := url.Parse(srv.URL)
+	return &redirectClient{target: target, client: srv.Client()}
+}
+
+func (c *redirectClient) Do(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = c.target.Scheme
+	req.URL.Host = c.target.Host
+	req.Host = c.target.Host
+	return c.client.Do(req)
+}
+
+// ============================================================================
+// Namecheap
+// ============================================================================
+
+type ncHost struct {
+	HostId  int    `xml:"HostId,attr"`
+	Name    string `xml:"Name,attr"`
+	Type    string `xml:"Type,attr"`
+	Address string `xml:"Address,attr"`
+	MXPref  int    `xml:"MXPref,attr"`
+	TTL     int    `xml:"TTL,attr"`
+}
+
+// newFakeNamecheap serves the getHosts/setHosts commands of the Namecheap XML
+// API. Like the real API, the apex is returned as "@", every host carries an
+// MXPref and setHosts replaces the zone, assigning new host IDs.
+func newFakeNamecheap(t *testing.T, zone *fakeZone) *httptest.Server {
+	respond := func(w http.ResponseWriter, command, body string) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <RequestedCommand>%s</RequestedCommand>
+  <CommandResponse Type="%s">%s</CommandResponse>
+</ApiResponse>`, command, command, body)
+	}
+	fail := func(w http.ResponseWriter, message string) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors><Error Number="2019166">%s</Error></Errors>
+</ApiResponse>`, message)
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			fail(w, err.Error())
+			return
+		}
+		if domain := r.Form.Get("SLD") + "." + r.Form.Get("TLD"); domain != zone.domain {
+			fail(w, "Domain not found")
+			return
+		}
+
+		command := r.Form.Get("Command")
+		switch command {
+		case "namecheap.domains.dns.getHosts":
+			var hosts []byte
+			for _, rec := range zone.list() {
+				priority := rec.Priority
+				if rec.Type != "MX" {
+					priority = 10
+				}
+				out, _ := xml.Marshal(struct {
+					XMLName xml.Name `xml:"host"`
+					ncHost
+				}{ncHost: ncHost{HostId: rec.ID, Name: rec.Name, Type: rec.Type, Address: rec.Content, MXPref: priority, TTL: rec.TTL}})
+				hosts = append(hosts, out...)
+			}
+			respond(w, command, fmt.Sprintf(`<DomainDNSGetHostsResult Domain="%s" IsUsingOurDNS="true">%s</DomainDNSGetHostsResult>`, zone.domain, hosts))
+		case "namecheap.domains.dns.setHosts":
+			var indexes []int
+			for key := range r.Form {
+				if rest, ok := strings.CutPrefix(key, "HostName"); ok {
+					if i, err := strconv.Atoi(rest); err == nil {
+						indexes = append(indexes, i)
+					}
+				}
+			}
+			sort.Ints(indexes)
+
+			var records []fakeRecord
+			for _, i := range indexes {
+				n := strconv.Itoa(i)
+				rec := fakeRecord{
+					Name:    r.Form.Get("HostName" + n),
+					Type:    r.Form.Get("RecordType" + n),
+					Content: r.Form.Get("Address" + n),
+				}
+				rec.TTL, _ = strconv.Atoi(r.Form.Get("TTL" + n))
+				rec.Priority, _ = strconv.Atoi(r.Form.Get("MXPref" + n))
+				if rec.Type == "MX" && r.Form.Get("EmailType") != "MX" {
+					fail(w, "MX records require EmailType=MX")
+					return
+				}
+				records = append(records, rec)
+			}
+			zone.replace(records)
+			respond(w, command, fmt.Sprintf(`<DomainDNSSetHostsResult Domain="%s" IsSuccess="true" />`, zone.domain))
+		default:
+			fail(w, "Unknown command")
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(handler))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// ============================================================================
+// The Little Host
+// ============================================================================
+
+// newFakeTheLittleHost serves the zone and record endpoints of The Little
+// Host REST API
+func newFakeTheLittleHost(t *testing.T, zone *fakeZone) *httptest.Server {
+	const zoneID = 1
+
+	render := func(r fakeRecord) tlhRecord {
+		out := tlhRecord{ID: r.ID, RecordType: r.Type, Name: r.Name, Value: r.Content, TTL: r.TTL}
+		if r.Type == "MX" {
+			priority := r.Priority
+			out.Priority = &priority
+		}
+		return out
+	}
+	decode := func(r *http.Request) (fakeRecord, error) {
+		var body tlhRecordRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		rec := fakeRecord{Name: body.Record.Name, Type: body.Record.RecordType, Content: body.Record.Value, TTL: body.Record.TTL}
+		if body.Record.Priority != nil {
+			rec.Priority = *body.Record.Priority
+		}
+		return rec, err
+	}
+	notFound := func(w http.ResponseWriter) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not found"})
+	}
+	checkZone := func(w http.ResponseWriter, r *http.Request) bool {
+		if ref := r.PathValue("zone"); ref != zone.domain && ref != strconv.Itoa(zoneID) {
+			notFound(w)
+			return false
+		}
+		return true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /zones", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, []tlhZone{{ID: zoneID, DomainName: zone.domain}})
+	})
+	mux.HandleFunc("GET /zones/{zone}", func(w http.ResponseWriter, r *http.Request) {
+		if checkZone(w, r) {
+			writeJSON(w, http.StatusOK, tlhZone{ID: zoneID, DomainName: zone.domain})
+		}
+	})
+	mux.HandleFunc("GET /zones/{zone}/records", func(w http.ResponseWriter, r *http.Request) {
+		if !checkZone(w, r) {
+			return
+		}
+		records := []tlhRecord{}
+		for _, rec := range zone.list() {
+			records = append(records, render(rec))
+		}
+		writeJSON(w, http.StatusOK, records)
+	})
+	mux.HandleFunc("POST /zones/{zone}/records", func(w http.ResponseWriter, r *http.Request) {
+		if !checkZone(w, r) {
+			return
+		}
+		rec, err := decode(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		writeJSON(w, http.StatusCreated, render(zone.add(rec)))
+	})
+	mux.HandleFunc("PATCH /zones/{zone}/records/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !checkZone(w, r) {
+			return
+		}
+		rec, err := decode(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		id, _ := strconv.Atoi(r.PathValue("id"))
+		updated, ok := zone.update(id, rec)
+		if !ok {
+			notFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, render(updated))
+	})
+	mux.HandleFunc("DELETE /zones/{zone}/records/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !checkZone(w, r) {
+			return
+		}
+		id, _ := strconv.Atoi(r.PathValue("id"))
+		if !zone.remove(id) {
+			notFound(w)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
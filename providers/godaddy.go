@@ -1,12 +1,16 @@
 package providers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"indietool/cli/domains"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +20,7 @@ type GoDaddyConfig struct {
 	APISecret   string `yaml:"api_secret"`
 	Environment string `yaml:"environment"` // "production" or "ote" (test environment)
 	Enabled     bool   `yaml:"enabled"`
+	ClientIP    string `yaml:"client_ip,omitempty"` // Recorded as who agreed to the registration terms
 }
 
 // IsEnabled implements ProviderConfig interface
@@ -53,11 +58,21 @@ func NewGoDaddyClient(apiKey, apiSecret, environment string) *GoDaddyClient {
 	}
 }
 
-// makeRequest makes an authenticated HTTP request to the GoDaddy API
-func (c *GoDaddyClient) makeRequest(ctx context.Context, method, endpoint string) (*http.Response, error) {
+// makeRequest makes an authenticated HTTP request to the GoDaddy API. A
+// non-nil payload is sent as the JSON request body.
+func (c *GoDaddyClient) makeRequest(ctx context.Context, method, endpoint string, payload any) (*http.Response, error) {
 	url := c.baseURL + endpoint
 
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -66,6 +81,9 @@ func (c *GoDaddyClient) makeRequest(ctx context.Context, method, endpoint string
 	authHeader := fmt.Sprintf("sso-key %s:%s", c.apiKey, c.apiSecret)
 	req.Header.Set("Authorization", authHeader)
 	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -96,7 +114,7 @@ type GoDaddyDomain struct {
 
 // ListDomains retrieves all domains from GoDaddy
 func (c *GoDaddyClient) ListDomains(ctx context.Context) ([]GoDaddyDomain, error) {
-	resp, err := c.makeRequest(ctx, "GET", "/v1/domains")
+	resp, err := c.makeRequest(ctx, "GET", "/v1/domains", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +133,150 @@ func (c *GoDaddyClient) ListDomains(ctx context.Context) ([]GoDaddyDomain, error
 	return domains, nil
 }
 
+// goDaddyPriceUnit converts GoDaddy prices, given in micro-units, to currency units
+const goDaddyPriceUnit = 1_000_000
+
+// GoDaddyDomainDetail is the subset of a single domain's details used by
+// indietool
+type GoDaddyDomainDetail struct {
+	GoDaddyDomain
+	AuthCode string `json:"authCode"`
+	Renewal  *struct {
+		Currency  string `json:"currency"`
+		Price     int64  `json:"price"` // Micro-units
+		Renewable bool   `json:"renewable"`
+	} `json:"renewal"`
+}
+
+// GetDomainDetail retrieves the details of a single domain
+func (c *GoDaddyClient) GetDomainDetail(ctx context.Context, name string) (*GoDaddyDomainDetail, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/v1/domains/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var detail GoDaddyDomainDetail
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &detail, nil
+}
+
+// UpdateDomain patches the settings of a single domain
+func (c *GoDaddyClient) UpdateDomain(ctx context.Context, name string, update map[string]any) error {
+	resp, err := c.makeRequest(ctx, "PATCH", "/v1/domains/"+name, update)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// GoDaddyAvailability is GoDaddy's answer to an availability check
+type GoDaddyAvailability struct {
+	Domain    string `json:"domain"`
+	Available bool   `json:"available"`
+	Currency  string `json:"currency"`
+	Price     int64  `json:"price"`  // Micro-units
+	Period    int    `json:"period"` // Years the price covers
+}
+
+// CheckAvailability checks whether a domain can be registered and its price
+func (c *GoDaddyClient) CheckAvailability(ctx context.Context, name string) (*GoDaddyAvailability, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/v1/domains/available?domain="+url.QueryEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var availability GoDaddyAvailability
+	if err := json.NewDecoder(resp.Body).Decode(&availability); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &availability, nil
+}
+
+// GoDaddyAgreement is a legal agreement that must be accepted to register
+// a domain
+type GoDaddyAgreement struct {
+	AgreementKey string `json:"agreementKey"`
+	Title        string `json:"title"`
+	URL          string `json:"url"`
+}
+
+// GetAgreements retrieves the agreements for registering a domain in tld
+func (c *GoDaddyClient) GetAgreements(ctx context.Context, tld string) ([]GoDaddyAgreement, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/v1/domains/agreements?privacy=false&tlds="+url.QueryEscape(tld), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var agreements []GoDaddyAgreement
+	if err := json.NewDecoder(resp.Body).Decode(&agreements); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return agreements, nil
+}
+
+// GoDaddyContact is a contact in a GoDaddy purchase
+type GoDaddyContact struct {
+	NameFirst      string `json:"nameFirst"`
+	NameLast       string `json:"nameLast"`
+	Organization   string `json:"organization,omitempty"`
+	Email          string `json:"email"`
+	Phone          string `json:"phone"`
+	AddressMailing struct {
+		Address1   string `json:"address1"`
+		Address2   string `json:"address2,omitempty"`
+		City       string `json:"city"`
+		State      string `json:"state"`
+		PostalCode string `json:"postalCode"`
+		Country    string `json:"country"`
+	} `json:"addressMailing"`
+}
+
+// GoDaddyPurchase is the body of a domain purchase
+type GoDaddyPurchase struct {
+	Domain  string `json:"domain"`
+	Period  int    `json:"period"`
+	Consent struct {
+		AgreementKeys []string `json:"agreementKeys"`
+		AgreedAt      string   `json:"agreedAt"`
+		AgreedBy      string   `json:"agreedBy"`
+	} `json:"consent"`
+	ContactRegistrant GoDaddyContact `json:"contactRegistrant"`
+	ContactAdmin      GoDaddyContact `json:"contactAdmin"`
+	ContactTech       GoDaddyContact `json:"contactTech"`
+	ContactBilling    GoDaddyContact `json:"contactBilling"`
+	NameServers       []string       `json:"nameServers,omitempty"`
+	RenewAuto         bool           `json:"renewAuto"`
+	Privacy           bool           `json:"privacy"`
+}
+
+// GoDaddyOrder is GoDaddy's receipt for a purchase
+type GoDaddyOrder struct {
+	OrderID  int64  `json:"orderId"`
+	Total    int64  `json:"total"` // Micro-units
+	Currency string `json:"currency"`
+}
+
+// PurchaseDomain buys a domain
+func (c *GoDaddyClient) PurchaseDomain(ctx context.Context, purchase GoDaddyPurchase) (*GoDaddyOrder, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/v1/domains/purchase", purchase)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var order GoDaddyOrder
+	if err := json.NewDecoder(resp.Body).Decode(&order); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &order, nil
+}
+
 // GoDaddyProvider implements the Provider interface for GoDaddy
 type GoDaddyProvider struct {
 	client *GoDaddyClient
@@ -161,7 +323,7 @@ func (g *GoDaddyProvider) Validate(ctx context.Context) error {
 	}
 
 	// Test the connection by making a simple API call
-	_, err := g.client.makeRequest(ctx, "GET", "/v1/domains?limit=1")
+	_, err := g.client.makeRequest(ctx, "GET", "/v1/domains?limit=1", nil)
 	if err != nil {
 		return fmt.Errorf("failed to validate GoDaddy API connection: %w", err)
 	}
@@ -243,16 +405,175 @@ func (g *GoDaddyProvider) GetDomain(ctx context.Context, name string) (*domains.
 
 // UpdateAutoRenewal updates the auto-renewal setting for a domain
 func (g *GoDaddyProvider) UpdateAutoRenewal(ctx context.Context, name string, enabled bool) error {
-	// TODO: Implement auto-renewal update via GoDaddy API
-	// This would require the PATCH /v1/domains/{domain} endpoint
-	return fmt.Errorf("UpdateAutoRenewal not implemented yet")
+	if g.client == nil {
+		return fmt.Errorf("GoDaddy client not configured")
+	}
+
+	if err := g.client.UpdateDomain(ctx, name, map[string]any{"renewAuto": enabled}); err != nil {
+		return fmt.Errorf("failed to update auto-renewal for %s: %w", name, err)
+	}
+	return nil
 }
 
 // GetRenewalInfo retrieves renewal pricing information
 func (g *GoDaddyProvider) GetRenewalInfo(ctx context.Context, name string) (*domains.DomainCost, error) {
-	// TODO: Implement renewal info retrieval from GoDaddy API
-	// This would require checking pricing endpoints
-	return nil, fmt.Errorf("GetRenewalInfo not implemented yet")
+	if g.client == nil {
+		return nil, fmt.Errorf("GoDaddy client not configured")
+	}
+
+	detail, err := g.client.GetDomainDetail(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get renewal pricing for %s: %w", name, err)
+	}
+	if detail.Renewal == nil || detail.Renewal.Price == 0 {
+		return nil, fmt.Errorf("GoDaddy did not report a renewal price for %s", name)
+	}
+
+	currency := detail.Renewal.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	return &domains.DomainCost{
+		Currency:     currency,
+		RenewalPrice: float64(detail.Renewal.Price) / goDaddyPriceUnit,
+	}, nil
+}
+
+// QuoteDomain returns GoDaddy's registration price for a name, or nil when
+// GoDaddy can't sell it. GoDaddy doesn't publish a price list, so every name
+// is quoted.
+func (g *GoDaddyProvider) QuoteDomain(ctx context.Context, domain string) (*domains.DomainPrice, error) {
+	if g.client == nil {
+		return nil, fmt.Errorf("GoDaddy client not configured")
+	}
+
+	availability, err := g.client.CheckAvailability(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing for %s: %w", domain, err)
+	}
+	if !availability.Available || availability.Price == 0 {
+		return nil, nil
+	}
+
+	currency := availability.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	price := float64(availability.Price) / goDaddyPriceUnit
+	if availability.Period > 1 {
+		price /= float64(availability.Period)
+	}
+	return &domains.DomainPrice{Currency: currency, Registration: price}, nil
+}
+
+// PurchaseInfo reports whether orders go to GoDaddy's OTE test environment
+func (g *GoDaddyProvider) PurchaseInfo() domains.PurchaseInfo {
+	return domains.PurchaseInfo{Sandbox: g.config.Environment == "ote", SetsAutoRenew: true}
+}
+
+// Register buys a domain, accepting the registration agreements for its
+// TLD on behalf of the configured client IP
+func (g *GoDaddyProvider) Register(ctx context.Context, request domains.RegistrationRequest) (*domains.Registration, error) {
+	if g.client == nil {
+		return nil, fmt.Errorf("GoDaddy client not configured")
+	}
+	if g.config.ClientIP == "" {
+		return nil, fmt.Errorf("GoDaddy needs the IP address agreeing to its terms; set client_ip in the godaddy provider config")
+	}
+
+	agreements, err := g.client.GetAgreements(ctx, extractTLD(request.Domain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration agreements: %w", err)
+	}
+
+	purchase := GoDaddyPurchase{
+		Domain:      request.Domain,
+		Period:      request.Years,
+		NameServers: request.Nameservers,
+		RenewAuto:   request.AutoRenew,
+	}
+	for _, agreement := range agreements {
+		purchase.Consent.AgreementKeys = append(purchase.Consent.AgreementKeys, agreement.AgreementKey)
+	}
+	purchase.Consent.AgreedAt = time.Now().UTC().Format(time.RFC3339)
+	purchase.Consent.AgreedBy = g.config.ClientIP
+
+	contact := goDaddyContact(request.Contact)
+	purchase.ContactRegistrant = contact
+	purchase.ContactAdmin = contact
+	purchase.ContactTech = contact
+	purchase.ContactBilling = contact
+
+	order, err := g.client.PurchaseDomain(ctx, purchase)
+	if err != nil {
+		return nil, err
+	}
+	return &domains.Registration{
+		Domain:   request.Domain,
+		Years:    request.Years,
+		OrderID:  strconv.FormatInt(order.OrderID, 10),
+		Charged:  float64(order.Total) / goDaddyPriceUnit,
+		Currency: order.Currency,
+	}, nil
+}
+
+// goDaddyContact converts a contact to GoDaddy's format
+func goDaddyContact(contact domains.Contact) GoDaddyContact {
+	gd := GoDaddyContact{
+		NameFirst:    contact.FirstName,
+		NameLast:     contact.LastName,
+		Organization: contact.Organization,
+		Email:        contact.Email,
+		Phone:        contact.Phone,
+	}
+	gd.AddressMailing.Address1 = contact.Address1
+	gd.AddressMailing.Address2 = contact.Address2
+	gd.AddressMailing.City = contact.City
+	gd.AddressMailing.State = contact.State
+	gd.AddressMailing.PostalCode = contact.PostalCode
+	gd.AddressMailing.Country = strings.ToUpper(contact.Country)
+	return gd
+}
+
+// GetTransferLock reports whether a domain is locked against transfers
+func (g *GoDaddyProvider) GetTransferLock(ctx context.Context, name string) (bool, error) {
+	if g.client == nil {
+		return false, fmt.Errorf("GoDaddy client not configured")
+	}
+
+	detail, err := g.client.GetDomainDetail(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transfer lock for %s: %w", name, err)
+	}
+	return detail.Locked, nil
+}
+
+// SetTransferLock locks or unlocks a domain for transfers
+func (g *GoDaddyProvider) SetTransferLock(ctx context.Context, name string, locked bool) error {
+	if g.client == nil {
+		return fmt.Errorf("GoDaddy client not configured")
+	}
+
+	if err := g.client.UpdateDomain(ctx, name, map[string]any{"locked": locked}); err != nil {
+		return fmt.Errorf("failed to update transfer lock for %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetAuthCode retrieves the auth code needed to transfer a domain away
+func (g *GoDaddyProvider) GetAuthCode(ctx context.Context, name string) (string, error) {
+	if g.client == nil {
+		return "", fmt.Errorf("GoDaddy client not configured")
+	}
+
+	detail, err := g.client.GetDomainDetail(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth code for %s: %w", name, err)
+	}
+	if detail.AuthCode == "" {
+		return "", fmt.Errorf("GoDaddy did not return an auth code for %s", name)
+	}
+	return detail.AuthCode, nil
 }
 
 // GetNameservers retrieves nameservers for a domain
@@ -266,7 +587,12 @@ func (g *GoDaddyProvider) GetNameservers(ctx context.Context, name string) ([]st
 
 // UpdateNameservers updates nameservers for a domain
 func (g *GoDaddyProvider) UpdateNameservers(ctx context.Context, name string, nameservers []string) error {
-	// TODO: Implement nameserver update via GoDaddy API
-	// This would require the PUT /v1/domains/{domain}/nameServers endpoint
-	return fmt.Errorf("UpdateNameservers not implemented yet")
+	if g.client == nil {
+		return fmt.Errorf("GoDaddy client not configured")
+	}
+
+	if err := g.client.UpdateDomain(ctx, name, map[string]any{"nameServers": nameservers}); err != nil {
+		return fmt.Errorf("failed to update nameservers for %s: %w", name, err)
+	}
+	return nil
 }
@@ -0,0 +1,578 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"indietool/cli/dns"
+	"indietool/cli/domains"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/goccy/go-yaml"
+)
+
+// MockConfig holds configuration for the mock provider
+type MockConfig struct {
+	StatePath string `yaml:"state"`
+	Enabled   bool   `yaml:"enabled"`
+}
+
+// IsEnabled implements ProviderConfig interface
+func (m *MockConfig) IsEnabled() bool {
+	return m.Enabled
+}
+
+// SetEnabled implements ProviderConfig interface
+func (m *MockConfig) SetEnabled(enabled bool) {
+	m.Enabled = enabled
+}
+
+// MockState is the data served by the mock provider. It is what gets
+// persisted to the state file, so it can also be written by hand to seed
+// demos and tests.
+type MockState struct {
+	Domains []domains.ManagedDomain `json:"domains"`
+	Zones   map[string][]dns.Record `json:"zones"`
+	NextID  int                     `json:"next_id"`
+
+	// Unlocked lists the domains whose transfer lock is off; every other
+	// domain is locked
+	Unlocked []string `json:"unlocked,omitempty"`
+
+	// Prices is the mock registrar's price list, and Premium the names it
+	// charges more for
+	Prices  []domains.TLDPrice             `json:"prices,omitempty"`
+	Premium map[string]domains.DomainPrice `json:"premium,omitempty"`
+}
+
+// MockProvider implements both dns.Provider and domains.Registrar without
+// talking to any real service. With a state path configured every operation
+// reads and writes the state file, so changes survive between runs; without
+// one the state only lives in memory.
+type MockProvider struct {
+	config MockConfig
+	state  MockState
+	mu     sync.Mutex
+}
+
+// NewMockProvider creates a new in-memory mock provider instance
+func NewMockProvider() *MockProvider {
+	return &MockProvider{
+		config: MockConfig{Enabled: true},
+		state:  MockState{Zones: make(map[string][]dns.Record)},
+	}
+}
+
+// NewMock creates a new mock provider instance with configuration
+func NewMock(config MockConfig) *MockProvider {
+	return &MockProvider{
+		config: config,
+		state:  MockState{Zones: make(map[string][]dns.Record)},
+	}
+}
+
+// Name returns the provider name
+func (m *MockProvider) Name() string {
+	return "mock"
+}
+
+// IsEnabled returns whether this provider is enabled
+func (m *MockProvider) IsEnabled() bool {
+	return m.config.Enabled
+}
+
+// SetEnabled sets the enabled state of this provider
+func (m *MockProvider) SetEnabled(enabled bool) {
+	m.config.Enabled = enabled
+}
+
+// Validate checks that the state file, if configured, can be read
+func (m *MockProvider) Validate(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return fmt.Errorf("failed to validate mock provider state: %w", err)
+	}
+	return nil
+}
+
+// AsRegistrar returns the registrar interface for domain operations
+func (m *MockProvider) AsRegistrar() domains.Registrar {
+	return m
+}
+
+// Capabilities returns the provider's capabilities
+func (m *MockProvider) Capabilities() dns.ProviderCapabilities {
+	return dns.ProviderCapabilities{
+		SupportsPriority: true,
+		SupportsWildcard: true,
+		SupportsTTLRange: true,
+		MinTTL:           1,
+		MaxTTL:           86400,
+	}
+}
+
+// AddDomain registers a domain with the mock registrar and creates an empty
+// DNS zone for it. Existing domains are replaced.
+func (m *MockProvider) AddDomain(domain domains.ManagedDomain) error {
+	return m.update(func(state *MockState) error {
+		domain.Provider = m.Name()
+		domain.SetStatus()
+
+		for i, existing := range state.Domains {
+			if strings.EqualFold(existing.Name, domain.Name) {
+				state.Domains[i] = domain
+				return nil
+			}
+		}
+		state.Domains = append(state.Domains, domain)
+
+		if _, ok := state.Zones[domain.Name]; !ok {
+			state.Zones[domain.Name] = []dns.Record{}
+		}
+		return nil
+	})
+}
+
+// State returns a copy of the current provider state
+func (m *MockProvider) State() (MockState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return MockState{}, err
+	}
+
+	state := MockState{
+		Domains:  append([]domains.ManagedDomain(nil), m.state.Domains...),
+		Zones:    make(map[string][]dns.Record, len(m.state.Zones)),
+		NextID:   m.state.NextID,
+		Unlocked: slices.Clone(m.state.Unlocked),
+		Prices:   slices.Clone(m.state.Prices),
+		Premium:  maps.Clone(m.state.Premium),
+	}
+	for zone, records := range m.state.Zones {
+		state.Zones[zone] = append([]dns.Record(nil), records...)
+	}
+	return state, nil
+}
+
+// ============================================================================
+// Registrar Methods
+// ============================================================================
+
+// ListDomains returns all domains held by the mock registrar
+func (m *MockProvider) ListDomains(ctx context.Context) ([]domains.ManagedDomain, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	domainList := make([]domains.ManagedDomain, 0, len(m.state.Domains))
+	for _, domain := range m.state.Domains {
+		domain.Provider = m.Name()
+		domain.SetStatus()
+		domainList = append(domainList, domain)
+	}
+	return domainList, nil
+}
+
+// GetDomain retrieves a specific domain
+func (m *MockProvider) GetDomain(ctx context.Context, name string) (*domains.ManagedDomain, error) {
+	domainList, err := m.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, domain := range domainList {
+		if strings.EqualFold(domain.Name, name) {
+			return &domain, nil
+		}
+	}
+	return nil, fmt.Errorf("domain %s not found", name)
+}
+
+// UpdateAutoRenewal updates the auto-renewal setting for a domain
+func (m *MockProvider) UpdateAutoRenewal(ctx context.Context, name string, enabled bool) error {
+	return m.updateDomain(name, func(domain *domains.ManagedDomain) {
+		domain.AutoRenewal = enabled
+	})
+}
+
+// GetRenewalInfo returns the renewal pricing stored for a domain
+func (m *MockProvider) GetRenewalInfo(ctx context.Context, name string) (*domains.DomainCost, error) {
+	domain, err := m.GetDomain(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if domain.Cost == nil {
+		return nil, fmt.Errorf("pricing information not available for domain %s", name)
+	}
+	return domain.Cost, nil
+}
+
+// ListPrices returns the price list from the mock state
+func (m *MockProvider) ListPrices(ctx context.Context) ([]domains.TLDPrice, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(m.state.Prices), nil
+}
+
+// QuoteDomain returns the premium price of a name, or nil when the list
+// price applies
+func (m *MockProvider) QuoteDomain(ctx context.Context, domain string) (*domains.DomainPrice, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	price, ok := m.state.Premium[strings.ToLower(domain)]
+	if !ok {
+		return nil, nil
+	}
+	price.Premium = true
+	return &price, nil
+}
+
+// GetNameservers retrieves nameservers for a domain
+func (m *MockProvider) GetNameservers(ctx context.Context, name string) ([]string, error) {
+	domain, err := m.GetDomain(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Nameservers, nil
+}
+
+// UpdateNameservers updates nameservers for a domain
+func (m *MockProvider) UpdateNameservers(ctx context.Context, name string, nameservers []string) error {
+	return m.updateDomain(name, func(domain *domains.ManagedDomain) {
+		domain.Nameservers = append([]string(nil), nameservers...)
+	})
+}
+
+// AssignedNameservers returns the nameservers the mock provider serves
+// every zone from
+func (m *MockProvider) AssignedNameservers(ctx context.Context, domain string) ([]string, error) {
+	return []string{"ns1.mock.test", "ns2.mock.test"}, nil
+}
+
+// GetTransferLock reports whether a domain is locked against transfers
+func (m *MockProvider) GetTransferLock(ctx context.Context, name string) (bool, error) {
+	if _, err := m.GetDomain(ctx, name); err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return !slices.Contains(m.state.Unlocked, strings.ToLower(name)), nil
+}
+
+// SetTransferLock locks or unlocks a domain for transfers
+func (m *MockProvider) SetTransferLock(ctx context.Context, name string, locked bool) error {
+	name = strings.ToLower(name)
+	if _, err := m.GetDomain(ctx, name); err != nil {
+		return err
+	}
+
+	return m.update(func(state *MockState) error {
+		state.Unlocked = slices.DeleteFunc(state.Unlocked, func(domain string) bool { return domain == name })
+		if !locked {
+			state.Unlocked = append(state.Unlocked, name)
+		}
+		return nil
+	})
+}
+
+// GetAuthCode returns a fixed auth code derived from the domain name
+func (m *MockProvider) GetAuthCode(ctx context.Context, name string) (string, error) {
+	if _, err := m.GetDomain(ctx, name); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("MOCK-%08X", crc32.ChecksumIEEE([]byte(strings.ToLower(name)))), nil
+}
+
+// InitiateTransfer completes an inbound transfer immediately, adding the
+// domain with a year of registration
+func (m *MockProvider) InitiateTransfer(ctx context.Context, name, authCode string) (string, error) {
+	if authCode == "" {
+		return "", fmt.Errorf("an auth code is required to transfer %s", name)
+	}
+
+	err := m.AddDomain(domains.ManagedDomain{
+		Name:        strings.ToLower(name),
+		ExpiryDate:  time.Now().AddDate(1, 0, 0),
+		AutoRenewal: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("mock-transfer-%s", strings.ToLower(name)), nil
+}
+
+// PurchaseInfo reports that nothing bought from the mock registrar is charged
+func (m *MockProvider) PurchaseInfo() domains.PurchaseInfo {
+	return domains.PurchaseInfo{Sandbox: true, SetsAutoRenew: true}
+}
+
+// Register adds the domain for the requested years, using the mock
+// nameservers unless others are given
+func (m *MockProvider) Register(ctx context.Context, request domains.RegistrationRequest) (*domains.Registration, error) {
+	name := strings.ToLower(request.Domain)
+	if _, err := m.GetDomain(ctx, name); err == nil {
+		return nil, fmt.Errorf("%s is already registered", name)
+	}
+
+	nameservers := request.Nameservers
+	if len(nameservers) == 0 {
+		nameservers, _ = m.AssignedNameservers(ctx, name)
+	}
+	err := m.AddDomain(domains.ManagedDomain{
+		Name:        name,
+		ExpiryDate:  time.Now().AddDate(request.Years, 0, 0),
+		AutoRenewal: request.AutoRenew,
+		Nameservers: slices.Clone(nameservers),
+		LastUpdated: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	registration := &domains.Registration{
+		Domain:  name,
+		Years:   request.Years,
+		OrderID: fmt.Sprintf("mock-order-%08x", crc32.ChecksumIEEE([]byte(name))),
+	}
+	if request.Price != nil {
+		registration.Charged = domains.RegistrationCost(*request.Price, request.Years)
+		registration.Currency = request.Price.Currency
+	}
+	return registration, nil
+}
+
+// updateDomain applies fn to the named domain and persists the result
+func (m *MockProvider) updateDomain(name string, fn func(domain *domains.ManagedDomain)) error {
+	return m.update(func(state *MockState) error {
+		for i := range state.Domains {
+			if strings.EqualFold(state.Domains[i].Name, name) {
+				fn(&state.Domains[i])
+				state.Domains[i].LastUpdated = time.Now()
+				return nil
+			}
+		}
+		return fmt.Errorf("domain %s not found", name)
+	})
+}
+
+// ============================================================================
+// DNS Provider Methods
+// ============================================================================
+
+// ListZones returns the names of all zones held by the mock provider
+func (m *MockProvider) ListZones(ctx context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(m.state.Zones))
+	for zone := range m.state.Zones {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ListRecords retrieves all DNS records for a domain
+func (m *MockProvider) ListRecords(ctx context.Context, domain string) ([]dns.Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	records, ok := m.state.Zones[domain]
+	if !ok {
+		return nil, fmt.Errorf("zone not found for domain %s", domain)
+	}
+	return append([]dns.Record{}, records...), nil
+}
+
+// SetRecord creates or updates a DNS record
+func (m *MockProvider) SetRecord(ctx context.Context, domain string, record dns.Record) error {
+	return m.update(func(state *MockState) error {
+		records, ok := state.Zones[domain]
+		if !ok {
+			return fmt.Errorf("zone not found for domain %s", domain)
+		}
+
+		record.Name = dns.NormalizeName(record.Name, domain)
+		record.Type = strings.ToUpper(record.Type)
+
+		index := -1
+		for i, existing := range records {
+			if record.ID != "" {
+				if existing.ID == record.ID {
+					index = i
+					break
+				}
+			} else if strings.EqualFold(existing.Name, record.Name) && existing.Type == record.Type {
+				index = i
+				break
+			}
+		}
+
+		if index >= 0 {
+			log.Debugf("Updating existing DNS record: %s %s %s", record.Name, record.Type, record.Content)
+			record.ID = records[index].ID
+			records[index] = record
+		} else if record.ID != "" {
+			return fmt.Errorf("DNS record %s not found", record.ID)
+		} else {
+			log.Debugf("Creating new DNS record: %s %s %s", record.Name, record.Type, record.Content)
+			state.NextID++
+			record.ID = strconv.Itoa(state.NextID)
+			records = append(records, record)
+		}
+
+		state.Zones[domain] = records
+		return nil
+	})
+}
+
+// DeleteRecord removes a DNS record by ID
+func (m *MockProvider) DeleteRecord(ctx context.Context, domain, recordID string) error {
+	return m.update(func(state *MockState) error {
+		records, ok := state.Zones[domain]
+		if !ok {
+			return fmt.Errorf("zone not found for domain %s", domain)
+		}
+
+		for i, record := range records {
+			if record.ID == recordID {
+				state.Zones[domain] = append(records[:i], records[i+1:]...)
+				log.Debugf("Deleted DNS record %s", recordID)
+				return nil
+			}
+		}
+		return fmt.Errorf("DNS record %s not found", recordID)
+	})
+}
+
+// GetRecord retrieves a specific DNS record by name and type
+func (m *MockProvider) GetRecord(ctx context.Context, domain, name, recordType string) (*dns.Record, error) {
+	records, err := m.ListRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	name = dns.NormalizeName(name, domain)
+	for _, record := range records {
+		if strings.EqualFold(record.Name, name) && strings.EqualFold(record.Type, recordType) {
+			return &record, nil
+		}
+	}
+	return nil, fmt.Errorf("DNS record not found: %s %s", name, recordType)
+}
+
+// ============================================================================
+// State Persistence
+// ============================================================================
+
+// update loads the latest state, applies fn and saves the result. Nothing is
+// written when fn fails.
+func (m *MockProvider) update(fn func(state *MockState) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return err
+	}
+	if err := fn(&m.state); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+// load refreshes the in-memory state from the state file. A missing file is
+// treated as an empty state.
+func (m *MockProvider) load() error {
+	if m.config.StatePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(m.config.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read mock state %s: %w", m.config.StatePath, err)
+	}
+
+	var state MockState
+	if m.isJSON() {
+		err = json.Unmarshal(data, &state)
+	} else {
+		err = yaml.Unmarshal(data, &state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse mock state %s: %w", m.config.StatePath, err)
+	}
+
+	if state.Zones == nil {
+		state.Zones = make(map[string][]dns.Record)
+	}
+	m.state = state
+	return nil
+}
+
+// save writes the in-memory state to the state file, if one is configured
+func (m *MockProvider) save() error {
+	if m.config.StatePath == "" {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if m.isJSON() {
+		data, err = json.MarshalIndent(m.state, "", "  ")
+	} else {
+		data, err = yaml.Marshal(m.state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode mock state: %w", err)
+	}
+
+	if dir := filepath.Dir(m.config.StatePath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create mock state directory: %w", err)
+		}
+	}
+	return os.WriteFile(m.config.StatePath, data, 0644)
+}
+
+// isJSON reports whether the state file should be read and written as JSON
+func (m *MockProvider) isJSON() bool {
+	return strings.EqualFold(filepath.Ext(m.config.StatePath), ".json")
+}
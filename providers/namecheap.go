@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"indietool/cli/dns"
 	"indietool/cli/domains"
@@ -253,16 +254,377 @@ func (n *NamecheapProvider) GetDomain(ctx context.Context, name string) (*domain
 
 // UpdateAutoRenewal updates the auto-renewal setting for a domain
 func (n *NamecheapProvider) UpdateAutoRenewal(ctx context.Context, name string, enabled bool) error {
-	// TODO: Implement auto-renewal update via Namecheap API
-	// Note: This requires the domains.renew command which may not be available in all Namecheap accounts
-	return fmt.Errorf("auto-renewal update not yet implemented for Namecheap")
+	// Namecheap's API exposes the auto-renew flag in domains.getList but has no
+	// command to change it
+	return fmt.Errorf("namecheap: auto-renewal must be changed in the Namecheap dashboard: %w", domains.ErrNotSupported)
 }
 
 // GetRenewalInfo retrieves renewal pricing information
 func (n *NamecheapProvider) GetRenewalInfo(ctx context.Context, name string) (*domains.DomainCost, error) {
-	// TODO: Implement renewal pricing via Namecheap API
-	// This would require integration with their pricing API
-	return nil, fmt.Errorf("renewal pricing information not yet implemented for Namecheap")
+	if n.client == nil {
+		return nil, fmt.Errorf("namecheap client not configured")
+	}
+
+	parsed, err := namecheap.ParseDomain(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse domain %s: %w", name, err)
+	}
+
+	var response namecheapPricingResponse
+	_, err = n.client.DoXML(map[string]string{
+		"Command":         "namecheap.users.getPricing",
+		"ProductType":     "DOMAIN",
+		"ProductCategory": "RENEW",
+		"ProductName":     parsed.TLD,
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get renewal pricing for %s: %w", name, err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("failed to get renewal pricing for %s: %s (%s)", name, response.Errors[0].Message, response.Errors[0].Number)
+	}
+
+	for _, price := range response.Prices {
+		if price.Duration != 1 || !strings.EqualFold(price.DurationType, "YEAR") {
+			continue
+		}
+		// YourPrice includes account discounts; Price is the list price
+		amount := price.YourPrice
+		if amount == 0 {
+			amount = price.Price
+		}
+		return &domains.DomainCost{
+			Currency:     price.Currency,
+			RenewalPrice: amount + price.AdditionalCost,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("pricing information not available for TLD: %s", parsed.TLD)
+}
+
+// namecheapPricingResponse is the part of a namecheap.users.getPricing
+// response indietool reads. The SDK doesn't cover this command.
+type namecheapPricingResponse struct {
+	Errors []struct {
+		Message string `xml:",chardata"`
+		Number  string `xml:"Number,attr"`
+	} `xml:"Errors>Error"`
+	Prices []struct {
+		Duration       int     `xml:"Duration,attr"`
+		DurationType   string  `xml:"DurationType,attr"`
+		Price          float64 `xml:"Price,attr"`
+		YourPrice      float64 `xml:"YourPrice,attr"`
+		AdditionalCost float64 `xml:"AdditionalCost,attr"`
+		Currency       string  `xml:"Currency,attr"`
+	} `xml:"CommandResponse>UserGetPricingResult>ProductType>ProductCategory>Product>Price"`
+}
+
+// ListPrices returns Namecheap's one-year register, renew and transfer
+// prices for every TLD, including account discounts
+func (n *NamecheapProvider) ListPrices(ctx context.Context) ([]domains.TLDPrice, error) {
+	if n.client == nil {
+		return nil, fmt.Errorf("namecheap client not configured")
+	}
+
+	var response namecheapPriceListResponse
+	_, err := n.client.DoXML(map[string]string{
+		"Command":     "namecheap.users.getPricing",
+		"ProductType": "DOMAIN",
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("failed to get pricing: %s (%s)", response.Errors[0].Message, response.Errors[0].Number)
+	}
+
+	byTLD := make(map[string]*domains.TLDPrice)
+	for _, category := range response.Categories {
+		for _, product := range category.Products {
+			for _, price := range product.Prices {
+				if price.Duration != 1 || !strings.EqualFold(price.DurationType, "YEAR") {
+					continue
+				}
+				tld := strings.ToLower(product.Name)
+				entry, ok := byTLD[tld]
+				if !ok {
+					entry = &domains.TLDPrice{TLD: tld, Currency: price.Currency}
+					byTLD[tld] = entry
+				}
+				amount := price.YourPrice
+				if amount == 0 {
+					amount = price.Price
+				}
+				amount += price.AdditionalCost
+				switch strings.ToLower(category.Name) {
+				case "register":
+					entry.Registration = amount
+				case "renew":
+					entry.Renewal = amount
+				case "transfer":
+					entry.Transfer = amount
+				}
+			}
+		}
+	}
+
+	prices := make([]domains.TLDPrice, 0, len(byTLD))
+	for _, price := range byTLD {
+		prices = append(prices, *price)
+	}
+	return prices, nil
+}
+
+// namecheapPriceListResponse is a namecheap.users.getPricing response
+// covering every domain category and TLD
+type namecheapPriceListResponse struct {
+	Errors []struct {
+		Message string `xml:",chardata"`
+		Number  string `xml:"Number,attr"`
+	} `xml:"Errors>Error"`
+	Categories []struct {
+		Name     string `xml:"Name,attr"`
+		Products []struct {
+			Name   string `xml:"Name,attr"`
+			Prices []struct {
+				Duration       int     `xml:"Duration,attr"`
+				DurationType   string  `xml:"DurationType,attr"`
+				Price          float64 `xml:"Price,attr"`
+				YourPrice      float64 `xml:"YourPrice,attr"`
+				AdditionalCost float64 `xml:"AdditionalCost,attr"`
+				Currency       string  `xml:"Currency,attr"`
+			} `xml:"Price"`
+		} `xml:"Product"`
+	} `xml:"CommandResponse>UserGetPricingResult>ProductType>ProductCategory"`
+}
+
+// QuoteDomain reports the premium price of a name, or nil when Namecheap's
+// list price applies
+func (n *NamecheapProvider) QuoteDomain(ctx context.Context, domain string) (*domains.DomainPrice, error) {
+	if n.client == nil {
+		return nil, fmt.Errorf("namecheap client not configured")
+	}
+
+	var response namecheapCheckResponse
+	_, err := n.client.DoXML(map[string]string{
+		"Command":    "namecheap.domains.check",
+		"DomainList": domain,
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s: %w", domain, err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("failed to check %s: %s (%s)", domain, response.Errors[0].Message, response.Errors[0].Number)
+	}
+
+	for _, result := range response.Results {
+		if !strings.EqualFold(result.Domain, domain) || !result.IsPremiumName {
+			continue
+		}
+		return &domains.DomainPrice{
+			Currency:     "USD",
+			Registration: result.PremiumRegistrationPrice,
+			Fees:         result.IcannFee,
+			Renewal:      result.PremiumRenewalPrice,
+			Transfer:     result.PremiumTransferPrice,
+			Premium:      true,
+		}, nil
+	}
+	return nil, nil
+}
+
+// namecheapCheckResponse is the part of a namecheap.domains.check response
+// indietool reads
+type namecheapCheckResponse struct {
+	Errors []struct {
+		Message string `xml:",chardata"`
+		Number  string `xml:"Number,attr"`
+	} `xml:"Errors>Error"`
+	Results []struct {
+		Domain                   string  `xml:"Domain,attr"`
+		Available                bool    `xml:"Available,attr"`
+		IsPremiumName            bool    `xml:"IsPremiumName,attr"`
+		PremiumRegistrationPrice float64 `xml:"PremiumRegistrationPrice,attr"`
+		PremiumRenewalPrice      float64 `xml:"PremiumRenewalPrice,attr"`
+		PremiumTransferPrice     float64 `xml:"PremiumTransferPrice,attr"`
+		IcannFee                 float64 `xml:"IcannFee,attr"`
+	} `xml:"CommandResponse>DomainCheckResult"`
+}
+
+// GetTransferLock reports whether a domain is locked against transfers
+func (n *NamecheapProvider) GetTransferLock(ctx context.Context, name string) (bool, error) {
+	if n.client == nil {
+		return false, fmt.Errorf("namecheap client not configured")
+	}
+
+	var response namecheapRegistrarLockResponse
+	_, err := n.client.DoXML(map[string]string{
+		"Command":    "namecheap.domains.getRegistrarLock",
+		"DomainName": name,
+	}, &response)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transfer lock for %s: %w", name, err)
+	}
+	if len(response.Errors) > 0 {
+		return false, fmt.Errorf("failed to get transfer lock for %s: %s (%s)", name, response.Errors[0].Message, response.Errors[0].Number)
+	}
+	return response.Result.Locked, nil
+}
+
+// SetTransferLock locks or unlocks a domain for transfers
+func (n *NamecheapProvider) SetTransferLock(ctx context.Context, name string, locked bool) error {
+	if n.client == nil {
+		return fmt.Errorf("namecheap client not configured")
+	}
+
+	action := "UNLOCK"
+	if locked {
+		action = "LOCK"
+	}
+
+	var response namecheapRegistrarLockResponse
+	_, err := n.client.DoXML(map[string]string{
+		"Command":    "namecheap.domains.setRegistrarLock",
+		"DomainName": name,
+		"LockAction": action,
+	}, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update transfer lock for %s: %w", name, err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("failed to update transfer lock for %s: %s (%s)", name, response.Errors[0].Message, response.Errors[0].Number)
+	}
+	return nil
+}
+
+// InitiateTransfer requests an inbound transfer of a domain for one year and
+// returns Namecheap's transfer ID
+func (n *NamecheapProvider) InitiateTransfer(ctx context.Context, name, authCode string) (string, error) {
+	if n.client == nil {
+		return "", fmt.Errorf("namecheap client not configured")
+	}
+
+	var response namecheapTransferResponse
+	_, err := n.client.DoXML(map[string]string{
+		"Command":    "namecheap.domains.transfer.create",
+		"DomainName": name,
+		"Years":      "1",
+		// Namecheap accepts base64 so auth codes with special characters survive
+		"EPPCode": "base64:" + base64.StdEncoding.EncodeToString([]byte(authCode)),
+	}, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to start transfer of %s: %w", name, err)
+	}
+	if len(response.Errors) > 0 {
+		return "", fmt.Errorf("failed to start transfer of %s: %s (%s)", name, response.Errors[0].Message, response.Errors[0].Number)
+	}
+	if !response.Result.Transfer {
+		return "", fmt.Errorf("namecheap did not accept the transfer of %s", name)
+	}
+	return response.Result.TransferID, nil
+}
+
+// PurchaseInfo reports whether orders go to the Namecheap sandbox
+func (n *NamecheapProvider) PurchaseInfo() domains.PurchaseInfo {
+	return domains.PurchaseInfo{Sandbox: n.config.Sandbox}
+}
+
+// Register buys a domain through namecheap.domains.create, using the
+// request's contact for all four Namecheap contacts
+func (n *NamecheapProvider) Register(ctx context.Context, request domains.RegistrationRequest) (*domains.Registration, error) {
+	if n.client == nil {
+		return nil, fmt.Errorf("namecheap client not configured")
+	}
+
+	params := map[string]string{
+		"Command":    "namecheap.domains.create",
+		"DomainName": request.Domain,
+		"Years":      strconv.Itoa(request.Years),
+	}
+	if len(request.Nameservers) > 0 {
+		params["Nameservers"] = strings.Join(request.Nameservers, ",")
+	}
+	// Premium names are only sold when the price is acknowledged, and
+	// Namecheap wants the premium price exactly as domains.check reported it
+	if request.Price != nil && request.Price.Premium {
+		params["IsPremiumDomain"] = "true"
+		params["PremiumPrice"] = strconv.FormatFloat(request.Price.Registration, 'f', 2, 64)
+	}
+	contact := request.Contact
+	for _, role := range []string{"Registrant", "Tech", "Admin", "AuxBilling"} {
+		params[role+"FirstName"] = contact.FirstName
+		params[role+"LastName"] = contact.LastName
+		params[role+"Address1"] = contact.Address1
+		params[role+"City"] = contact.City
+		params[role+"StateProvince"] = contact.State
+		params[role+"PostalCode"] = contact.PostalCode
+		params[role+"Country"] = strings.ToUpper(contact.Country)
+		params[role+"Phone"] = contact.Phone
+		params[role+"EmailAddress"] = contact.Email
+		if contact.Address2 != "" {
+			params[role+"Address2"] = contact.Address2
+		}
+		if contact.Organization != "" {
+			params[role+"OrganizationName"] = contact.Organization
+		}
+	}
+
+	var response namecheapCreateResponse
+	if _, err := n.client.DoXML(params, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("%s (%s)", response.Errors[0].Message, response.Errors[0].Number)
+	}
+	if !response.Result.Registered {
+		return nil, fmt.Errorf("namecheap did not register %s", request.Domain)
+	}
+	return &domains.Registration{
+		Domain:   request.Domain,
+		Years:    request.Years,
+		OrderID:  response.Result.OrderID,
+		Charged:  response.Result.ChargedAmount,
+		Currency: "USD",
+	}, nil
+}
+
+// namecheapCreateResponse is the part of a domains.create response
+// indietool reads
+type namecheapCreateResponse struct {
+	Errors []struct {
+		Message string `xml:",chardata"`
+		Number  string `xml:"Number,attr"`
+	} `xml:"Errors>Error"`
+	Result struct {
+		Registered    bool    `xml:"Registered,attr"`
+		ChargedAmount float64 `xml:"ChargedAmount,attr"`
+		OrderID       string  `xml:"OrderID,attr"`
+	} `xml:"CommandResponse>DomainCreateResult"`
+}
+
+// namecheapRegistrarLockResponse is the part of a domains.getRegistrarLock
+// or domains.setRegistrarLock response indietool reads
+type namecheapRegistrarLockResponse struct {
+	Errors []struct {
+		Message string `xml:",chardata"`
+		Number  string `xml:"Number,attr"`
+	} `xml:"Errors>Error"`
+	Result struct {
+		Locked bool `xml:"RegistrarLockStatus,attr"`
+	} `xml:"CommandResponse>DomainGetRegistrarLockResult"`
+}
+
+// namecheapTransferResponse is the part of a domains.transfer.create
+// response indietool reads
+type namecheapTransferResponse struct {
+	Errors []struct {
+		Message string `xml:",chardata"`
+		Number  string `xml:"Number,attr"`
+	} `xml:"Errors>Error"`
+	Result struct {
+		Transfer   bool   `xml:"Transfer,attr"`
+		TransferID string `xml:"TransferID,attr"`
+	} `xml:"CommandResponse>DomainTransferCreateResult"`
 }
 
 // GetNameservers retrieves nameservers for a domain
@@ -289,6 +651,11 @@ func (n *NamecheapProvider) GetNameservers(ctx context.Context, name string) ([]
 		}
 	}
 
+	// Domains on Namecheap's own DNS may not list the nameservers explicitly
+	if len(nameservers) == 0 && response.DomainDNSGetListResult.IsUsingOurDNS != nil && *response.DomainDNSGetListResult.IsUsingOurDNS {
+		nameservers, _ = dns.PresetNameservers("namecheap")
+	}
+
 	return nameservers, nil
 }
 
@@ -298,6 +665,15 @@ func (n *NamecheapProvider) UpdateNameservers(ctx context.Context, name string,
 		return fmt.Errorf("namecheap client not configured")
 	}
 
+	// Namecheap's own nameservers can't be set as custom ones; switching back
+	// to them goes through domains.dns.setDefault
+	if preset, _ := dns.PresetNameservers("namecheap"); dns.SameNameservers(nameservers, preset) {
+		if _, err := n.client.DomainsDNS.SetDefault(name); err != nil {
+			return fmt.Errorf("failed to switch domain %s to Namecheap DNS: %w", name, err)
+		}
+		return nil
+	}
+
 	_, err := n.client.DomainsDNS.SetCustom(name, nameservers)
 	if err != nil {
 		return fmt.Errorf("failed to update nameservers for domain %s: %w", name, err)
@@ -370,6 +746,10 @@ func (n *NamecheapProvider) SetRecord(ctx context.Context, domain string, record
 		}
 	}
 
+	if !recordFound && record.ID != "" {
+		return fmt.Errorf("DNS record %s not found", record.ID)
+	}
+
 	if !recordFound {
 		// Add new record
 		newHost := n.convertToNamecheapRecord(record, domain)
@@ -503,6 +883,14 @@ func (n *NamecheapProvider) commitRecordChanges(ctx context.Context, domain stri
 		Records: &inputRecords,
 	}
 
+	// Namecheap rejects MX records unless the zone's email type is MX
+	for _, record := range inputRecords {
+		if record.RecordType != nil && *record.RecordType == "MX" {
+			args.EmailType = namecheap.String("MX")
+			break
+		}
+	}
+
 	// Execute batch update
 	_, err := n.client.DomainsDNS.SetHosts(args)
 	if err != nil {
@@ -623,8 +1011,13 @@ func (n *NamecheapProvider) validateTTL(ttl int) int {
 // DNS Helper Methods
 // ============================================================================
 
-// recordMatches checks if a Namecheap Host record matches our DNS record
+// recordMatches checks if a Namecheap Host record matches our DNS record.
+// Records carrying an ID only match the host with that ID.
 func (n *NamecheapProvider) recordMatches(host namecheap.DomainsDNSHostRecordDetailed, record dns.Record, domain string) bool {
+	if record.ID != "" {
+		return host.HostId != nil && strconv.Itoa(*host.HostId) == record.ID
+	}
+
 	// Check record type
 	if host.Type == nil || *host.Type != record.Type {
 		return false
@@ -636,6 +1029,10 @@ func (n *NamecheapProvider) recordMatches(host namecheap.DomainsDNSHostRecordDet
 		hostName = *host.Name
 	}
 
+	if hostName == "@" {
+		hostName = "" // Namecheap returns the root domain as "@"
+	}
+
 	recordName := record.Name
 	if recordName == "@" {
 		recordName = "" // Convert to Namecheap format for comparison
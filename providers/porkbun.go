@@ -1,10 +1,16 @@
 package providers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"indietool/cli/dns"
 	"indietool/cli/domains"
+	"io"
+	"maps"
+	"math"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -35,8 +41,19 @@ func (p *PorkbunConfig) SetEnabled(enabled bool) {
 type PorkbunProvider struct {
 	client *porkbun.Client
 	config PorkbunConfig
+
+	// Porkbun only publishes pricing for all TLDs at once, so it is fetched
+	// once per process
+	pricing      map[string]porkbun.Pricing
+	pricingMutex sync.Mutex
+
+	// httpClient makes the calls the SDK lacks; http.DefaultClient when nil
+	httpClient porkbun.HTTPClient
 }
 
+// porkbunAPIBaseURL is where the calls the SDK lacks are sent
+const porkbunAPIBaseURL = "https://api.porkbun.com/api/json/v3"
+
 // NewPorkbunProvider creates a new Porkbun provider instance
 func NewPorkbunProvider() *PorkbunProvider {
 	return &PorkbunProvider{}
@@ -191,9 +208,8 @@ func (p *PorkbunProvider) GetDomain(ctx context.Context, name string) (*domains.
 
 // UpdateAutoRenewal updates the auto-renewal setting for a domain
 func (p *PorkbunProvider) UpdateAutoRenewal(ctx context.Context, name string, enabled bool) error {
-	// TODO: Implement auto-renewal update via Porkbun API
-	// Note: Porkbun API doesn't currently provide an endpoint to update auto-renewal settings
-	return fmt.Errorf("auto-renewal update not supported by Porkbun API")
+	// Porkbun API doesn't currently provide an endpoint to update auto-renewal settings
+	return fmt.Errorf("porkbun: auto-renewal must be changed in the Porkbun dashboard: %w", domains.ErrNotSupported)
 }
 
 // GetRenewalInfo retrieves renewal pricing information
@@ -203,16 +219,16 @@ func (p *PorkbunProvider) GetRenewalInfo(ctx context.Context, name string) (*dom
 		return nil, fmt.Errorf("porkbun client not configured")
 	}
 
-	pricingResponse, err := p.client.Pricing.ListPricing(ctx)
+	pricingTable, err := p.pricingTable(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pricing information: %w", err)
+		return nil, err
 	}
 
 	// Extract TLD from domain name
 	// Simple extraction - in production you might want more robust TLD parsing
 	tld := extractTLD(name)
 
-	if pricing, exists := pricingResponse.Pricing[tld]; exists {
+	if pricing, exists := pricingTable[tld]; exists {
 		// Parse renewal price (Porkbun returns prices as strings)
 		// Note: You might need more robust price parsing here
 		return &domains.DomainCost{
@@ -224,6 +240,68 @@ func (p *PorkbunProvider) GetRenewalInfo(ctx context.Context, name string) (*dom
 	return nil, fmt.Errorf("pricing information not available for TLD: %s", tld)
 }
 
+// ListPrices returns Porkbun's price list for every TLD it sells
+func (p *PorkbunProvider) ListPrices(ctx context.Context) ([]domains.TLDPrice, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("porkbun client not configured")
+	}
+
+	pricingTable, err := p.pricingTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]domains.TLDPrice, 0, len(pricingTable))
+	for tld, pricing := range pricingTable {
+		prices = append(prices, domains.TLDPrice{
+			TLD:          tld,
+			Currency:     "USD",
+			Registration: parsePrice(pricing.Registration),
+			Renewal:      parsePrice(pricing.Renewal),
+			Transfer:     parsePrice(pricing.Transfer),
+		})
+	}
+	return prices, nil
+}
+
+// pricingTable returns Porkbun's pricing for every TLD, fetching it on first use
+func (p *PorkbunProvider) pricingTable(ctx context.Context) (map[string]porkbun.Pricing, error) {
+	p.pricingMutex.Lock()
+	defer p.pricingMutex.Unlock()
+
+	if p.pricing == nil {
+		pricingResponse, err := p.client.Pricing.ListPricing(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pricing information: %w", err)
+		}
+		p.pricing = pricingResponse.Pricing
+	}
+	return p.pricing, nil
+}
+
+// GetTransferLock reports whether a domain is locked against transfers
+func (p *PorkbunProvider) GetTransferLock(ctx context.Context, name string) (bool, error) {
+	if p.client == nil {
+		return false, fmt.Errorf("porkbun client not configured")
+	}
+
+	response, err := p.client.Domains.ListDomains(ctx, &porkbun.DomainListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("provider/porkbun: failed to list domains: %w", err)
+	}
+	for _, domain := range response.Domains {
+		if strings.EqualFold(domain.Domain, name) {
+			return bool(domain.SecurityLock), nil
+		}
+	}
+	return false, fmt.Errorf("domain %s not found", name)
+}
+
+// SetTransferLock is not available through Porkbun's API
+func (p *PorkbunProvider) SetTransferLock(ctx context.Context, name string, locked bool) error {
+	return fmt.Errorf("porkbun: the transfer lock must be changed in the Porkbun dashboard: %w", domains.ErrNotSupported)
+}
+
 // GetNameservers retrieves nameservers for a domain
 func (p *PorkbunProvider) GetNameservers(ctx context.Context, name string) ([]string, error) {
 	if p.client == nil {
@@ -253,6 +331,95 @@ func (p *PorkbunProvider) UpdateNameservers(ctx context.Context, name string, na
 	return nil
 }
 
+// PurchaseInfo reports that Porkbun registers domains to the account's
+// default contact, for one year
+func (p *PorkbunProvider) PurchaseInfo() domains.PurchaseInfo {
+	return domains.PurchaseInfo{AccountContact: true, MaxYears: 1}
+}
+
+// Register buys a domain for one year at the price the user agreed to,
+// charged to the account balance. Porkbun refuses the order when the price
+// has changed.
+func (p *PorkbunProvider) Register(ctx context.Context, request domains.RegistrationRequest) (*domains.Registration, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("porkbun client not configured")
+	}
+	if request.Price == nil || request.Price.Registration <= 0 {
+		return nil, fmt.Errorf("porkbun needs the agreed price to place the order")
+	}
+
+	var response struct {
+		Cost    int64 `json:"cost"` // Pennies
+		OrderID int64 `json:"orderId"`
+	}
+	err := p.post(ctx, "/domain/create/"+request.Domain, map[string]any{
+		"cost":         int64(math.Round(request.Price.Registration * 100)),
+		"agreeToTerms": "yes",
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	registration := &domains.Registration{
+		Domain:   request.Domain,
+		Years:    1,
+		OrderID:  strconv.FormatInt(response.OrderID, 10),
+		Charged:  float64(response.Cost) / 100,
+		Currency: "USD",
+	}
+
+	// Porkbun can't take nameservers with the order
+	if len(request.Nameservers) > 0 {
+		if err := p.UpdateNameservers(ctx, request.Domain, request.Nameservers); err != nil {
+			log.Warnf("%s was registered, but setting its nameservers failed: %v", request.Domain, err)
+		}
+	}
+	return registration, nil
+}
+
+// post sends an authenticated request to a Porkbun endpoint the SDK doesn't
+// cover and decodes the response into result
+func (p *PorkbunProvider) post(ctx context.Context, endpoint string, payload map[string]any, result any) error {
+	body := map[string]any{"apikey": p.config.APIKey, "secretapikey": p.config.APISecret}
+	maps.Copy(body, payload)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, porkbunAPIBaseURL+endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	var status struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(data))
+	}
+	if status.Status != "SUCCESS" {
+		return fmt.Errorf("API request failed: %s", status.Message)
+	}
+	return json.Unmarshal(data, result)
+}
+
 // Helper functions
 
 // extractTLD extracts the TLD from a domain name
@@ -315,6 +482,16 @@ func (p *PorkbunProvider) SetRecord(ctx context.Context, domain string, record d
 		return fmt.Errorf("porkbun client not configured")
 	}
 
+	// Update a specific record when the caller picked one by ID
+	if record.ID != "" {
+		id, err := strconv.ParseInt(record.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid record ID format: %w", err)
+		}
+		log.Debugf("Updating DNS record %s: %s %s %s", record.ID, record.Name, record.Type, record.Content)
+		return p.updateRecord(ctx, domain, id, record)
+	}
+
 	// Check if record already exists
 	existingRecord, err := p.findExistingRecord(ctx, domain, record.Name, record.Type)
 	if err != nil {
@@ -501,13 +678,13 @@ func (p *PorkbunProvider) normalizeSubdomain(name, domain string) string {
 	return name
 }
 
-// denormalizeSubdomain converts Porkbun subdomain format to our record name format
+// denormalizeSubdomain converts Porkbun subdomain format to our record name format.
+// Porkbun returns fully qualified names when retrieving records.
 func (p *PorkbunProvider) denormalizeSubdomain(subdomain, domain string) string {
 	// Handle root domain
-	if subdomain == "" {
+	if subdomain == "" || strings.EqualFold(strings.TrimSuffix(subdomain, "."), domain) {
 		return "@"
 	}
 
-	// Return subdomain as-is for non-root records
-	return subdomain
+	return dns.NormalizeName(subdomain, domain)
 }
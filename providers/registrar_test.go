@@ -0,0 +1,502 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"indietool/cli/domains"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+)
+
+type capturedCall struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+func newCaptureServer(t *testing.T, respond func(w http.ResponseWriter)) (*httptest.Server, *[]capturedCall) {
+	t.Helper()
+	var calls []capturedCall
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := capturedCall{method: r.Method, path: r.URL.Path}
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			json.Unmarshal(data, &call.body)
+		}
+		calls = append(calls, call)
+		respond(w)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestCloudflareUpdateAutoRenewal(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success":true,"errors":[],"messages":[],"result":{}}`)
+	})
+
+	provider := &CloudflareProvider{
+		client: cloudflare.NewClient(
+			option.WithAPIToken("test-token"),
+			option.WithBaseURL(srv.URL+"/"),
+			option.WithMaxRetries(0),
+		),
+		config: CloudflareConfig{AccountId: "acct-1", APIToken: "test-token", Enabled: true},
+	}
+
+	if err := provider.UpdateAutoRenewal(context.Background(), "example.com", false); err != nil {
+		t.Fatalf("UpdateAutoRenewal: %v", err)
+	}
+
+	if len(*calls) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(*calls))
+	}
+	call := (*calls)[0]
+	if call.method != http.MethodPut || call.path != "/accounts/acct-1/registrar/domains/example.com" {
+		t.Errorf("unexpected request %s %s", call.method, call.path)
+	}
+	if call.body["auto_renew"] != false {
+		t.Errorf("auto_renew = %v, want false", call.body["auto_renew"])
+	}
+	if _, ok := call.body["locked"]; ok {
+		t.Error("request should only change auto_renew")
+	}
+}
+
+func TestGoDaddyUpdateAutoRenewal(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Enabled: true})
+	provider.client.baseURL = srv.URL
+
+	if err := provider.UpdateAutoRenewal(context.Background(), "example.com", true); err != nil {
+		t.Fatalf("UpdateAutoRenewal: %v", err)
+	}
+
+	call := (*calls)[0]
+	if call.method != http.MethodPatch || call.path != "/v1/domains/example.com" {
+		t.Errorf("unexpected request %s %s", call.method, call.path)
+	}
+	if call.body["renewAuto"] != true {
+		t.Errorf("renewAuto = %v, want true", call.body["renewAuto"])
+	}
+}
+
+func TestGoDaddyUpdateAutoRenewalError(t *testing.T) {
+	srv, _ := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		io.WriteString(w, `{"code":"INVALID_BODY"}`)
+	})
+
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Enabled: true})
+	provider.client.baseURL = srv.URL
+
+	err := provider.UpdateAutoRenewal(context.Background(), "example.com", true)
+	if err == nil || errors.Is(err, domains.ErrNotSupported) {
+		t.Fatalf("expected a failure that is not ErrNotSupported, got %v", err)
+	}
+}
+
+func TestUnsupportedAutoRenewal(t *testing.T) {
+	for _, registrar := range []domains.Registrar{NewPorkbunProvider(), NewNamecheapProvider()} {
+		err := registrar.UpdateAutoRenewal(context.Background(), "example.com", true)
+		if !errors.Is(err, domains.ErrNotSupported) {
+			t.Errorf("%s: expected ErrNotSupported, got %v", domains.RegistrarName(registrar), err)
+		}
+	}
+}
+
+func TestGoDaddyUpdateNameservers(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Enabled: true})
+	provider.client.baseURL = srv.URL
+
+	nameservers := []string{"fred.ns.cloudflare.com", "pam.ns.cloudflare.com"}
+	if err := provider.UpdateNameservers(context.Background(), "example.com", nameservers); err != nil {
+		t.Fatalf("UpdateNameservers: %v", err)
+	}
+
+	call := (*calls)[0]
+	if call.method != http.MethodPatch || call.path != "/v1/domains/example.com" {
+		t.Errorf("unexpected request %s %s", call.method, call.path)
+	}
+	got, _ := call.body["nameServers"].([]any)
+	if len(got) != 2 || got[0] != nameservers[0] || got[1] != nameservers[1] {
+		t.Errorf("nameServers = %v, want %v", call.body["nameServers"], nameservers)
+	}
+}
+
+func TestCloudflareUpdateNameserversUnsupported(t *testing.T) {
+	err := NewCloudflareProvider().UpdateNameservers(context.Background(), "example.com", []string{"ns1.example.net"})
+	if !errors.Is(err, domains.ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestGoDaddyGetRenewalInfo(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"domain":"example.com","renewAuto":true,"renewal":{"currency":"EUR","price":21990000,"renewable":true}}`)
+	})
+
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Enabled: true})
+	provider.client.baseURL = srv.URL
+
+	cost, err := provider.GetRenewalInfo(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetRenewalInfo: %v", err)
+	}
+	if cost.Currency != "EUR" || cost.RenewalPrice != 21.99 {
+		t.Errorf("cost = %+v, want 21.99 EUR", cost)
+	}
+	if call := (*calls)[0]; call.method != http.MethodGet || call.path != "/v1/domains/example.com" {
+		t.Errorf("unexpected request %s %s", call.method, call.path)
+	}
+}
+
+func TestCloudflareGetRenewalInfo(t *testing.T) {
+	srv, _ := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success":true,"errors":[],"messages":[],"result":[
+			{"id":"1","name":"other.com","fees":{"renewal_fee":9.77}},
+			{"id":"2","name":"example.com","fees":{"renewal_fee":10.44,"transfer_fee":10.44}}
+		]}`)
+	})
+
+	provider := &CloudflareProvider{
+		client: cloudflare.NewClient(
+			option.WithAPIToken("test-token"),
+			option.WithBaseURL(srv.URL+"/"),
+			option.WithMaxRetries(0),
+		),
+		config: CloudflareConfig{AccountId: "acct-1", APIToken: "test-token", Enabled: true},
+	}
+
+	cost, err := provider.GetRenewalInfo(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetRenewalInfo: %v", err)
+	}
+	if cost.Currency != "USD" || cost.RenewalPrice != 10.44 {
+		t.Errorf("cost = %+v, want 10.44 USD", cost)
+	}
+}
+
+func TestNamecheapGetRenewalInfo(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		query = r.Form.Get("Command") + " " + r.Form.Get("ProductCategory") + " " + r.Form.Get("ProductName")
+		w.Header().Set("Content-Type", "text/xml")
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse Type="namecheap.users.getPricing">
+    <UserGetPricingResult>
+      <ProductType Name="domain">
+        <ProductCategory Name="renew">
+          <Product Name="io">
+            <Price Duration="1" DurationType="YEAR" Price="52.98" PricingType="MULTIPLE" AdditionalCost="0.18" RegularPrice="59.98" YourPrice="49.98" CouponPrice="" Currency="USD" />
+            <Price Duration="2" DurationType="YEAR" Price="105.96" PricingType="MULTIPLE" AdditionalCost="0.36" RegularPrice="119.96" YourPrice="99.96" CouponPrice="" Currency="USD" />
+          </Product>
+        </ProductCategory>
+      </ProductType>
+    </UserGetPricingResult>
+  </CommandResponse>
+</ApiResponse>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	nc := NewNamecheap(NamecheapConfig{APIKey: "test-key", Username: "test-user", ClientIP: "192.0.2.100", Enabled: true})
+	nc.client.BaseURL = srv.URL
+
+	cost, err := nc.GetRenewalInfo(context.Background(), "example.io")
+	if err != nil {
+		t.Fatalf("GetRenewalInfo: %v", err)
+	}
+	if query != "namecheap.users.getPricing RENEW io" {
+		t.Errorf("unexpected query %q", query)
+	}
+	if cost.Currency != "USD" || cost.RenewalPrice != 50.16 {
+		t.Errorf("cost = %+v, want 50.16 USD", cost)
+	}
+}
+
+func TestGoDaddyTransferOut(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"domain":"example.com","locked":true,"authCode":"Gd#123"}`)
+	})
+
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Enabled: true})
+	provider.client.baseURL = srv.URL
+
+	locked, err := provider.GetTransferLock(context.Background(), "example.com")
+	if err != nil || !locked {
+		t.Fatalf("GetTransferLock = %v, %v; want locked", locked, err)
+	}
+	code, err := provider.GetAuthCode(context.Background(), "example.com")
+	if err != nil || code != "Gd#123" {
+		t.Fatalf("GetAuthCode = %q, %v", code, err)
+	}
+
+	if err := provider.SetTransferLock(context.Background(), "example.com", false); err != nil {
+		t.Fatalf("SetTransferLock: %v", err)
+	}
+	call := (*calls)[2]
+	if call.method != http.MethodPatch || call.body["locked"] != false {
+		t.Errorf("unexpected request %s %v", call.method, call.body)
+	}
+}
+
+func TestNamecheapTransfer(t *testing.T) {
+	var forms []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form := map[string]string{}
+		for key := range r.Form {
+			form[key] = r.Form.Get(key)
+		}
+		forms = append(forms, form)
+
+		w.Header().Set("Content-Type", "text/xml")
+		result := `<DomainGetRegistrarLockResult Domain="example.com" RegistrarLockStatus="true" />`
+		switch form["Command"] {
+		case "namecheap.domains.setRegistrarLock":
+			result = `<DomainSetRegistrarLockResult Domain="example.com" IsSuccess="true" />`
+		case "namecheap.domains.transfer.create":
+			result = `<DomainTransferCreateResult DomainName="example.com" Transfer="true" TransferID="15" StatusID="-1" OrderID="1234" />`
+		}
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse>`+result+`</CommandResponse>
+</ApiResponse>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	nc := NewNamecheap(NamecheapConfig{APIKey: "test-key", Username: "test-user", ClientIP: "192.0.2.100", Enabled: true})
+	nc.client.BaseURL = srv.URL
+	ctx := context.Background()
+
+	locked, err := nc.GetTransferLock(ctx, "example.com")
+	if err != nil || !locked {
+		t.Fatalf("GetTransferLock = %v, %v; want locked", locked, err)
+	}
+	if err := nc.SetTransferLock(ctx, "example.com", false); err != nil {
+		t.Fatalf("SetTransferLock: %v", err)
+	}
+	if forms[1]["LockAction"] != "UNLOCK" {
+		t.Errorf("LockAction = %q, want UNLOCK", forms[1]["LockAction"])
+	}
+
+	orderID, err := nc.InitiateTransfer(ctx, "example.com", "a&b")
+	if err != nil || orderID != "15" {
+		t.Fatalf("InitiateTransfer = %q, %v", orderID, err)
+	}
+	if forms[2]["EPPCode"] != "base64:YSZi" {
+		t.Errorf("EPPCode = %q, want the base64 encoded auth code", forms[2]["EPPCode"])
+	}
+}
+
+func TestPorkbunTransferLockUnsupported(t *testing.T) {
+	err := NewPorkbunProvider().SetTransferLock(context.Background(), "example.com", false)
+	if !errors.Is(err, domains.ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestNamecheapListPrices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse Type="namecheap.users.getPricing">
+    <UserGetPricingResult>
+      <ProductType Name="domains">
+        <ProductCategory Name="register">
+          <Product Name="com">
+            <Price Duration="1" DurationType="YEAR" Price="10.28" AdditionalCost="0.20" YourPrice="9.58" Currency="USD" />
+            <Price Duration="2" DurationType="YEAR" Price="20.56" AdditionalCost="0.40" YourPrice="19.16" Currency="USD" />
+          </Product>
+        </ProductCategory>
+        <ProductCategory Name="renew">
+          <Product Name="com">
+            <Price Duration="1" DurationType="YEAR" Price="14.58" AdditionalCost="0.20" YourPrice="0" Currency="USD" />
+          </Product>
+        </ProductCategory>
+      </ProductType>
+    </UserGetPricingResult>
+  </CommandResponse>
+</ApiResponse>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	nc := NewNamecheap(NamecheapConfig{APIKey: "test-key", Username: "test-user", ClientIP: "192.0.2.100", Enabled: true})
+	nc.client.BaseURL = srv.URL
+
+	prices, err := nc.ListPrices(context.Background())
+	if err != nil {
+		t.Fatalf("ListPrices: %v", err)
+	}
+	if len(prices) != 1 || prices[0].TLD != "com" || prices[0].Registration != 9.78 || prices[0].Renewal != 14.78 {
+		t.Errorf("prices = %+v, want com at 9.78, renewing at 14.78", prices)
+	}
+}
+
+func TestGoDaddyQuoteDomain(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"available":true,"currency":"USD","definitive":true,"domain":"kopi.com","period":1,"price":11990000}`)
+	})
+
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Enabled: true})
+	provider.client.baseURL = srv.URL
+
+	quote, err := provider.QuoteDomain(context.Background(), "kopi.com")
+	if err != nil {
+		t.Fatalf("QuoteDomain: %v", err)
+	}
+	if quote == nil || quote.Registration != 11.99 || quote.Currency != "USD" {
+		t.Errorf("quote = %+v, want 11.99 USD", quote)
+	}
+	if call := (*calls)[0]; call.path != "/v1/domains/available" {
+		t.Errorf("unexpected request %s %s", call.method, call.path)
+	}
+}
+
+var testContact = domains.Contact{
+	FirstName: "Jane", LastName: "Doe", Email: "jane@example.com", Phone: "+1.5555550100",
+	Address1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "us",
+}
+
+func TestGoDaddyRegister(t *testing.T) {
+	responses := []string{
+		`[{"agreementKey":"DNRA","title":"Domain Name Registration Agreement"}]`,
+		`{"orderId":98765,"itemCount":1,"total":25980000,"currency":"USD"}`,
+	}
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, responses[0])
+		responses = responses[1:]
+	})
+	provider := NewGoDaddy(GoDaddyConfig{APIKey: "key", APISecret: "secret", Environment: "ote", ClientIP: "192.0.2.1", Enabled: true})
+	provider.client.baseURL = srv.URL
+	if !provider.PurchaseInfo().Sandbox {
+		t.Error("OTE environment isn't reported as a sandbox")
+	}
+
+	registration, err := provider.Register(context.Background(), domains.RegistrationRequest{
+		Domain: "kopi.com", Years: 2, Contact: testContact, Nameservers: []string{"ns1.example.net"},
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if registration.OrderID != "98765" || registration.Charged != 25.98 {
+		t.Errorf("registration = %+v", registration)
+	}
+
+	if len(*calls) != 2 || (*calls)[0].path != "/v1/domains/agreements" || (*calls)[1].path != "/v1/domains/purchase" {
+		t.Fatalf("calls = %+v", *calls)
+	}
+	body := (*calls)[1].body
+	consent, _ := body["consent"].(map[string]any)
+	registrant, _ := body["contactRegistrant"].(map[string]any)
+	address, _ := registrant["addressMailing"].(map[string]any)
+	if body["period"] != 2.0 || consent["agreedBy"] != "192.0.2.1" || address["country"] != "US" {
+		t.Errorf("purchase body = %v", body)
+	}
+	if keys, _ := consent["agreementKeys"].([]any); len(keys) != 1 || keys[0] != "DNRA" {
+		t.Errorf("agreement keys = %v, want [DNRA]", consent["agreementKeys"])
+	}
+	if body["renewAuto"] != false {
+		t.Errorf("renewAuto = %v, want false unless the request asks for it", body["renewAuto"])
+	}
+}
+
+func TestNamecheapRegisterPremium(t *testing.T) {
+	var forms []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form := map[string]string{}
+		for key := range r.Form {
+			form[key] = r.Form.Get(key)
+		}
+		forms = append(forms, form)
+
+		w.Header().Set("Content-Type", "text/xml")
+		result := `<DomainCheckResult Domain="kopi.io" Available="true" IsPremiumName="true" PremiumRegistrationPrice="1250.00" PremiumRenewalPrice="62.00" PremiumTransferPrice="62.00" IcannFee="0.18" />`
+		if form["Command"] == "namecheap.domains.create" {
+			result = `<DomainCreateResult Domain="kopi.io" Registered="true" ChargedAmount="1250.1800" OrderID="4321" />`
+		}
+		io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <CommandResponse>`+result+`</CommandResponse>
+</ApiResponse>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	nc := NewNamecheap(NamecheapConfig{APIKey: "test-key", Username: "test-user", ClientIP: "192.0.2.100", Enabled: true})
+	nc.client.BaseURL = srv.URL
+	ctx := context.Background()
+
+	quote, err := nc.QuoteDomain(ctx, "kopi.io")
+	if err != nil {
+		t.Fatalf("QuoteDomain: %v", err)
+	}
+	if quote == nil || !quote.Premium || quote.Registration != 1250 || quote.Fees != 0.18 {
+		t.Fatalf("quote = %+v, want premium 1250 with 0.18 in fees", quote)
+	}
+
+	registration, err := nc.Register(ctx, domains.RegistrationRequest{Domain: "kopi.io", Years: 1, Contact: testContact, Price: quote})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if registration.OrderID != "4321" {
+		t.Errorf("registration = %+v", registration)
+	}
+	create := forms[1]
+	if create["IsPremiumDomain"] != "true" || create["PremiumPrice"] != "1250.00" {
+		t.Errorf("IsPremiumDomain = %q, PremiumPrice = %q; want true and the exact premium registration price", create["IsPremiumDomain"], create["PremiumPrice"])
+	}
+}
+
+func TestPorkbunRegister(t *testing.T) {
+	srv, calls := newCaptureServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":"SUCCESS","domain":"kopi.dev","cost":1108,"orderId":1234567,"balance":5000}`)
+	})
+	provider := NewPorkbun(PorkbunConfig{APIKey: "pk", APISecret: "sk", Enabled: true})
+	provider.httpClient = newRedirectClient(srv)
+
+	if _, err := provider.Register(context.Background(), domains.RegistrationRequest{Domain: "kopi.dev", Years: 1}); err == nil {
+		t.Error("registered without an agreed price")
+	}
+
+	registration, err := provider.Register(context.Background(), domains.RegistrationRequest{
+		Domain: "kopi.dev", Years: 1, Price: &domains.DomainPrice{Currency: "USD", Registration: 11.08},
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if registration.OrderID != "1234567" || registration.Charged != 11.08 {
+		t.Errorf("registration = %+v", registration)
+	}
+	call := (*calls)[0]
+	if call.path != "/api/json/v3/domain/create/kopi.dev" || call.body["cost"] != 1108.0 || call.body["agreeToTerms"] != "yes" || call.body["secretapikey"] != "sk" {
+		t.Errorf("unexpected request %s %v", call.path, call.body)
+	}
+}
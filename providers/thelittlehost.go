@@ -463,14 +463,25 @@ func (t *TheLittleHostProvider) SetRecord(ctx context.Context, domain string, re
 		return fmt.Errorf("failed to get zone for domain %s: %w", domain, err)
 	}
 
+	params := t.convertToTLHParams(record)
+
+	// Update a specific record when the caller picked one by ID
+	if record.ID != "" {
+		id, err := strconv.Atoi(record.ID)
+		if err != nil {
+			return fmt.Errorf("invalid record ID %q: %w", record.ID, err)
+		}
+		log.Debugf("Updating DNS record %s: %s %s %s", record.ID, record.Name, record.Type, record.Content)
+		_, err = t.client.UpdateRecord(ctx, zone.ID, id, params)
+		return err
+	}
+
 	// Check if a record with the same name and type already exists
 	existing, err := t.findExistingRecord(ctx, zone.ID, record.Name, record.Type, domain)
 	if err != nil {
 		return fmt.Errorf("failed to check for existing record: %w", err)
 	}
 
-	params := t.convertToTLHParams(record)
-
 	if existing != nil {
 		log.Debugf("Updating existing DNS record: %s %s %s", record.Name, record.Type, record.Content)
 		_, err = t.client.UpdateRecord(ctx, zone.ID, existing.ID, params)